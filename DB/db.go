@@ -1,11 +1,15 @@
 package db2
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/AryaTabani/Dorivo/models"
 	_ "github.com/go-sql-driver/mysql"
@@ -33,6 +37,7 @@ func InitDB() {
 	DB.SetMaxOpenConns(10)
 	DB.SetMaxIdleConns(5)
 	createTables()
+	migrateTables()
 	createDefaultTenant()
 }
 
@@ -84,7 +89,14 @@ func createTables() {
         id INT PRIMARY KEY AUTO_INCREMENT,
         user_id INT NOT NULL,
         name VARCHAR(255) NOT NULL,
-        address TEXT NOT NULL,
+        street TEXT NOT NULL,
+        unit VARCHAR(64) NOT NULL DEFAULT '',
+        city VARCHAR(100) NOT NULL DEFAULT '',
+        postal_code VARCHAR(20) NOT NULL DEFAULT '',
+        notes VARCHAR(500) NOT NULL DEFAULT '',
+        latitude DECIMAL(9, 6),
+        longitude DECIMAL(9, 6),
+        is_default TINYINT(1) NOT NULL DEFAULT 0,
         FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
     );`
 	_, err = DB.Exec(createUserAddressesTable)
@@ -111,7 +123,7 @@ func createTables() {
         user_id INT NOT NULL,
         tenant_id VARCHAR(191) NOT NULL,
         status VARCHAR(255) NOT NULL,
-        total_price DECIMAL(10, 2) NOT NULL,
+        total_price BIGINT NOT NULL,
         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
         FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
         FOREIGN KEY (tenant_id) REFERENCES tenants(name) ON DELETE CASCADE
@@ -127,7 +139,7 @@ func createTables() {
         order_id INT NOT NULL,
         item_name VARCHAR(255) NOT NULL,
         quantity INT NOT NULL,
-        price DECIMAL(10, 2) NOT NULL,
+        price BIGINT NOT NULL,
         image_url VARCHAR(255),
         FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
     );`
@@ -136,6 +148,19 @@ func createTables() {
 		panic("Failed to create order_items table: " + err.Error())
 	}
 
+	createOrderItemOptionsTable := `
+    CREATE TABLE IF NOT EXISTS order_item_options (
+        id INT PRIMARY KEY AUTO_INCREMENT,
+        order_item_id INT NOT NULL,
+        option_name VARCHAR(255) NOT NULL,
+        price_modifier BIGINT NOT NULL DEFAULT 0,
+        FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE CASCADE
+    );`
+	_, err = DB.Exec(createOrderItemOptionsTable)
+	if err != nil {
+		panic("Failed to create order_item_options table: " + err.Error())
+	}
+
 	createCancellationTable := `
     CREATE TABLE IF NOT EXISTS cancellations (
         id INT PRIMARY KEY AUTO_INCREMENT,
@@ -214,17 +239,37 @@ func createTables() {
 		panic("Failed to create notifications table: " + err.Error())
 	}
 
+	createCategoriesTable := `
+    CREATE TABLE IF NOT EXISTS categories (
+        id INT PRIMARY KEY AUTO_INCREMENT,
+        tenant_id VARCHAR(191) NOT NULL,
+        parent_id INT NULL,
+        name VARCHAR(255) NOT NULL,
+        image_url VARCHAR(255) NOT NULL DEFAULT '',
+        display_order INT NOT NULL DEFAULT 0,
+        is_visible TINYINT(1) NOT NULL DEFAULT 1,
+        available_from TIME NULL,
+        available_until TIME NULL,
+        FOREIGN KEY (tenant_id) REFERENCES tenants(name) ON DELETE CASCADE,
+        FOREIGN KEY (parent_id) REFERENCES categories(id),
+        UNIQUE (tenant_id, name)
+    );`
+	_, err = DB.Exec(createCategoriesTable)
+	if err != nil {
+		panic("Failed to create categories table: " + err.Error())
+	}
+
 	createProductsTable := `
     CREATE TABLE IF NOT EXISTS products (
         id INT PRIMARY KEY AUTO_INCREMENT,
         tenant_id VARCHAR(191) NOT NULL,
         name VARCHAR(255) NOT NULL,
         description TEXT,
-        price DECIMAL(10, 2) NOT NULL,
+        price BIGINT NOT NULL,
         rating DECIMAL(3, 2) DEFAULT 0,
         image_url VARCHAR(255),
         main_category VARCHAR(255) NOT NULL,
-        discount_price DECIMAL(10, 2),
+        discount_price BIGINT,
         is_featured TINYINT(1) DEFAULT 0,
         is_recommended TINYINT(1) DEFAULT 0,
         FOREIGN KEY (tenant_id) REFERENCES tenants(name) ON DELETE CASCADE
@@ -240,7 +285,8 @@ func createTables() {
         tenant_id VARCHAR(150) NOT NULL,
         name VARCHAR(150) NOT NULL,
         main_category VARCHAR(255) NOT NULL,
-        UNIQUE(tenant_id, name)
+        UNIQUE(tenant_id, name),
+        FOREIGN KEY (tenant_id) REFERENCES tenants(name) ON DELETE CASCADE
     );`
 	_, err = DB.Exec(createTagsTable)
 	if err != nil {
@@ -278,7 +324,7 @@ func createTables() {
         id INT PRIMARY KEY AUTO_INCREMENT,
         option_group_id INT NOT NULL,
         name VARCHAR(255) NOT NULL,
-        price_modifier DECIMAL(10, 2) NOT NULL DEFAULT 0,
+        price_modifier BIGINT NOT NULL DEFAULT 0,
         FOREIGN KEY (option_group_id) REFERENCES option_groups(id) ON DELETE CASCADE
     );`
 	_, err = DB.Exec(createOptionsTable)
@@ -336,6 +382,473 @@ func createTables() {
 	if err != nil {
 		panic("Failed to create user_favorites table: " + err.Error())
 	}
+
+	createPromotionsTable := `
+    CREATE TABLE IF NOT EXISTS promotions (
+        id INT PRIMARY KEY AUTO_INCREMENT,
+        tenant_id VARCHAR(191) NOT NULL,
+        code VARCHAR(64) NOT NULL,
+        description TEXT,
+        type VARCHAR(50) NOT NULL,
+        value DECIMAL(10, 2) NOT NULL DEFAULT 0,
+        free_product_id INT,
+        min_basket BIGINT,
+        max_uses INT,
+        max_uses_per_user INT,
+        starts_at TIMESTAMP NULL,
+        ends_at TIMESTAMP NULL,
+        categories JSON,
+        tags JSON,
+        is_active TINYINT(1) DEFAULT 1,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (tenant_id) REFERENCES tenants(name) ON DELETE CASCADE,
+        FOREIGN KEY (free_product_id) REFERENCES products(id) ON DELETE SET NULL,
+        UNIQUE (tenant_id, code)
+    );`
+	_, err = DB.Exec(createPromotionsTable)
+	if err != nil {
+		panic("Failed to create promotions table: " + err.Error())
+	}
+
+	createPromotionRedemptionsTable := `
+    CREATE TABLE IF NOT EXISTS promotion_redemptions (
+        id INT PRIMARY KEY AUTO_INCREMENT,
+        promotion_id INT NOT NULL,
+        user_id INT NOT NULL,
+        order_id INT NOT NULL,
+        code VARCHAR(64) NOT NULL,
+        discount_amount BIGINT NOT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (promotion_id) REFERENCES promotions(id),
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+        FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+    );`
+	_, err = DB.Exec(createPromotionRedemptionsTable)
+	if err != nil {
+		panic("Failed to create promotion_redemptions table: " + err.Error())
+	}
+
+	createOrderStatusHistoryTable := `
+    CREATE TABLE IF NOT EXISTS order_status_history (
+        id INT PRIMARY KEY AUTO_INCREMENT,
+        order_id INT NOT NULL,
+        from_status VARCHAR(255),
+        to_status VARCHAR(255) NOT NULL,
+        changed_by INT,
+        note TEXT,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+        FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE SET NULL
+    );`
+	_, err = DB.Exec(createOrderStatusHistoryTable)
+	if err != nil {
+		panic("Failed to create order_status_history table: " + err.Error())
+	}
+
+	createPaymentsTable := `
+    CREATE TABLE IF NOT EXISTS payments (
+        id INT PRIMARY KEY AUTO_INCREMENT,
+        order_id INT,
+        user_id INT NOT NULL,
+        payment_method_id INT,
+        operation VARCHAR(32) NOT NULL,
+        status VARCHAR(32) NOT NULL,
+        amount BIGINT NOT NULL,
+        gateway_reference VARCHAR(255),
+        failure_reason VARCHAR(255),
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        INDEX idx_payments_order (order_id),
+        FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL,
+        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+        FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id) ON DELETE SET NULL
+    );`
+	_, err = DB.Exec(createPaymentsTable)
+	if err != nil {
+		panic("Failed to create payments table: " + err.Error())
+	}
+
+	createRefundsTable := `
+    CREATE TABLE IF NOT EXISTS refunds (
+        id INT PRIMARY KEY AUTO_INCREMENT,
+        order_id INT NOT NULL,
+        order_item_id INT,
+        quantity INT,
+        amount BIGINT NOT NULL,
+        reason VARCHAR(255) NOT NULL,
+        refunded_by INT,
+        payment_id INT,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+        FOREIGN KEY (order_item_id) REFERENCES order_items(id) ON DELETE SET NULL,
+        FOREIGN KEY (refunded_by) REFERENCES users(id) ON DELETE SET NULL,
+        FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE SET NULL
+    );`
+	_, err = DB.Exec(createRefundsTable)
+	if err != nil {
+		panic("Failed to create refunds table: " + err.Error())
+	}
+
+	createStockReservationsTable := `
+    CREATE TABLE IF NOT EXISTS stock_reservations (
+        id INT PRIMARY KEY AUTO_INCREMENT,
+        order_id INT NOT NULL,
+        product_id INT,
+        option_id INT,
+        quantity INT NOT NULL,
+        released TINYINT(1) NOT NULL DEFAULT 0,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE,
+        FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE SET NULL,
+        FOREIGN KEY (option_id) REFERENCES options(id) ON DELETE SET NULL
+    );`
+	_, err = DB.Exec(createStockReservationsTable)
+	if err != nil {
+		panic("Failed to create stock_reservations table: " + err.Error())
+	}
+
+	createOrderChargesTable := `
+    CREATE TABLE IF NOT EXISTS order_charges (
+        id INT PRIMARY KEY AUTO_INCREMENT,
+        order_id INT NOT NULL,
+        kind VARCHAR(32) NOT NULL,
+        name VARCHAR(64) NOT NULL,
+        rate DECIMAL(7, 4),
+        inclusive TINYINT(1) NOT NULL DEFAULT 0,
+        amount BIGINT NOT NULL,
+        FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+    );`
+	_, err = DB.Exec(createOrderChargesTable)
+	if err != nil {
+		panic("Failed to create order_charges table: " + err.Error())
+	}
+}
+
+func migrateTables() {
+	// Money used to be stored as DECIMAL(10, 2) in major units; it is now a BIGINT of minor units. This runs first so the
+	// migrations below already see integer amounts. Each amount is scaled by the currency of the tenant it belongs to,
+	// which is found through the given expression on the row being converted.
+	const (
+		productTenant = "(SELECT p.tenant_id FROM products p WHERE p.id = x.product_id)"
+		orderTenant   = "(SELECT o.tenant_id FROM orders o WHERE o.id = x.order_id)"
+	)
+	convertToMinorUnits("products", "price", "NOT NULL", "x.tenant_id")
+	convertToMinorUnits("products", "discount_price", "NULL", "x.tenant_id")
+	convertToMinorUnits("options", "price_modifier", "NOT NULL DEFAULT 0",
+		"(SELECT p.tenant_id FROM option_groups og JOIN products p ON p.id = og.product_id WHERE og.id = x.option_group_id)")
+	convertToMinorUnits("cart_items", "unit_price", "NULL", productTenant)
+	convertToMinorUnits("cart_item_options", "price_modifier", "NULL",
+		"(SELECT p.tenant_id FROM cart_items ci JOIN products p ON p.id = ci.product_id WHERE ci.id = x.cart_item_id)")
+	convertToMinorUnits("orders", "total_price", "NOT NULL", "x.tenant_id")
+	convertToMinorUnits("orders", "discount_total", "NOT NULL DEFAULT 0", "x.tenant_id")
+	convertToMinorUnits("orders", "refunded_total", "NOT NULL DEFAULT 0", "x.tenant_id")
+	convertToMinorUnits("order_items", "price", "NOT NULL", orderTenant)
+	convertToMinorUnits("order_item_options", "price_modifier", "NOT NULL DEFAULT 0",
+		"(SELECT o.tenant_id FROM order_items oi JOIN orders o ON o.id = oi.order_id WHERE oi.id = x.order_item_id)")
+	convertToMinorUnits("promotions", "min_basket", "NULL", "x.tenant_id")
+	convertToMinorUnits("promotion_redemptions", "discount_amount", "NOT NULL", "(SELECT p.tenant_id FROM promotions p WHERE p.id = x.promotion_id)")
+	convertToMinorUnits("payments", "amount", "NOT NULL", orderTenant)
+	convertToMinorUnits("refunds", "amount", "NOT NULL", orderTenant)
+	addColumnIfMissing("orders", "currency", "CHAR(3) NOT NULL DEFAULT 'USD'")
+	addColumnIfMissing("promotions", "amount", "BIGINT NOT NULL DEFAULT 0")
+	// Fixed amount promotions kept their discount in value; it moves to amount, leaving value for percentages.
+	migrateData("promotions", fmt.Sprintf("UPDATE promotions x SET x.amount = ROUND(x.value * %s), x.value = 0 WHERE x.type = 'fixed_amount' AND x.value <> 0",
+		minorUnitScale("x.tenant_id")))
+
+	addColumnIfMissing("carts", "promotion_id", "INT NULL, ADD FOREIGN KEY (promotion_id) REFERENCES promotions(id) ON DELETE SET NULL")
+	addColumnIfMissing("orders", "discount_total", "BIGINT NOT NULL DEFAULT 0")
+	addColumnIfMissing("orders", "tax_total", "BIGINT NOT NULL DEFAULT 0")
+	addColumnIfMissing("orders", "fee_total", "BIGINT NOT NULL DEFAULT 0")
+	addColumnIfMissing("orders", "fulfilment_type", "VARCHAR(32) NOT NULL DEFAULT 'delivery'")
+	addColumnIfMissing("orders", "table_number", "VARCHAR(32) NULL")
+	addColumnIfMissing("orders", "delivery_zone", "VARCHAR(64) NULL")
+	addColumnIfMissing("orders", "eta_minutes", "INT NULL")
+	addColumnIfMissing("orders", "scheduled_for", "DATETIME NULL, ADD INDEX idx_orders_scheduled_for (tenant_id, scheduled_for)")
+	// Slot capacity is shared by scheduled orders and orders made as soon as possible, so every order records the slot
+	// it takes up. Orders from before only took up a slot when they were scheduled.
+	if !columnExists("orders", "slot_start") {
+		addColumnIfMissing("orders", "slot_start", "DATETIME NULL, ADD INDEX idx_orders_slot_start (tenant_id, slot_start)")
+		migrateData("orders", "UPDATE orders SET slot_start = scheduled_for WHERE scheduled_for IS NOT NULL")
+	}
+	addColumnIfMissing("user_addresses", "latitude", "DECIMAL(9, 6) NULL")
+	addColumnIfMissing("user_addresses", "longitude", "DECIMAL(9, 6) NULL")
+	addColumnIfMissing("orders", "promo_code", "VARCHAR(64) NULL")
+	addColumnIfMissing("orders", "refunded_total", "BIGINT NOT NULL DEFAULT 0")
+	addColumnIfMissing("orders", "address_id", "INT NULL, ADD FOREIGN KEY (address_id) REFERENCES user_addresses(id) ON DELETE SET NULL")
+	addColumnIfMissing("orders", "payment_method_id", "INT NULL, ADD FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id) ON DELETE SET NULL")
+	addColumnIfMissing("payment_methods", "expiry_reminder_sent", "TINYINT(1) NOT NULL DEFAULT 0")
+	addColumnIfMissing("products", "is_available", "TINYINT(1) NOT NULL DEFAULT 1")
+	addColumnIfMissing("products", "stock_quantity", "INT NULL")
+	addColumnIfMissing("products", "low_stock_threshold", "INT NOT NULL DEFAULT 5")
+	addColumnIfMissing("options", "is_available", "TINYINT(1) NOT NULL DEFAULT 1")
+	addColumnIfMissing("options", "stock_quantity", "INT NULL")
+	addColumnIfMissing("option_groups", "min_selections", "INT NOT NULL DEFAULT 0")
+	addColumnIfMissing("option_groups", "max_selections", "INT NULL")
+	addColumnIfMissing("option_groups", "is_required", "TINYINT(1) NOT NULL DEFAULT 0")
+	addColumnIfMissing("option_groups", "sort_order", "INT NOT NULL DEFAULT 0")
+	addColumnIfMissing("options", "is_default", "TINYINT(1) NOT NULL DEFAULT 0")
+	addColumnIfMissing("options", "sort_order", "INT NOT NULL DEFAULT 0")
+	addColumnIfMissing("notifications", "content", "TEXT NULL")
+	addColumnIfMissing("user_favorites", "created_at", "TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP")
+
+	// Guest carts and guest orders have no user; guest carts are found by the hash of their cart token instead.
+	if !columnIsNullable("carts", "user_id") {
+		modifyColumn("carts", "user_id", "INT NULL")
+	}
+	addColumnIfMissing("carts", "tenant_id", "VARCHAR(191) NULL, ADD FOREIGN KEY (tenant_id) REFERENCES tenants(name) ON DELETE CASCADE")
+	addColumnIfMissing("carts", "guest_token_hash", "CHAR(64) NULL, ADD UNIQUE (guest_token_hash)")
+	addColumnIfMissing("carts", "expires_at", "TIMESTAMP NULL")
+	if !columnIsNullable("orders", "user_id") {
+		modifyColumn("orders", "user_id", "INT NULL")
+	}
+	addColumnIfMissing("orders", "guest_email", "VARCHAR(150) NULL")
+	addColumnIfMissing("orders", "guest_phone", "VARCHAR(50) NULL")
+
+	// Cart lines keep the prices they were added at so price changes can be shown before checkout.
+	addColumnIfMissing("cart_items", "unit_price", "BIGINT NULL")
+	addColumnIfMissing("cart_item_options", "price_modifier", "BIGINT NULL")
+	migrateData("cart_items", "UPDATE cart_items ci JOIN products p ON ci.product_id = p.id SET ci.unit_price = CASE WHEN p.discount_price IS NOT NULL AND p.discount_price < p.price THEN p.discount_price ELSE p.price END WHERE ci.unit_price IS NULL")
+	migrateData("cart_item_options", "UPDATE cart_item_options cio JOIN options o ON cio.option_id = o.id SET cio.price_modifier = o.price_modifier WHERE cio.price_modifier IS NULL")
+	if columnDataType("cart_items", "unit_price") != "bigint" || columnIsNullable("cart_items", "unit_price") {
+		modifyColumn("cart_items", "unit_price", "BIGINT NOT NULL")
+	}
+	if columnDataType("cart_item_options", "price_modifier") != "bigint" || columnIsNullable("cart_item_options", "price_modifier") {
+		modifyColumn("cart_item_options", "price_modifier", "BIGINT NOT NULL")
+	}
+
+	// Addresses used to be a single free-text line, which becomes the street of a structured address as a whole, so
+	// street is as wide as that line was; each user's newest address becomes their default. Orders keep a copy of their delivery address so later edits do not
+	// rewrite them.
+	addColumnIfMissing("user_addresses", "street", "TEXT NOT NULL")
+	addColumnIfMissing("user_addresses", "unit", "VARCHAR(64) NOT NULL DEFAULT ''")
+	addColumnIfMissing("user_addresses", "city", "VARCHAR(100) NOT NULL DEFAULT ''")
+	addColumnIfMissing("user_addresses", "postal_code", "VARCHAR(20) NOT NULL DEFAULT ''")
+	addColumnIfMissing("user_addresses", "notes", "VARCHAR(500) NOT NULL DEFAULT ''")
+	addColumnIfMissing("user_addresses", "is_default", "TINYINT(1) NOT NULL DEFAULT 0")
+	if columnExists("user_addresses", "address") {
+		if columnDataType("user_addresses", "street") != "text" {
+			modifyColumn("user_addresses", "street", "TEXT NOT NULL")
+		}
+		migrateData("user_addresses", "UPDATE user_addresses SET street = address")
+		migrateData("user_addresses", "UPDATE user_addresses a JOIN (SELECT MAX(id) AS id FROM user_addresses GROUP BY user_id HAVING MAX(is_default) = 0) newest ON a.id = newest.id SET a.is_default = 1")
+		dropColumn("user_addresses", "address")
+	}
+	if !columnExists("orders", "delivery_address") {
+		addColumnIfMissing("orders", "delivery_address", "JSON NULL")
+		migrateData("orders", `UPDATE orders o JOIN user_addresses a ON o.address_id = a.id SET o.delivery_address = JSON_OBJECT(
+			'id', a.id, 'name', a.name, 'street', a.street, 'unit', a.unit, 'city', a.city, 'postal_code', a.postal_code,
+			'notes', a.notes, 'latitude', a.latitude, 'longitude', a.longitude)`)
+	}
+
+	// Tags were the only tenant data without a foreign key to the tenant. Tags left behind by deleted tenants go first.
+	if !foreignKeyExists("tags", "tenant_id") {
+		migrateData("tags", "DELETE FROM tags WHERE tenant_id NOT IN (SELECT name FROM tenants)")
+		migrateData("tags", "ALTER TABLE tags ADD FOREIGN KEY (tenant_id) REFERENCES tenants(name) ON DELETE CASCADE")
+	}
+
+	// Categories were free text on products and tags. Every distinct name becomes a category of its tenant, and
+	// main_category stays on both as a copy of the category's name that the services keep in sync. Blank names go to
+	// an "Uncategorized" category; earlier runs of this migration gave them a category without a name, which is renamed.
+	if !columnExists("products", "category_id") {
+		migrateData("categories", `INSERT IGNORE INTO categories (tenant_id, name)
+			SELECT DISTINCT tenant_id, COALESCE(NULLIF(TRIM(main_category), ''), 'Uncategorized') FROM products
+			UNION SELECT DISTINCT tenant_id, COALESCE(NULLIF(TRIM(main_category), ''), 'Uncategorized') FROM tags`)
+		addColumnIfMissing("products", "category_id", "INT NULL, ADD FOREIGN KEY (category_id) REFERENCES categories(id)")
+		migrateData("products", `UPDATE products p JOIN categories c ON c.tenant_id = p.tenant_id AND c.name = COALESCE(NULLIF(TRIM(p.main_category), ''), 'Uncategorized')
+			SET p.category_id = c.id, p.main_category = c.name`)
+	}
+	if !columnExists("tags", "category_id") {
+		addColumnIfMissing("tags", "category_id", "INT NULL, ADD FOREIGN KEY (category_id) REFERENCES categories(id)")
+		migrateData("tags", `UPDATE tags t JOIN categories c ON c.tenant_id = t.tenant_id AND c.name = COALESCE(NULLIF(TRIM(t.main_category), ''), 'Uncategorized')
+			SET t.category_id = c.id, t.main_category = c.name`)
+	}
+	migrateData("categories", "UPDATE IGNORE categories SET name = 'Uncategorized' WHERE name = ''")
+	migrateData("products", "UPDATE products p JOIN categories c ON p.category_id = c.id SET p.main_category = c.name WHERE TRIM(p.main_category) = ''")
+	migrateData("tags", "UPDATE tags t JOIN categories c ON t.category_id = c.id SET t.main_category = c.name WHERE TRIM(t.main_category) = ''")
+
+	// Order lines only kept names. They now point at the product and options they were ordered from; older lines are
+	// matched by name once, which is the best that can be done for them. The columns are added here rather than in
+	// createTables because products and options are created after the order tables.
+	if !columnExists("order_items", "product_id") {
+		addColumnIfMissing("order_items", "product_id", "INT NULL, ADD FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE SET NULL")
+		migrateData("order_items", "UPDATE order_items oi JOIN orders o ON oi.order_id = o.id JOIN products p ON p.tenant_id = o.tenant_id AND p.name = oi.item_name SET oi.product_id = p.id")
+	}
+	if !columnExists("order_item_options", "option_id") {
+		addColumnIfMissing("order_item_options", "option_id", "INT NULL, ADD FOREIGN KEY (option_id) REFERENCES options(id) ON DELETE SET NULL")
+		migrateData("order_item_options", "UPDATE order_item_options oio JOIN order_items oi ON oio.order_item_id = oi.id JOIN option_groups og ON og.product_id = oi.product_id JOIN options o ON o.option_group_id = og.id AND o.name = oio.option_name SET oio.option_id = o.id")
+	}
+
+	// Keyword search runs on a lower-cased copy of each product's name, description and tags in which the Arabic yeh and
+	// kaf are folded into their Persian forms. The ngram parser indexes it in two-character pieces, so misspelled words
+	// still find candidates. The repository rewrites the copy whenever a product or its tags change. The first version
+	// of the index was built with InnoDB's stopwords and is replaced.
+	if !columnExists("products", "search_text") {
+		addColumnIfMissing("products", "search_text", "TEXT NULL")
+		migrateData("products", `UPDATE products p SET p.search_text = REPLACE(REPLACE(REPLACE(LOWER(CONCAT_WS(' ', p.name, p.description,
+			(SELECT GROUP_CONCAT(t.name SEPARATOR ' ') FROM product_tags pt JOIN tags t ON pt.tag_id = t.id WHERE pt.product_id = p.id))),
+			'ي', 'ی'), 'ى', 'ی'), 'ك', 'ک')`)
+	}
+	if indexExists("products", "ft_products_search_text") {
+		migrateData("products", "ALTER TABLE products DROP INDEX ft_products_search_text")
+	}
+	if !indexExists("products", "ft_products_search") {
+		addNgramIndex("products", "ft_products_search", "search_text")
+	}
+
+	// Orders used to be created as 'Active'; the status lifecycle now starts at 'Pending'.
+	migrateData("orders", "UPDATE orders SET status = 'Pending' WHERE status = 'Active'")
+	migrateData("order_status_history", "UPDATE order_status_history SET to_status = 'Pending' WHERE to_status = 'Active'")
+	migrateData("order_status_history", "UPDATE order_status_history SET from_status = 'Pending' WHERE from_status = 'Active'")
+
+	// Selection types were free text; only 'single' and 'multiple' are accepted now and single choice means at most one.
+	migrateData("option_groups", "UPDATE option_groups SET selection_type = IF(LOWER(selection_type) = 'single', 'single', 'multiple') WHERE selection_type NOT IN ('single', 'multiple')")
+	migrateData("option_groups", "UPDATE option_groups SET max_selections = 1 WHERE selection_type = 'single' AND max_selections IS NULL")
+}
+
+func migrateData(table, statement string) {
+	if _, err := DB.Exec(statement); err != nil {
+		panic("Failed to migrate " + table + " table: " + err.Error())
+	}
+}
+
+func modifyColumn(table, column, definition string) {
+	_, err := DB.Exec(fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s", table, column, definition))
+	if err != nil {
+		panic("Failed to modify " + column + " column of " + table + " table: " + err.Error())
+	}
+}
+
+// convertToMinorUnits turns a DECIMAL money column into a BIGINT of minor units. Existing amounts are in the major unit
+// of their tenant's currency, which tenantExpr finds for a row aliased x. Missing columns and columns that are no
+// longer DECIMAL are left alone. The scaled amounts are written to a new column that replaces the old one in a single
+// ALTER, so a conversion that is interrupted leaves the DECIMAL amounts untouched and is simply redone on the next start.
+func convertToMinorUnits(table, column, nullability, tenantExpr string) {
+	var dataType string
+	query := `SELECT DATA_TYPE FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`
+	err := DB.QueryRow(query, table, column).Scan(&dataType)
+	if err == sql.ErrNoRows || (err == nil && dataType != "decimal") {
+		return
+	}
+	if err != nil {
+		panic("Failed to inspect " + column + " column of " + table + " table: " + err.Error())
+	}
+	scaled := column + "_minor"
+	addColumnIfMissing(table, scaled, "BIGINT NULL AFTER "+column)
+	migrateData(table, fmt.Sprintf("UPDATE %s x SET x.%s = ROUND(x.%s * %s)", table, scaled, column, minorUnitScale(tenantExpr)))
+	migrateData(table, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s, CHANGE COLUMN %s %s BIGINT %s", table, column, scaled, column, nullability))
+}
+
+// minorUnitScale returns the SQL for the number of minor units in a major unit of the currency of the tenant named by
+// tenantExpr. Tenants without a currency use the default one.
+func minorUnitScale(tenantExpr string) string {
+	currency := fmt.Sprintf("UPPER(COALESCE((SELECT JSON_UNQUOTE(JSON_EXTRACT(t.config, '$.currency')) FROM tenants t WHERE t.name = %s), '%s'))",
+		tenantExpr, models.DefaultCurrency)
+	exponents := models.CurrencyExponents()
+	codes := slices.Sorted(maps.Keys(exponents))
+	var cases strings.Builder
+	for _, code := range codes {
+		fmt.Fprintf(&cases, " WHEN '%s' THEN %d", code, minorUnitsPerMajor(exponents[code]))
+	}
+	// Scaling by an integer keeps the DECIMAL arithmetic exact, so ROUND rounds half away from zero.
+	return fmt.Sprintf("(CASE %s%s ELSE %d END)", currency, cases.String(), minorUnitsPerMajor(2))
+}
+
+func minorUnitsPerMajor(exponent int) int {
+	scale := 1
+	for range exponent {
+		scale *= 10
+	}
+	return scale
+}
+
+func columnExists(table, column string) bool {
+	var count int
+	query := `SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`
+	err := DB.QueryRow(query, table, column).Scan(&count)
+	if err != nil {
+		panic("Failed to inspect " + table + " table: " + err.Error())
+	}
+	return count > 0
+}
+
+func columnDataType(table, column string) string {
+	var dataType string
+	query := `SELECT DATA_TYPE FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`
+	err := DB.QueryRow(query, table, column).Scan(&dataType)
+	if err != nil {
+		panic("Failed to inspect " + table + " table: " + err.Error())
+	}
+	return dataType
+}
+
+func columnIsNullable(table, column string) bool {
+	var nullable string
+	query := `SELECT IS_NULLABLE FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?`
+	err := DB.QueryRow(query, table, column).Scan(&nullable)
+	if err != nil {
+		panic("Failed to inspect " + table + " table: " + err.Error())
+	}
+	return nullable == "YES"
+}
+
+func indexExists(table, index string) bool {
+	var count int
+	query := `SELECT COUNT(*) FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME = ?`
+	err := DB.QueryRow(query, table, index).Scan(&count)
+	if err != nil {
+		panic("Failed to inspect " + table + " table: " + err.Error())
+	}
+	return count > 0
+}
+
+func foreignKeyExists(table, column string) bool {
+	var count int
+	query := `SELECT COUNT(*) FROM information_schema.KEY_COLUMN_USAGE WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL`
+	err := DB.QueryRow(query, table, column).Scan(&count)
+	if err != nil {
+		panic("Failed to inspect " + table + " table: " + err.Error())
+	}
+	return count > 0
+}
+
+func addColumnIfMissing(table, column, definition string) {
+	if columnExists(table, column) {
+		return
+	}
+
+	_, err := DB.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	if err != nil {
+		panic("Failed to add " + column + " column to " + table + " table: " + err.Error())
+	}
+}
+
+// addNgramIndex adds a FULLTEXT index with the ngram parser and without stopwords. InnoDB's default stopword list holds
+// two-letter words such as "in" and "on", and the ngram parser leaves out every piece containing a stopword, so many
+// pieces of ordinary words could never match. An index keeps the stopword setting it was created with, so stopwords
+// are only turned off on the connection that creates it and no server configuration is needed.
+func addNgramIndex(table, index, column string) {
+	ctx := context.Background()
+	conn, err := DB.Conn(ctx)
+	if err != nil {
+		panic("Failed to add " + index + " index to " + table + " table: " + err.Error())
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SET SESSION innodb_ft_enable_stopword = OFF"); err != nil {
+		panic("Failed to add " + index + " index to " + table + " table: " + err.Error())
+	}
+	defer conn.ExecContext(ctx, "SET SESSION innodb_ft_enable_stopword = ON")
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD FULLTEXT INDEX %s (%s) WITH PARSER ngram", table, index, column))
+	if err != nil {
+		panic("Failed to add " + index + " index to " + table + " table: " + err.Error())
+	}
+}
+
+func dropColumn(table, column string) {
+	_, err := DB.Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, column))
+	if err != nil {
+		panic("Failed to drop " + column + " column of " + table + " table: " + err.Error())
+	}
 }
 
 func createDefaultTenant() {
@@ -367,6 +880,8 @@ func createDefaultTenant() {
 				Facebook:        "https://facebook.com/example",
 				Instagram:       "https://instagram.com/example",
 			},
+			Currency: models.DefaultCurrency,
+			Rounding: models.DefaultRounding,
 		}
 
 		configJSON, err := json.Marshal(defaultConfig)
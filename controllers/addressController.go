@@ -12,13 +12,13 @@ import (
 
 // AddAddressHandler godoc
 // @Summary      Add a new address
-// @Description  Adds a new delivery address to the authenticated user's profile.
+// @Description  Adds a new delivery address to the authenticated user's profile. Street and city are required. Latitude and longitude are optional but must be given together; stores with delivery zones only deliver to addresses that have them. The first address, or one sent with is_default, becomes the default address.
 // @Tags         User & Profile
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        address body     models.AddAddressPayload true "Address Information"
-// @Success      201     {object} models.APIResponse[any] "Address added successfully"
+// @Success      201     {object} models.APIResponse[models.Address] "Address added successfully"
 // @Failure      400     {object} models.APIResponse[any] "Invalid request body"
 // @Failure      500     {object} models.APIResponse[any] "Failed to add new address"
 // @Router       /addresses [post]
@@ -32,19 +32,62 @@ func AddAddressHandler() gin.HandlerFunc {
 			return
 		}
 
-		err := services.AddAddress(c.Request.Context(), userID, &payload)
+		address, err := services.AddAddress(c.Request.Context(), userID, &payload)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to add new address"})
 			return
 		}
 
-		c.JSON(http.StatusCreated, models.APIResponse[any]{Success: true, Message: "Address added successfully"})
+		c.JSON(http.StatusCreated, models.APIResponse[*models.Address]{Success: true, Message: "Address added successfully", Data: address})
+	}
+}
+
+// UpdateAddressHandler godoc
+// @Summary      Update an address
+// @Description  Replaces the fields of an address belonging to the authenticated user. Setting is_default makes it the default address and leaving it out keeps its current mark; orders already placed keep the address they were placed with.
+// @Tags         User & Profile
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        addressId path     int                      true "Address ID"
+// @Param        address   body     models.AddAddressPayload true "Address Information"
+// @Success      200       {object} models.APIResponse[models.Address] "Address updated successfully"
+// @Failure      400       {object} models.APIResponse[any] "Invalid address ID or request body"
+// @Failure      404       {object} models.APIResponse[any] "Address not found"
+// @Failure      500       {object} models.APIResponse[any] "Failed to update address"
+// @Router       /addresses/{addressId} [put]
+func UpdateAddressHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt64("userID")
+		addressID, err := strconv.ParseInt(c.Param("addressId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid address ID"})
+			return
+		}
+
+		var payload models.AddAddressPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		address, err := services.UpdateAddress(c.Request.Context(), userID, addressID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrAddressNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update address"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[*models.Address]{Success: true, Message: "Address updated successfully", Data: address})
 	}
 }
 
 // GetAddressesHandler godoc
 // @Summary      Get user's addresses
-// @Description  Retrieves a list of all saved delivery addresses for the authenticated user.
+// @Description  Retrieves a list of all saved delivery addresses for the authenticated user, the default address first.
 // @Tags         User & Profile
 // @Produce      json
 // @Security     BearerAuth
@@ -67,7 +110,7 @@ func GetAddressesHandler() gin.HandlerFunc {
 
 // DeleteAddressHandler godoc
 // @Summary      Delete an address
-// @Description  Deletes a specific address belonging to the authenticated user.
+// @Description  Deletes a specific address belonging to the authenticated user. When it was the default, the newest remaining address becomes the default.
 // @Tags         User & Profile
 // @Produce      json
 // @Security     BearerAuth
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -11,7 +12,7 @@ import (
 
 // CreateProductHandler godoc
 // @Summary      Create a new product
-// @Description  Allows a tenant admin to create a new product for their store.
+// @Description  Allows a tenant admin to create a new product for their store. Prices are in minor units of the store's currency. The product goes into an existing category, given by category_id or by name in main_category. Tags are given by name; tags the store does not have yet are created in the product's category.
 // @Tags         Admin Panel - Product Management
 // @Accept       json
 // @Produce      json
@@ -19,7 +20,7 @@ import (
 // @Param        tenantId path     string                true "Tenant ID"
 // @Param        product  body     models.ProductPayload true "New Product Data"
 // @Success      201      {object} models.APIResponse[models.CreateProductResponse] "Product created successfully"
-// @Failure      400      {object} models.APIResponse[any] "Invalid request body"
+// @Failure      400      {object} models.APIResponse[any] "Invalid request body, tag or category"
 // @Failure      403      {object} models.APIResponse[any] "Forbidden"
 // @Failure      500      {object} models.APIResponse[any] "Failed to create product"
 // @Router       /{tenantId}/admin/products [post]
@@ -40,6 +41,10 @@ func CreateProductHandler() gin.HandlerFunc {
 		}
 		productID, err := services.CreateProduct(c.Request.Context(), tenantId, &payload)
 		if err != nil {
+			if errors.Is(err, services.ErrInvalidTag) || errors.Is(err, services.ErrInvalidCategory) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			response := models.APIResponse[any]{
 				Success: false,
 				Message: "Could not create Product",
@@ -57,7 +62,7 @@ func CreateProductHandler() gin.HandlerFunc {
 
 // UpdateProductHandler godoc
 // @Summary      Update an existing product
-// @Description  Allows a tenant admin to update the details of an existing product.
+// @Description  Allows a tenant admin to update the details of an existing product. Listing tags replaces the product's tags, creating missing ones; leaving them out keeps the current tags.
 // @Tags         Admin Panel - Product Management
 // @Accept       json
 // @Produce      json
@@ -66,8 +71,9 @@ func CreateProductHandler() gin.HandlerFunc {
 // @Param        productId path     int                   true "Product ID"
 // @Param        product   body     models.ProductPayload true "Updated Product Data"
 // @Success      201      {object} models.APIResponse[models.CreateProductResponse] "Product created successfully"
-// @Failure      400       {object} models.APIResponse[any] "Invalid request body"
+// @Failure      400       {object} models.APIResponse[any] "Invalid request body, tag or category"
 // @Failure      403       {object} models.APIResponse[any] "Forbidden"
+// @Failure      404       {object} models.APIResponse[any] "Product not found"
 // @Failure      500       {object} models.APIResponse[any] "Failed to update product"
 // @Router       /{tenantId}/admin/products/{productId} [put]
 func UpdateProductHandler() gin.HandlerFunc {
@@ -83,6 +89,14 @@ func UpdateProductHandler() gin.HandlerFunc {
 
 		err := services.UpdateProduct(c.Request.Context(), tenantID, productID, &payload)
 		if err != nil {
+			if errors.Is(err, services.ErrInvalidTag) || errors.Is(err, services.ErrInvalidCategory) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrProductNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update product"})
 			return
 		}
@@ -120,7 +134,7 @@ func DeleteProductHandler() gin.HandlerFunc {
 
 // UpdateTenantConfigHandler godoc
 // @Summary      Update tenant configuration
-// @Description  Allows a tenant admin to update their own store's configuration (e.g., name, theme, contact info).
+// @Description  Allows a tenant admin to update their own store's configuration (e.g., name, theme, contact info, currency and rounding rule). All prices are kept in minor units of the currency, so the currency can only be changed while the store has no products, promotions or orders; leaving it out keeps the current one. Taxes (inclusive or exclusive, optionally limited to main categories), service charges and fixed fees configured here apply to carts and new orders; placed orders keep the charges they were placed with. fulfilmentTypes limits how orders are fulfilled (delivery and pickup by default); deliveryZones, each a radiusKm around the store location or a polygon, set where the store delivers with a fee, minimum order and ETA per zone. openingHours (per weekday, in timezone), closures, prepMinutes, slotMinutes, scheduleDays and slotCapacity decide when orders are taken and which slots can be scheduled.
 // @Tags         Admin Panel - Configuration
 // @Accept       json
 // @Produce      json
@@ -128,8 +142,10 @@ func DeleteProductHandler() gin.HandlerFunc {
 // @Param        tenantId path     string              true "Tenant ID"
 // @Param        config   body     models.TenantConfig true "Updated Tenant Configuration"
 // @Success      200      {object} models.APIResponse[any] "Tenant configuration updated successfully"
-// @Failure      400      {object} models.APIResponse[any] "Invalid request body"
+// @Failure      400      {object} models.APIResponse[any] "Invalid request body or delivery zone"
 // @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      404      {object} models.APIResponse[any] "Tenant not found"
+// @Failure      409      {object} models.APIResponse[any] "The currency cannot be changed"
 // @Failure      500      {object} models.APIResponse[any] "Failed to update tenant configuration"
 // @Router       /{tenantId}/admin/config [put]
 func UpdateTenantConfigHandler() gin.HandlerFunc {
@@ -144,6 +160,18 @@ func UpdateTenantConfigHandler() gin.HandlerFunc {
 
 		err := services.UpdateTenantConfig(c.Request.Context(), tenantID, &payload)
 		if err != nil {
+			if errors.Is(err, services.ErrInvalidDeliveryZone) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrTenantNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrCurrencyLocked) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update tenant configuration"})
 			return
 		}
@@ -154,34 +182,84 @@ func UpdateTenantConfigHandler() gin.HandlerFunc {
 
 // GetTenantOrdersHandler godoc
 // @Summary      Get all orders for the tenant
-// @Description  Allows a tenant admin to view all orders placed for their store, filterable by status.
+// @Description  Allows a tenant admin to view all orders placed for their store, filterable by one or more statuses. Without a filter all open orders are returned.
 // @Tags         Admin Panel - Order Management
 // @Produce      json
 // @Security     BearerAuth
-// @Param        tenantId path     string true "Tenant ID"
-// @Param        status   query    string false "Filter orders by status (e.g., Active, Completed, Cancelled)"
+// @Param        tenantId path     string   true  "Tenant ID"
+// @Param        status   query    []string false "Filter orders by status, repeated or comma separated (e.g., Pending,Preparing or Active for all open orders)" collectionFormat(multi)
+// @Param        limit    query    int      false "Page size (default 20, at most 100)"
+// @Param        cursor   query    string   false "Cursor from the previous page's pagination.next_cursor"
+// @Param        sort     query    string   false "Comma separated sort fields: newest (default), price; prefix a field with - to reverse it"
 // @Success      200      {object} models.APIResponse[[]models.Order]
+// @Failure      400      {object} models.APIResponse[any] "Invalid order status, page size, sort or cursor"
 // @Failure      403      {object} models.APIResponse[any] "Forbidden"
 // @Failure      500      {object} models.APIResponse[any] "Failed to retrieve orders"
 // @Router       /{tenantId}/admin/orders [get]
 func GetTenantOrdersHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tenantID := c.Param("tenantId")
-		status := c.Query("status")
+		statuses := c.QueryArray("status")
+		page, err := pageRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+			return
+		}
 
-		orders, err := services.GetTenantOrders(c.Request.Context(), tenantID, status)
+		orders, err := services.GetTenantOrders(c.Request.Context(), tenantID, statuses, page)
 		if err != nil {
+			if errors.Is(err, services.ErrInvalidOrderStatus) || isPageError(err) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve orders"})
 			return
 		}
 
-		c.JSON(http.StatusOK, models.APIResponse[[]models.Order]{Success: true, Data: orders})
+		c.JSON(http.StatusOK, models.APIResponse[[]models.Order]{Success: true, Data: orders.Items, Pagination: &orders.Pagination})
+	}
+}
+
+// AdminGetOrderDetailsHandler godoc
+// @Summary      Get the details of an order
+// @Description  Allows a tenant admin to view an order with its line items, chosen options, price breakdown, delivery address, payment method, cancellation reason, review and status history.
+// @Tags         Admin Panel - Order Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string true "Tenant ID"
+// @Param        orderId  path     int    true "Order ID"
+// @Success      200      {object} models.APIResponse[models.OrderDetails]
+// @Failure      400      {object} models.APIResponse[any] "Invalid order ID"
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      404      {object} models.APIResponse[any] "Order not found"
+// @Failure      500      {object} models.APIResponse[any] "Failed to retrieve order"
+// @Router       /{tenantId}/admin/orders/{orderId} [get]
+func AdminGetOrderDetailsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		orderID, err := strconv.ParseInt(c.Param("orderId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid order ID"})
+			return
+		}
+
+		details, err := services.AdminGetOrderDetails(c.Request.Context(), tenantID, orderID)
+		if err != nil {
+			if errors.Is(err, services.ErrOrderNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve order"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[*models.OrderDetails]{Success: true, Data: details})
 	}
 }
 
 // UpdateOrderStatusHandler godoc
 // @Summary      Update an order's status
-// @Description  Allows a tenant admin to update the status of a specific order (e.g., to 'Preparing', 'Completed').
+// @Description  Allows a tenant admin to move an order to its next status (Pending → Accepted → Preparing → ReadyForPickup/OutForDelivery → Completed, or Cancelled/Refunded). Only delivery orders go OutForDelivery. Illegal transitions are rejected and every change is recorded in the order's status history. Completing an order captures its authorized payment, cancelling it voids the authorization and refunding it pays back whatever has not been refunded yet. Card orders can only be refunded once their payment has been captured.
 // @Tags         Admin Panel - Order Management
 // @Accept       json
 // @Produce      json
@@ -190,14 +268,22 @@ func GetTenantOrdersHandler() gin.HandlerFunc {
 // @Param        orderId  path     int                            true "Order ID"
 // @Param        status   body     models.UpdateOrderStatusPayload true "New Order Status"
 // @Success      200      {object} models.APIResponse[any] "Order status updated successfully"
-// @Failure      400      {object} models.APIResponse[any] "Invalid request body"
+// @Failure      400      {object} models.APIResponse[any] "Invalid order ID, request body or status"
 // @Failure      403      {object} models.APIResponse[any] "Forbidden"
 // @Failure      404      {object} models.APIResponse[any] "Order not found"
+// @Failure      409      {object} models.APIResponse[any] "The order cannot move to this status"
+// @Failure      500      {object} models.APIResponse[any] "Failed to update order status"
+// @Failure      502      {object} models.APIResponse[any] "The payment could not be captured or voided"
 // @Router       /{tenantId}/admin/orders/{orderId}/status [put]
 func UpdateOrderStatusHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tenantID := c.Param("tenantId")
-		orderID, _ := strconv.ParseInt(c.Param("orderId"), 10, 64)
+		adminID := c.GetInt64("userID")
+		orderID, err := strconv.ParseInt(c.Param("orderId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid order ID"})
+			return
+		}
 
 		var payload models.UpdateOrderStatusPayload
 		if err := c.ShouldBindJSON(&payload); err != nil {
@@ -205,9 +291,25 @@ func UpdateOrderStatusHandler() gin.HandlerFunc {
 			return
 		}
 
-		err := services.UpdateOrderStatus(c.Request.Context(), tenantID, orderID, payload.Status)
+		err = services.UpdateOrderStatus(c.Request.Context(), tenantID, adminID, orderID, &payload)
 		if err != nil {
-			c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+			if errors.Is(err, services.ErrInvalidOrderStatus) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrIllegalStatusTransition) || errors.Is(err, services.ErrOrderNotRefundable) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPaymentDeclined) || errors.Is(err, services.ErrProcessorFailed) {
+				c.JSON(http.StatusBadGateway, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrOrderNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update order status"})
 			return
 		}
 
@@ -217,30 +319,44 @@ func UpdateOrderStatusHandler() gin.HandlerFunc {
 
 // GetTenantCustomersHandler godoc
 // @Summary      Get all customers for the tenant
-// @Description  Allows a tenant admin to view a list of all customers who have registered with their store.
+// @Description  Allows a tenant admin to page through the customers who have registered with their store.
 // @Tags         Admin Panel - Customer Management
 // @Produce      json
 // @Security     BearerAuth
-// @Param        tenantId path     string true "Tenant ID"
+// @Param        tenantId path     string true  "Tenant ID"
+// @Param        limit    query    int    false "Page size (default 20, at most 100)"
+// @Param        cursor   query    string false "Cursor from the previous page's pagination.next_cursor"
+// @Param        sort     query    string false "Comma separated sort fields: newest (default), name; prefix a field with - to reverse it"
 // @Success      200      {object} models.APIResponse[[]models.User]
+// @Failure      400      {object} models.APIResponse[any] "Invalid page size, sort or cursor"
 // @Failure      403      {object} models.APIResponse[any] "Forbidden"
 // @Failure      500      {object} models.APIResponse[any] "Failed to retrieve customers"
 // @Router       /{tenantId}/admin/customers [get]
 func GetTenantCustomersHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tenantID := c.Param("tenantId")
-		customers, err := services.GetTenantCustomers(c.Request.Context(), tenantID)
+		page, err := pageRequest(c)
 		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+			return
+		}
+
+		customers, err := services.GetTenantCustomers(c.Request.Context(), tenantID, page)
+		if err != nil {
+			if isPageError(err) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve customers"})
 			return
 		}
-		c.JSON(http.StatusOK, models.APIResponse[[]models.User]{Success: true, Data: customers})
+		c.JSON(http.StatusOK, models.APIResponse[[]models.User]{Success: true, Data: customers.Items, Pagination: &customers.Pagination})
 	}
 }
 
 // GetDashboardStatsHandler godoc
 // @Summary      Get dashboard analytics
-// @Description  Retrieves key performance statistics for the tenant's store, such as total revenue and orders today.
+// @Description  Retrieves key performance statistics for the tenant's store, such as total revenue (net of refunds), refunded amount and orders today.
 // @Tags         Admin Panel - Dashboard
 // @Produce      json
 // @Security     BearerAuth
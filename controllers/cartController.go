@@ -12,19 +12,22 @@ import (
 
 // AddToCartHandler godoc
 // @Summary      Add an item to the cart
-// @Description  Adds a product with selected options and quantity to the user's shopping cart.
+// @Description  Adds a product with selected options and quantity to the user's shopping cart. If the cart already holds the product with exactly these options, that line's quantity is increased instead. The options are checked against the product's option groups; every problem is listed per group in data. Products or options that are unavailable or do not have enough stock are rejected.
 // @Tags         Cart & Checkout
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        item body     models.AddToCartPayload true "Item to Add"
 // @Success      200  {object} models.APIResponse[any] "Item added to cart"
-// @Failure      400  {object} models.APIResponse[any] "Invalid request body"
+// @Failure      400  {object} models.APIResponse[[]models.OptionSelectionProblem] "Invalid request body or option selection"
+// @Failure      404  {object} models.APIResponse[any] "Product or option not found"
+// @Failure      409  {object} models.APIResponse[any] "Item is unavailable or out of stock"
 // @Failure      500  {object} models.APIResponse[any] "Failed to add item to cart"
 // @Router       /cart/items [post]
 func AddToCartHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.GetInt64("userID")
+		tenantID := c.GetString("tenantID")
 
 		var payload models.AddToCartPayload
 		if err := c.ShouldBindJSON(&payload); err != nil {
@@ -32,8 +35,21 @@ func AddToCartHandler() gin.HandlerFunc {
 			return
 		}
 
-		err := services.AddToCart(c.Request.Context(), userID, &payload)
+		err := services.AddToCart(c.Request.Context(), userID, tenantID, &payload)
 		if err != nil {
+			var selectionErr *services.OptionSelectionError
+			if errors.As(err, &selectionErr) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[[]models.OptionSelectionProblem]{Success: false, Error: err.Error(), Data: selectionErr.Problems})
+				return
+			}
+			if errors.Is(err, services.ErrProductNotFound) || errors.Is(err, services.ErrOptionNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrItemUnavailable) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to add item to cart"})
 			return
 		}
@@ -44,7 +60,7 @@ func AddToCartHandler() gin.HandlerFunc {
 
 // GetCartHandler godoc
 // @Summary      Get cart contents
-// @Description  Retrieves the full contents of the user's shopping cart, with calculated totals.
+// @Description  Retrieves the full contents of the user's shopping cart, with calculated totals. Lines are priced at the current prices, discount prices included, and also show the prices they were added at; price_changed marks lines and carts whose price moved since. The tenant's taxes, service charges and fees are listed in charges and included in grand_total, except taxes already included in the prices.
 // @Tags         Cart & Checkout
 // @Produce      json
 // @Security     BearerAuth
@@ -54,8 +70,9 @@ func AddToCartHandler() gin.HandlerFunc {
 func GetCartHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.GetInt64("userID")
+		tenantID := c.GetString("tenantID")
 
-		cart, err := services.GetCart(c.Request.Context(), userID)
+		cart, err := services.GetCart(c.Request.Context(), userID, tenantID)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve cart"})
 			return
@@ -66,22 +83,24 @@ func GetCartHandler() gin.HandlerFunc {
 }
 
 // UpdateCartItemHandler godoc
-// @Summary      Update item quantity
-// @Description  Updates the quantity of a specific item in the user's cart.
+// @Summary      Update a cart item
+// @Description  Updates the quantity of a specific item in the user's cart and, when option_ids is given, replaces its option selection. If the line then matches another line of the same product and options, the two are merged.
 // @Tags         Cart & Checkout
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        itemId   path     int                         true "Cart Item ID"
-// @Param        quantity body     models.UpdateCartItemPayload true "New Quantity"
-// @Success      200      {object} models.APIResponse[any] "Item quantity updated"
-// @Failure      400      {object} models.APIResponse[any] "Invalid request body or item ID"
-// @Failure      404      {object} models.APIResponse[any] "Cart item not found"
-// @Failure      500      {object} models.APIResponse[any] "Failed to update item"
+// @Param        itemId path     int                          true "Cart Item ID"
+// @Param        item   body     models.UpdateCartItemPayload true "New quantity and optional option selection"
+// @Success      200    {object} models.APIResponse[any] "Cart item updated"
+// @Failure      400    {object} models.APIResponse[[]models.OptionSelectionProblem] "Invalid request body, item ID or option selection"
+// @Failure      404    {object} models.APIResponse[any] "Cart item not found"
+// @Failure      409    {object} models.APIResponse[any] "Item is unavailable or out of stock"
+// @Failure      500    {object} models.APIResponse[any] "Failed to update item"
 // @Router       /cart/items/{itemId} [put]
 func UpdateCartItemHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.GetInt64("userID")
+		tenantID := c.GetString("tenantID")
 		itemID, err := strconv.ParseInt(c.Param("itemId"), 10, 64)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid item ID"})
@@ -94,17 +113,26 @@ func UpdateCartItemHandler() gin.HandlerFunc {
 			return
 		}
 
-		err = services.UpdateCartItemQuantity(c.Request.Context(), userID, itemID, payload.Quantity)
+		err = services.UpdateCartItem(c.Request.Context(), userID, tenantID, itemID, &payload)
 		if err != nil {
-			if errors.Is(err, services.ErrCartItemNotFound) {
+			var selectionErr *services.OptionSelectionError
+			if errors.As(err, &selectionErr) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[[]models.OptionSelectionProblem]{Success: false, Error: err.Error(), Data: selectionErr.Problems})
+				return
+			}
+			if errors.Is(err, services.ErrCartItemNotFound) || errors.Is(err, services.ErrProductNotFound) || errors.Is(err, services.ErrOptionNotFound) {
 				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
 				return
 			}
+			if errors.Is(err, services.ErrItemUnavailable) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update item"})
 			return
 		}
 
-		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Item quantity updated"})
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Cart item updated"})
 	}
 }
 
@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/services"
+	"github.com/gin-gonic/gin"
+)
+
+// GetCategoryTreeHandler godoc
+// @Summary      Get the category tree
+// @Description  Retrieves the visible categories of a store nested under their parents, in display order. available_now tells whether a category, and every category above it, is served at the moment; categories with serving hours (such as a breakfast menu) are only served between available_from and available_until in the store's time zone.
+// @Tags         Public - Products
+// @Produce      json
+// @Param        tenantId path     string true "Tenant ID"
+// @Success      200      {object} models.APIResponse[[]models.Category]
+// @Failure      404      {object} models.APIResponse[any] "Tenant not found"
+// @Failure      500      {object} models.APIResponse[any] "Failed to retrieve categories"
+// @Router       /{tenantId}/categories [get]
+func GetCategoryTreeHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		categories, err := services.GetCategoryTree(c.Request.Context(), tenantID)
+		if err != nil {
+			if errors.Is(err, services.ErrTenantNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve categories"})
+			return
+		}
+		c.JSON(http.StatusOK, models.APIResponse[[]models.Category]{Success: true, Data: categories})
+	}
+}
+
+// GetCategoriesHandler godoc
+// @Summary      List categories
+// @Description  Allows a tenant admin to list all categories of their store, hidden ones included, in display order. Nesting is given by parent_id.
+// @Tags         Admin Panel - Categories
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string true "Tenant ID"
+// @Success      200      {object} models.APIResponse[[]models.Category]
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      500      {object} models.APIResponse[any] "Failed to retrieve categories"
+// @Router       /{tenantId}/admin/categories [get]
+func GetCategoriesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		categories, err := services.GetCategories(c.Request.Context(), tenantID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve categories"})
+			return
+		}
+		c.JSON(http.StatusOK, models.APIResponse[[]models.Category]{Success: true, Data: categories})
+	}
+}
+
+// CreateCategoryHandler godoc
+// @Summary      Create a category
+// @Description  Allows a tenant admin to add a category, optionally under a parent category. Names are unique per store regardless of case. available_from and available_until (HH:MM, in the store's time zone) limit when the category and everything below it can be ordered; a window ending at or before its start runs past midnight.
+// @Tags         Admin Panel - Categories
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string                 true "Tenant ID"
+// @Param        category body     models.CategoryPayload true "New Category"
+// @Success      201      {object} models.APIResponse[models.Category] "Category created successfully"
+// @Failure      400      {object} models.APIResponse[any] "Invalid request body or category"
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      409      {object} models.APIResponse[any] "A category with this name already exists"
+// @Failure      500      {object} models.APIResponse[any] "Failed to create category"
+// @Router       /{tenantId}/admin/categories [post]
+func CreateCategoryHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+
+		var payload models.CategoryPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		category, err := services.CreateCategory(c.Request.Context(), tenantID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidCategory) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrCategoryExists) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to create category"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, models.APIResponse[models.Category]{Success: true, Message: "Category created successfully", Data: *category})
+	}
+}
+
+// UpdateCategoryHandler godoc
+// @Summary      Update a category
+// @Description  Allows a tenant admin to change a category, including moving it under another parent. A new name is applied to the category's products and tags and to the promotions and taxes limited to it.
+// @Tags         Admin Panel - Categories
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId   path     string                 true "Tenant ID"
+// @Param        categoryId path     int                    true "Category ID"
+// @Param        category   body     models.CategoryPayload true "Updated Category"
+// @Success      200        {object} models.APIResponse[any] "Category updated successfully"
+// @Failure      400        {object} models.APIResponse[any] "Invalid request body or category"
+// @Failure      403        {object} models.APIResponse[any] "Forbidden"
+// @Failure      404        {object} models.APIResponse[any] "Category not found"
+// @Failure      409        {object} models.APIResponse[any] "A category with this name already exists"
+// @Failure      500        {object} models.APIResponse[any] "Failed to update category"
+// @Router       /{tenantId}/admin/categories/{categoryId} [put]
+func UpdateCategoryHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		categoryID, err := strconv.ParseInt(c.Param("categoryId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid category ID"})
+			return
+		}
+
+		var payload models.CategoryPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		err = services.UpdateCategory(c.Request.Context(), tenantID, categoryID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidCategory) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrCategoryNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrCategoryExists) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update category"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Category updated successfully"})
+	}
+}
+
+// DeleteCategoryHandler godoc
+// @Summary      Delete a category
+// @Description  Allows a tenant admin to delete an empty category. Categories that still have products, tags or subcategories, or that promotions or taxes are limited to, cannot be deleted.
+// @Tags         Admin Panel - Categories
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId   path     string true "Tenant ID"
+// @Param        categoryId path     int    true "Category ID"
+// @Success      200        {object} models.APIResponse[any] "Category deleted successfully"
+// @Failure      403        {object} models.APIResponse[any] "Forbidden"
+// @Failure      404        {object} models.APIResponse[any] "Category not found"
+// @Failure      409        {object} models.APIResponse[any] "Category is still in use"
+// @Failure      500        {object} models.APIResponse[any] "Failed to delete category"
+// @Router       /{tenantId}/admin/categories/{categoryId} [delete]
+func DeleteCategoryHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		categoryID, err := strconv.ParseInt(c.Param("categoryId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid category ID"})
+			return
+		}
+
+		err = services.DeleteCategory(c.Request.Context(), tenantID, categoryID)
+		if err != nil {
+			if errors.Is(err, services.ErrCategoryNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrCategoryInUse) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to delete category"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Category deleted successfully"})
+	}
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/services"
+	"github.com/gin-gonic/gin"
+)
+
+// CheckoutHandler godoc
+// @Summary      Check out the cart
+// @Description  Re-prices the authenticated user's cart on the server, applies its promo code, checks the fulfilment type, adds the tenant's taxes, service charges, fees and the delivery zone's fee, turns it into a new order and empties the cart, all in one transaction. Orders are delivered to address_id, picked up, or eaten in at table_number as the tenant allows; without fulfilment_type an order with an address is delivered and one without is picked up. When the tenant has delivery zones the address needs coordinates inside one of them and the order must reach the zone's minimum; the zone and its ETA are stored on the order. Without scheduled_for the order is made as soon as possible, which needs the store to stay open until the preparation lead time has passed and takes up a place in the slot the order is ready in; otherwise scheduled_for must be the start of a free slot from the store's availability. The options of every line are re-checked against the current option groups and stock is reserved for tracked products and options. When a payment method is given the order total is authorized on it and captured once the order is completed. If the cart total differs from the prices the items were added at, the checkout is rejected with the changed lines in data until it is repeated with accept_price_changes.
+// @Tags         Cart & Checkout
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        checkout body     models.CheckoutPayload false "Fulfilment type, delivery address or table, time slot and payment method"
+// @Success      201      {object} models.APIResponse[models.Order] "Order placed successfully"
+// @Failure      400      {object} models.APIResponse[any] "Invalid request body, empty cart, expired card or a fulfilment type, address or table that does not fit the store"
+// @Failure      402      {object} models.APIResponse[any] "The payment was declined"
+// @Failure      404      {object} models.APIResponse[any] "Address or payment method not found"
+// @Failure      409      {object} models.APIResponse[any] "The applied promo code is no longer valid, an item is out of stock, the options of a cart line no longer fit its product, prices changed and were not accepted, the cart changed while the payment was authorized, the store is closed or the time slot is not available"
+// @Failure      500      {object} models.APIResponse[any] "Failed to place order"
+// @Failure      502      {object} models.APIResponse[any] "The payment processor could not complete the request"
+// @Router       /checkout [post]
+func CheckoutHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt64("userID")
+		tenantID := c.GetString("tenantID")
+
+		var payload models.CheckoutPayload
+		if err := c.ShouldBindJSON(&payload); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		order, err := services.Checkout(c.Request.Context(), userID, tenantID, &payload)
+		if err != nil {
+			var selectionErr *services.OptionSelectionError
+			if errors.As(err, &selectionErr) {
+				c.JSON(http.StatusConflict, models.APIResponse[[]models.OptionSelectionProblem]{Success: false, Error: err.Error(), Data: selectionErr.Problems})
+				return
+			}
+			var priceErr *services.PriceChangeError
+			if errors.As(err, &priceErr) {
+				c.JSON(http.StatusConflict, models.APIResponse[[]models.CartPriceChange]{Success: false, Error: err.Error(), Data: priceErr.Changes})
+				return
+			}
+			if errors.Is(err, services.ErrCheckoutAddressNotFound) || errors.Is(err, services.ErrCheckoutPaymentMethodNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrCartEmpty) || errors.Is(err, services.ErrPaymentMethodExpired) || isFulfilmentError(err) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPaymentDeclined) || errors.Is(err, services.ErrInvalidCardToken) {
+				c.JSON(http.StatusPaymentRequired, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrProcessorFailed) {
+				c.JSON(http.StatusBadGateway, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPromoNotApplicable) || errors.Is(err, services.ErrItemUnavailable) || errors.Is(err, services.ErrOrderTotalChanged) || isScheduleError(err) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to place order"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, models.APIResponse[*models.Order]{Success: true, Message: "Order placed successfully", Data: order})
+	}
+}
+
+// isFulfilmentError reports whether a checkout failed because the fulfilment choice does not fit the store.
+func isFulfilmentError(err error) bool {
+	return errors.Is(err, services.ErrFulfilmentUnavailable) ||
+		errors.Is(err, services.ErrDeliveryAddressRequired) ||
+		errors.Is(err, services.ErrUnexpectedAddress) ||
+		errors.Is(err, services.ErrTableNumberRequired) ||
+		errors.Is(err, services.ErrAddressNotLocated) ||
+		errors.Is(err, services.ErrOutsideDeliveryArea) ||
+		errors.Is(err, services.ErrBelowDeliveryMinimum)
+}
+
+// isScheduleError reports whether a checkout failed because the store cannot take the order at the requested time.
+func isScheduleError(err error) bool {
+	return errors.Is(err, services.ErrStoreClosed) ||
+		errors.Is(err, services.ErrSlotUnavailable) ||
+		errors.Is(err, services.ErrSlotFull)
+}
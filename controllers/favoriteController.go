@@ -11,24 +11,37 @@ import (
 
 // GetFavoritesHandler godoc
 // @Summary      Get user's favorite products
-// @Description  Retrieves a list of all products that the authenticated user has marked as a favorite.
+// @Description  Retrieves a page of the products that the authenticated user has marked as a favorite, most recently favorited first.
 // @Tags         Favorites
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200 {object} models.APIResponse[[]models.Product]
-// @Failure      500 {object} models.APIResponse[any] "Failed to retrieve favorites"
+// @Param        limit  query    int    false "Page size (default 20, at most 100)"
+// @Param        cursor query    string false "Cursor from the previous page's pagination.next_cursor"
+// @Param        sort   query    string false "Comma separated sort fields: newest (default, when favorited), price, rating, name, popularity; prefix a field with - to reverse it"
+// @Success      200    {object} models.APIResponse[[]models.Product]
+// @Failure      400    {object} models.APIResponse[any] "Invalid page size, sort or cursor"
+// @Failure      500    {object} models.APIResponse[any] "Failed to retrieve favorites"
 // @Router       /favorites [get]
 func GetFavoritesHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.GetInt64("userID")
+		page, err := pageRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+			return
+		}
 
-		favorites, err := services.GetFavorites(c.Request.Context(), userID)
+		favorites, err := services.GetFavorites(c.Request.Context(), userID, page)
 		if err != nil {
+			if isPageError(err) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve favorites"})
 			return
 		}
 
-		c.JSON(http.StatusOK, models.APIResponse[[]models.Product]{Success: true, Data: favorites})
+		c.JSON(http.StatusOK, models.APIResponse[[]models.Product]{Success: true, Data: favorites.Items, Pagination: &favorites.Pagination})
 	}
 }
 
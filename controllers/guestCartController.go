@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/services"
+	"github.com/gin-gonic/gin"
+)
+
+// cartTokenHeader carries the token that identifies a guest cart.
+const cartTokenHeader = "X-Cart-Token"
+
+// GetGuestCartHandler godoc
+// @Summary      Get a guest cart
+// @Description  Retrieves the cart of a visitor without an account, with calculated totals, taxes and fees, current and snapshot prices. A missing, unknown or expired token returns an empty cart.
+// @Tags         Cart & Checkout
+// @Produce      json
+// @Param        tenantId     path   string true  "Tenant ID"
+// @Param        X-Cart-Token header string false "Guest cart token"
+// @Success      200 {object} models.APIResponse[models.Cart]
+// @Failure      500 {object} models.APIResponse[any] "Failed to retrieve cart"
+// @Router       /{tenantId}/guest/cart [get]
+func GetGuestCartHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+
+		cart, err := services.GetGuestCart(c.Request.Context(), tenantID, c.GetHeader(cartTokenHeader))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve cart"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[*models.Cart]{Success: true, Data: cart})
+	}
+}
+
+// AddToGuestCartHandler godoc
+// @Summary      Add an item to a guest cart
+// @Description  Adds a product to the cart of a visitor without an account, with the same option and stock checks as the user cart. Without a valid X-Cart-Token a new guest cart is started. The cart token is returned in data and in the X-Cart-Token response header; send it with later guest cart requests and on login or registration to keep the items. Guest carts expire after a week without changes.
+// @Tags         Cart & Checkout
+// @Accept       json
+// @Produce      json
+// @Param        tenantId     path   string                  true  "Tenant ID"
+// @Param        X-Cart-Token header string                  false "Guest cart token"
+// @Param        item         body   models.AddToCartPayload true  "Item to Add"
+// @Success      200 {object} models.APIResponse[models.GuestCartResponse] "Item added to cart"
+// @Failure      400 {object} models.APIResponse[[]models.OptionSelectionProblem] "Invalid request body or option selection"
+// @Failure      404 {object} models.APIResponse[any] "Product or option not found"
+// @Failure      409 {object} models.APIResponse[any] "Item is unavailable or out of stock"
+// @Failure      500 {object} models.APIResponse[any] "Failed to add item to cart"
+// @Router       /{tenantId}/guest/cart/items [post]
+func AddToGuestCartHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+
+		var payload models.AddToCartPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		token, err := services.AddToGuestCart(c.Request.Context(), tenantID, c.GetHeader(cartTokenHeader), &payload)
+		if err != nil {
+			var selectionErr *services.OptionSelectionError
+			if errors.As(err, &selectionErr) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[[]models.OptionSelectionProblem]{Success: false, Error: err.Error(), Data: selectionErr.Problems})
+				return
+			}
+			if errors.Is(err, services.ErrProductNotFound) || errors.Is(err, services.ErrOptionNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrItemUnavailable) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to add item to cart"})
+			return
+		}
+
+		c.Header(cartTokenHeader, token)
+		c.JSON(http.StatusOK, models.APIResponse[models.GuestCartResponse]{Success: true, Message: "Item added to cart", Data: models.GuestCartResponse{CartToken: token}})
+	}
+}
+
+// UpdateGuestCartItemHandler godoc
+// @Summary      Update a guest cart item
+// @Description  Updates the quantity and, when option_ids is given, the option selection of a line in a guest cart. Lines that end up identical are merged.
+// @Tags         Cart & Checkout
+// @Accept       json
+// @Produce      json
+// @Param        tenantId     path   string                       true "Tenant ID"
+// @Param        X-Cart-Token header string                       true "Guest cart token"
+// @Param        itemId       path   int                          true "Cart Item ID"
+// @Param        item         body   models.UpdateCartItemPayload true "New quantity and optional option selection"
+// @Success      200 {object} models.APIResponse[any] "Cart item updated"
+// @Failure      400 {object} models.APIResponse[[]models.OptionSelectionProblem] "Invalid request body, item ID or option selection"
+// @Failure      404 {object} models.APIResponse[any] "Cart item not found"
+// @Failure      409 {object} models.APIResponse[any] "Item is unavailable or out of stock"
+// @Failure      500 {object} models.APIResponse[any] "Failed to update item"
+// @Router       /{tenantId}/guest/cart/items/{itemId} [put]
+func UpdateGuestCartItemHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		itemID, err := strconv.ParseInt(c.Param("itemId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid item ID"})
+			return
+		}
+
+		var payload models.UpdateCartItemPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		err = services.UpdateGuestCartItem(c.Request.Context(), tenantID, c.GetHeader(cartTokenHeader), itemID, &payload)
+		if err != nil {
+			var selectionErr *services.OptionSelectionError
+			if errors.As(err, &selectionErr) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[[]models.OptionSelectionProblem]{Success: false, Error: err.Error(), Data: selectionErr.Problems})
+				return
+			}
+			if errors.Is(err, services.ErrCartItemNotFound) || errors.Is(err, services.ErrProductNotFound) || errors.Is(err, services.ErrOptionNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrItemUnavailable) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update item"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Cart item updated"})
+	}
+}
+
+// RemoveGuestCartItemHandler godoc
+// @Summary      Remove item from a guest cart
+// @Description  Removes a line from a guest cart.
+// @Tags         Cart & Checkout
+// @Produce      json
+// @Param        tenantId     path   string true "Tenant ID"
+// @Param        X-Cart-Token header string true "Guest cart token"
+// @Param        itemId       path   int    true "Cart Item ID"
+// @Success      200 {object} models.APIResponse[any] "Item removed from cart"
+// @Failure      400 {object} models.APIResponse[any] "Invalid item ID"
+// @Failure      404 {object} models.APIResponse[any] "Cart item not found"
+// @Failure      500 {object} models.APIResponse[any] "Failed to remove item"
+// @Router       /{tenantId}/guest/cart/items/{itemId} [delete]
+func RemoveGuestCartItemHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		itemID, err := strconv.ParseInt(c.Param("itemId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid item ID"})
+			return
+		}
+
+		err = services.RemoveGuestCartItem(c.Request.Context(), tenantID, c.GetHeader(cartTokenHeader), itemID)
+		if err != nil {
+			if errors.Is(err, services.ErrCartItemNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to remove item"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Item removed from cart"})
+	}
+}
+
+// GuestCheckoutHandler godoc
+// @Summary      Check out a guest cart
+// @Description  Turns a guest cart into an order without an account. The cart is re-priced and re-checked like a user checkout, stock is reserved and the order is paid at the store. Guests pick their order up or, with a table_number, eat in, as the tenant allows, either as soon as possible or in a slot chosen with scheduled_for. Taxes, service charges and fees are added as for a user checkout. Promo codes are not available to guests. Price changes since the items were added must be accepted with accept_price_changes. The confirmation and later status updates are sent to the given e-mail address, and the guest cart is removed.
+// @Tags         Cart & Checkout
+// @Accept       json
+// @Produce      json
+// @Param        tenantId     path   string                      true "Tenant ID"
+// @Param        X-Cart-Token header string                      true "Guest cart token"
+// @Param        checkout     body   models.GuestCheckoutPayload true "Contact details"
+// @Success      201 {object} models.APIResponse[models.Order] "Order placed successfully"
+// @Failure      400 {object} models.APIResponse[any] "Invalid request body, empty cart or a fulfilment type the store does not offer"
+// @Failure      409 {object} models.APIResponse[any] "An item is out of stock, the options of a cart line no longer fit its product, prices changed and were not accepted, the store is closed or the time slot is not available"
+// @Failure      500 {object} models.APIResponse[any] "Failed to place order"
+// @Router       /{tenantId}/guest/checkout [post]
+func GuestCheckoutHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+
+		var payload models.GuestCheckoutPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		order, err := services.GuestCheckout(c.Request.Context(), tenantID, c.GetHeader(cartTokenHeader), &payload)
+		if err != nil {
+			var selectionErr *services.OptionSelectionError
+			if errors.As(err, &selectionErr) {
+				c.JSON(http.StatusConflict, models.APIResponse[[]models.OptionSelectionProblem]{Success: false, Error: err.Error(), Data: selectionErr.Problems})
+				return
+			}
+			var priceErr *services.PriceChangeError
+			if errors.As(err, &priceErr) {
+				c.JSON(http.StatusConflict, models.APIResponse[[]models.CartPriceChange]{Success: false, Error: err.Error(), Data: priceErr.Changes})
+				return
+			}
+			if errors.Is(err, services.ErrCartEmpty) || isFulfilmentError(err) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrItemUnavailable) || isScheduleError(err) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to place order"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, models.APIResponse[*models.Order]{Success: true, Message: "Order placed successfully", Data: order})
+	}
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/services"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateProductStockHandler godoc
+// @Summary      Update product stock
+// @Description  Allows a tenant admin to set the stock of a product and mark it as available or unavailable. Leaving stock_quantity empty stops tracking stock for the product. Admins are notified when the stock drops to the low stock threshold (5 by default).
+// @Tags         Admin Panel - Product Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId  path     string              true "Tenant ID"
+// @Param        productId path     int                 true "Product ID"
+// @Param        stock     body     models.StockPayload true "Stock level and availability"
+// @Success      200       {object} models.APIResponse[any] "Stock updated successfully"
+// @Failure      400       {object} models.APIResponse[any] "Invalid product ID or request body"
+// @Failure      403       {object} models.APIResponse[any] "Forbidden"
+// @Failure      404       {object} models.APIResponse[any] "Product not found"
+// @Failure      500       {object} models.APIResponse[any] "Failed to update stock"
+// @Router       /{tenantId}/admin/products/{productId}/stock [put]
+func UpdateProductStockHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		productID, err := strconv.ParseInt(c.Param("productId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid product ID"})
+			return
+		}
+
+		var payload models.StockPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		if err := services.UpdateProductStock(c.Request.Context(), tenantID, productID, &payload); err != nil {
+			if errors.Is(err, services.ErrProductNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update stock"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Stock updated successfully"})
+	}
+}
+
+// UpdateOptionStockHandler godoc
+// @Summary      Update option stock
+// @Description  Allows a tenant admin to set the stock of a single option (e.g. an add-on) and mark it as available or unavailable. Leaving stock_quantity empty stops tracking stock for the option.
+// @Tags         Admin Panel - Product Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string              true "Tenant ID"
+// @Param        optionId path     int                 true "Option ID"
+// @Param        stock    body     models.StockPayload true "Stock level and availability"
+// @Success      200      {object} models.APIResponse[any] "Stock updated successfully"
+// @Failure      400      {object} models.APIResponse[any] "Invalid option ID or request body"
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      404      {object} models.APIResponse[any] "Option not found"
+// @Failure      500      {object} models.APIResponse[any] "Failed to update stock"
+// @Router       /{tenantId}/admin/options/{optionId}/stock [put]
+func UpdateOptionStockHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		optionID, err := strconv.ParseInt(c.Param("optionId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid option ID"})
+			return
+		}
+
+		var payload models.StockPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		if err := services.UpdateOptionStock(c.Request.Context(), tenantID, optionID, &payload); err != nil {
+			if errors.Is(err, services.ErrOptionNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update stock"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Stock updated successfully"})
+	}
+}
@@ -10,24 +10,37 @@ import (
 
 // GetNotificationsHandler godoc
 // @Summary      Get user's notifications
-// @Description  Retrieves a list of all notifications for the currently authenticated user.
+// @Description  Retrieves a page of the notifications of the currently authenticated user, newest first.
 // @Tags         Notifications & Settings
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200 {object} models.APIResponse[[]models.Notification]
-// @Failure      500 {object} models.APIResponse[any] "Failed to retrieve notifications"
+// @Param        limit  query    int    false "Page size (default 20, at most 100)"
+// @Param        cursor query    string false "Cursor from the previous page's pagination.next_cursor"
+// @Param        sort   query    string false "Sort field: newest (default); prefix it with - to reverse it"
+// @Success      200    {object} models.APIResponse[[]models.Notification]
+// @Failure      400    {object} models.APIResponse[any] "Invalid page size, sort or cursor"
+// @Failure      500    {object} models.APIResponse[any] "Failed to retrieve notifications"
 // @Router       /notifications [get]
 func GetNotificationsHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.GetInt64("userID")
+		page, err := pageRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+			return
+		}
 
-		notifications, err := services.GetMyNotifications(c.Request.Context(), userID)
+		notifications, err := services.GetMyNotifications(c.Request.Context(), userID, page)
 		if err != nil {
+			if isPageError(err) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve notifications"})
 			return
 		}
 
-		c.JSON(http.StatusOK, models.APIResponse[[]models.Notification]{Success: true, Data: notifications})
+		c.JSON(http.StatusOK, models.APIResponse[[]models.Notification]{Success: true, Data: notifications.Items, Pagination: &notifications.Pagination})
 	}
 }
 
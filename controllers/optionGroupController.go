@@ -0,0 +1,331 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/services"
+	"github.com/gin-gonic/gin"
+)
+
+// optionGroupPathIDs parses the product and option group IDs of a nested option group route.
+func optionGroupPathIDs(c *gin.Context) (int64, int64, bool) {
+	productID, err := strconv.ParseInt(c.Param("productId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid product ID"})
+		return 0, 0, false
+	}
+	groupID, err := strconv.ParseInt(c.Param("groupId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid option group ID"})
+		return 0, 0, false
+	}
+	return productID, groupID, true
+}
+
+// respondOptionGroupError maps the errors of the option group services to a response and reports whether it did.
+func respondOptionGroupError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, services.ErrInvalidOptionGroup):
+		c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+	case errors.Is(err, services.ErrProductNotFound), errors.Is(err, services.ErrOptionGroupNotFound), errors.Is(err, services.ErrOptionNotFound):
+		c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+// GetOptionGroupsHandler godoc
+// @Summary      List option groups of a product
+// @Description  Allows a tenant admin to list the option groups of a product with their options, in display order.
+// @Tags         Admin Panel - Product Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId  path     string true "Tenant ID"
+// @Param        productId path     int    true "Product ID"
+// @Success      200       {object} models.APIResponse[[]models.OptionGroup]
+// @Failure      400       {object} models.APIResponse[any] "Invalid product ID"
+// @Failure      403       {object} models.APIResponse[any] "Forbidden"
+// @Failure      404       {object} models.APIResponse[any] "Product not found"
+// @Failure      500       {object} models.APIResponse[any] "Failed to retrieve option groups"
+// @Router       /{tenantId}/admin/products/{productId}/option-groups [get]
+func GetOptionGroupsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		productID, err := strconv.ParseInt(c.Param("productId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid product ID"})
+			return
+		}
+
+		groups, err := services.GetOptionGroups(c.Request.Context(), tenantID, productID)
+		if err != nil {
+			if respondOptionGroupError(c, err) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve option groups"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[[]models.OptionGroup]{Success: true, Data: groups})
+	}
+}
+
+// CreateOptionGroupHandler godoc
+// @Summary      Create an option group
+// @Description  Allows a tenant admin to add an option group (e.g. size or extra toppings) to a product, optionally with its options. selection_type is single or multiple; a single choice group allows exactly one selection. A group with min_selections above zero is required.
+// @Tags         Admin Panel - Product Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId    path     string                    true "Tenant ID"
+// @Param        productId   path     int                       true "Product ID"
+// @Param        optionGroup body     models.OptionGroupPayload true "New option group"
+// @Success      201         {object} models.APIResponse[models.OptionGroup] "Option group created successfully"
+// @Failure      400         {object} models.APIResponse[any] "Invalid product ID, request body or selection limits"
+// @Failure      403         {object} models.APIResponse[any] "Forbidden"
+// @Failure      404         {object} models.APIResponse[any] "Product not found"
+// @Failure      500         {object} models.APIResponse[any] "Failed to create option group"
+// @Router       /{tenantId}/admin/products/{productId}/option-groups [post]
+func CreateOptionGroupHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		productID, err := strconv.ParseInt(c.Param("productId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid product ID"})
+			return
+		}
+
+		var payload models.OptionGroupPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		group, err := services.CreateOptionGroup(c.Request.Context(), tenantID, productID, &payload)
+		if err != nil {
+			if respondOptionGroupError(c, err) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to create option group"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, models.APIResponse[*models.OptionGroup]{Success: true, Message: "Option group created successfully", Data: group})
+	}
+}
+
+// UpdateOptionGroupHandler godoc
+// @Summary      Update an option group
+// @Description  Allows a tenant admin to change the name, selection limits and position of an option group. Options sent in the body are ignored; use the option endpoints to change them.
+// @Tags         Admin Panel - Product Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId    path     string                    true "Tenant ID"
+// @Param        productId   path     int                       true "Product ID"
+// @Param        groupId     path     int                       true "Option group ID"
+// @Param        optionGroup body     models.OptionGroupPayload true "Updated option group"
+// @Success      200         {object} models.APIResponse[any] "Option group updated successfully"
+// @Failure      400         {object} models.APIResponse[any] "Invalid ID, request body or selection limits"
+// @Failure      403         {object} models.APIResponse[any] "Forbidden"
+// @Failure      404         {object} models.APIResponse[any] "Option group not found"
+// @Failure      500         {object} models.APIResponse[any] "Failed to update option group"
+// @Router       /{tenantId}/admin/products/{productId}/option-groups/{groupId} [put]
+func UpdateOptionGroupHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		productID, groupID, ok := optionGroupPathIDs(c)
+		if !ok {
+			return
+		}
+
+		var payload models.OptionGroupPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		if err := services.UpdateOptionGroup(c.Request.Context(), tenantID, productID, groupID, &payload); err != nil {
+			if respondOptionGroupError(c, err) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update option group"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Option group updated successfully"})
+	}
+}
+
+// DeleteOptionGroupHandler godoc
+// @Summary      Delete an option group
+// @Description  Allows a tenant admin to delete an option group and all of its options. Carts holding these options lose them; past orders keep their option names.
+// @Tags         Admin Panel - Product Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId  path     string true "Tenant ID"
+// @Param        productId path     int    true "Product ID"
+// @Param        groupId   path     int    true "Option group ID"
+// @Success      200       {object} models.APIResponse[any] "Option group deleted successfully"
+// @Failure      400       {object} models.APIResponse[any] "Invalid ID"
+// @Failure      403       {object} models.APIResponse[any] "Forbidden"
+// @Failure      404       {object} models.APIResponse[any] "Option group not found"
+// @Failure      500       {object} models.APIResponse[any] "Failed to delete option group"
+// @Router       /{tenantId}/admin/products/{productId}/option-groups/{groupId} [delete]
+func DeleteOptionGroupHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		productID, groupID, ok := optionGroupPathIDs(c)
+		if !ok {
+			return
+		}
+
+		if err := services.DeleteOptionGroup(c.Request.Context(), tenantID, productID, groupID); err != nil {
+			if respondOptionGroupError(c, err) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to delete option group"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Option group deleted successfully"})
+	}
+}
+
+// CreateOptionHandler godoc
+// @Summary      Add an option to a group
+// @Description  Allows a tenant admin to add an option to an option group. Marking it as default in a single choice group replaces the previous default.
+// @Tags         Admin Panel - Product Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId  path     string               true "Tenant ID"
+// @Param        productId path     int                  true "Product ID"
+// @Param        groupId   path     int                  true "Option group ID"
+// @Param        option    body     models.OptionPayload true "New option"
+// @Success      201       {object} models.APIResponse[models.Option] "Option created successfully"
+// @Failure      400       {object} models.APIResponse[any] "Invalid ID, request body or too many defaults"
+// @Failure      403       {object} models.APIResponse[any] "Forbidden"
+// @Failure      404       {object} models.APIResponse[any] "Option group not found"
+// @Failure      500       {object} models.APIResponse[any] "Failed to create option"
+// @Router       /{tenantId}/admin/products/{productId}/option-groups/{groupId}/options [post]
+func CreateOptionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		productID, groupID, ok := optionGroupPathIDs(c)
+		if !ok {
+			return
+		}
+
+		var payload models.OptionPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		option, err := services.CreateOption(c.Request.Context(), tenantID, productID, groupID, &payload)
+		if err != nil {
+			if respondOptionGroupError(c, err) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to create option"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, models.APIResponse[*models.Option]{Success: true, Message: "Option created successfully", Data: option})
+	}
+}
+
+// UpdateOptionHandler godoc
+// @Summary      Update an option
+// @Description  Allows a tenant admin to change the name, price, default flag, position and availability of an option.
+// @Tags         Admin Panel - Product Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId  path     string               true "Tenant ID"
+// @Param        productId path     int                  true "Product ID"
+// @Param        groupId   path     int                  true "Option group ID"
+// @Param        optionId  path     int                  true "Option ID"
+// @Param        option    body     models.OptionPayload true "Updated option"
+// @Success      200       {object} models.APIResponse[any] "Option updated successfully"
+// @Failure      400       {object} models.APIResponse[any] "Invalid ID, request body or too many defaults"
+// @Failure      403       {object} models.APIResponse[any] "Forbidden"
+// @Failure      404       {object} models.APIResponse[any] "Option group or option not found"
+// @Failure      500       {object} models.APIResponse[any] "Failed to update option"
+// @Router       /{tenantId}/admin/products/{productId}/option-groups/{groupId}/options/{optionId} [put]
+func UpdateOptionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		productID, groupID, ok := optionGroupPathIDs(c)
+		if !ok {
+			return
+		}
+		optionID, err := strconv.ParseInt(c.Param("optionId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid option ID"})
+			return
+		}
+
+		var payload models.OptionPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		if err := services.UpdateOption(c.Request.Context(), tenantID, productID, groupID, optionID, &payload); err != nil {
+			if respondOptionGroupError(c, err) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update option"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Option updated successfully"})
+	}
+}
+
+// DeleteOptionHandler godoc
+// @Summary      Delete an option
+// @Description  Allows a tenant admin to remove an option from a group. A group cannot drop below its min_selections.
+// @Tags         Admin Panel - Product Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId  path     string true "Tenant ID"
+// @Param        productId path     int    true "Product ID"
+// @Param        groupId   path     int    true "Option group ID"
+// @Param        optionId  path     int    true "Option ID"
+// @Success      200       {object} models.APIResponse[any] "Option deleted successfully"
+// @Failure      400       {object} models.APIResponse[any] "Invalid ID or the group would have too few options"
+// @Failure      403       {object} models.APIResponse[any] "Forbidden"
+// @Failure      404       {object} models.APIResponse[any] "Option group or option not found"
+// @Failure      500       {object} models.APIResponse[any] "Failed to delete option"
+// @Router       /{tenantId}/admin/products/{productId}/option-groups/{groupId}/options/{optionId} [delete]
+func DeleteOptionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		productID, groupID, ok := optionGroupPathIDs(c)
+		if !ok {
+			return
+		}
+		optionID, err := strconv.ParseInt(c.Param("optionId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid option ID"})
+			return
+		}
+
+		if err := services.DeleteOption(c.Request.Context(), tenantID, productID, groupID, optionID); err != nil {
+			if respondOptionGroupError(c, err) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to delete option"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Option deleted successfully"})
+	}
+}
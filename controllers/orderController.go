@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -12,36 +13,86 @@ import (
 
 // GetMyOrdersHandler godoc
 // @Summary      Get user's order history
-// @Description  Retrieves a list of orders for the authenticated user, filterable by status.
+// @Description  Retrieves a list of orders for the authenticated user, filterable by one or more statuses. Without a filter all open orders are returned.
 // @Tags         Orders
 // @Produce      json
 // @Security     BearerAuth
-// @Param        status query    string false "Filter orders by status (e.g., Active, Completed, Cancelled)"
+// @Param        status query    []string false "Filter orders by status, repeated or comma separated (e.g., Pending,Preparing or Active for all open orders)" collectionFormat(multi)
+// @Param        limit  query    int      false "Page size (default 20, at most 100)"
+// @Param        cursor query    string   false "Cursor from the previous page's pagination.next_cursor"
+// @Param        sort   query    string   false "Comma separated sort fields: newest (default), price; prefix a field with - to reverse it"
 // @Success      200    {object} models.APIResponse[[]models.OrderSummaryView]
+// @Failure      400    {object} models.APIResponse[any] "Invalid order status, page size, sort or cursor"
 // @Failure      500    {object} models.APIResponse[any] "Failed to retrieve orders"
 // @Router       /orders [get]
 func GetMyOrdersHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.GetInt64("userID")
 
-		status := c.Query("status")
+		statuses := c.QueryArray("status")
+		page, err := pageRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+			return
+		}
 
-		orders, err := services.GetMyOrders(c.Request.Context(), userID, status)
+		orders, err := services.GetMyOrders(c.Request.Context(), userID, statuses, page)
 		if err != nil {
+			if errors.Is(err, services.ErrInvalidOrderStatus) || isPageError(err) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve orders"})
 			return
 		}
 
 		response := models.APIResponse[[]models.OrderSummaryView]{
-			Success: true,
-			Data:    orders,
+			Success:    true,
+			Data:       orders.Items,
+			Pagination: &orders.Pagination,
 		}
 		c.JSON(http.StatusOK, response)
 	}
 }
 
+// GetOrderDetailsHandler godoc
+// @Summary      Get the details of an order
+// @Description  Retrieves one of the authenticated user's orders with its line items, chosen options, price breakdown, delivery address, payment method, review and status history.
+// @Tags         Orders
+// @Produce      json
+// @Security     BearerAuth
+// @Param        orderId path     int true "Order ID"
+// @Success      200     {object} models.APIResponse[models.OrderDetails]
+// @Failure      400     {object} models.APIResponse[any] "Invalid order ID"
+// @Failure      404     {object} models.APIResponse[any] "Order not found"
+// @Failure      500     {object} models.APIResponse[any] "Failed to retrieve order"
+// @Router       /orders/{orderId} [get]
+func GetOrderDetailsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt64("userID")
+		orderID, err := strconv.ParseInt(c.Param("orderId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid order ID"})
+			return
+		}
+
+		details, err := services.GetOrderDetails(c.Request.Context(), userID, orderID)
+		if err != nil {
+			if errors.Is(err, services.ErrOrderNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve order"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[*models.OrderDetails]{Success: true, Data: details})
+	}
+}
+
 // CancelOrderHandler godoc
 // @Summary      Cancel an active order
-// @Description  Allows an authenticated user to cancel one of their own active orders.
+// @Description  Allows an authenticated user to cancel one of their own orders as long as it is still Pending or Accepted.
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
@@ -53,6 +104,7 @@ func GetMyOrdersHandler() gin.HandlerFunc {
 // @Failure      404     {object} models.APIResponse[any] "Order not found"
 // @Failure      409     {object} models.APIResponse[any] "Order cannot be cancelled"
 // @Failure      500     {object} models.APIResponse[any] "Failed to cancel order"
+// @Failure      502     {object} models.APIResponse[any] "The payment processor could not release the payment"
 // @Router       /orders/{orderId}/cancel [post]
 func CancelOrderHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -79,6 +131,10 @@ func CancelOrderHandler() gin.HandlerFunc {
 				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
 				return
 			}
+			if errors.Is(err, services.ErrProcessorFailed) {
+				c.JSON(http.StatusBadGateway, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to cancel order"})
 			return
 		}
@@ -87,6 +143,50 @@ func CancelOrderHandler() gin.HandlerFunc {
 	}
 }
 
+// ReorderHandler godoc
+// @Summary      Reorder a past order
+// @Description  Puts the lines of one of the user's past orders back into their cart. Lines whose product or options were deleted, that are out of stock or whose price changed are skipped and listed, unless price changes are accepted.
+// @Tags         Orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        orderId path     int                   true  "Order ID"
+// @Param        options body     models.ReorderPayload false "Reorder options"
+// @Success      200     {object} models.APIResponse[models.ReorderResult] "The updated cart and the lines that were skipped"
+// @Failure      400     {object} models.APIResponse[any] "Invalid order ID or request body"
+// @Failure      404     {object} models.APIResponse[any] "Order not found"
+// @Failure      500     {object} models.APIResponse[any] "Failed to reorder"
+// @Router       /orders/{orderId}/reorder [post]
+func ReorderHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt64("userID")
+		tenantID := c.GetString("tenantID")
+		orderID, err := strconv.ParseInt(c.Param("orderId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid order ID"})
+			return
+		}
+
+		var payload models.ReorderPayload
+		if err := c.ShouldBindJSON(&payload); err != nil && !errors.Is(err, io.EOF) {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		result, err := services.Reorder(c.Request.Context(), userID, tenantID, orderID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrOrderNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to reorder"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[*models.ReorderResult]{Success: true, Data: result})
+	}
+}
+
 // LeaveReviewHandler godoc
 // @Summary      Leave a review for an order
 // @Description  Allows an authenticated user to leave a rating and comment for one of their own completed orders.
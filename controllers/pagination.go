@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/services"
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidLimit = errors.New("limit must be a positive number")
+
+// pageRequest reads the limit, cursor and sort query parameters shared by all list endpoints.
+func pageRequest(c *gin.Context) (*models.PageRequest, error) {
+	req := &models.PageRequest{Cursor: c.Query("cursor"), Sort: c.Query("sort")}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return nil, errInvalidLimit
+		}
+		req.Limit = n
+	}
+	return req, nil
+}
+
+func isPageError(err error) bool {
+	return errors.Is(err, errInvalidLimit) || errors.Is(err, services.ErrInvalidSort) || errors.Is(err, services.ErrInvalidCursor)
+}
@@ -12,15 +12,16 @@ import (
 
 // AddPaymentMethodHandler godoc
 // @Summary      Add a new payment method
-// @Description  Adds a new payment method to the user's profile using a token from a payment processor.
+// @Description  Adds a new payment method to the user's profile using a token from a payment processor. The card brand, last four digits and expiry are looked up with the processor; expired cards are rejected. The first card becomes the default.
 // @Tags         User & Profile
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        token body     models.AddPaymentMethodPayload true "Payment Processor Token"
 // @Success      201   {object} models.APIResponse[any] "Payment method added successfully"
-// @Failure      400   {object} models.APIResponse[any] "Invalid request body"
+// @Failure      400   {object} models.APIResponse[any] "Invalid request body, unknown card token or expired card"
 // @Failure      500   {object} models.APIResponse[any] "Failed to add payment method"
+// @Failure      502   {object} models.APIResponse[any] "The payment processor could not complete the request"
 // @Router       /payment-methods [post]
 func AddPaymentMethodHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -34,8 +35,12 @@ func AddPaymentMethodHandler() gin.HandlerFunc {
 
 		err := services.AddPaymentMethod(c.Request.Context(), userID, &payload)
 		if err != nil {
+			if errors.Is(err, services.ErrInvalidCardToken) || errors.Is(err, services.ErrPaymentMethodExpired) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			if errors.Is(err, services.ErrProcessorFailed) {
-				c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: err.Error()})
+				c.JSON(http.StatusBadGateway, models.APIResponse[any]{Success: false, Error: err.Error()})
 				return
 			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to add payment method"})
@@ -48,7 +53,7 @@ func AddPaymentMethodHandler() gin.HandlerFunc {
 
 // GetPaymentMethodsHandler godoc
 // @Summary      Get user's payment methods
-// @Description  Retrieves a list of all saved payment methods for the authenticated user.
+// @Description  Retrieves a list of all saved payment methods for the authenticated user, default card first, flagging cards that have expired.
 // @Tags         User & Profile
 // @Produce      json
 // @Security     BearerAuth
@@ -71,7 +76,7 @@ func GetPaymentMethodsHandler() gin.HandlerFunc {
 
 // DeletePaymentMethodHandler godoc
 // @Summary      Delete a payment method
-// @Description  Deletes a specific payment method belonging to the authenticated user.
+// @Description  Deletes a specific payment method belonging to the authenticated user. When the default card is deleted the newest remaining card becomes the default, preferring cards that have not expired.
 // @Tags         User & Profile
 // @Produce      json
 // @Security     BearerAuth
@@ -103,3 +108,42 @@ func DeletePaymentMethodHandler() gin.HandlerFunc {
 		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Payment method deleted successfully"})
 	}
 }
+
+// SetDefaultPaymentMethodHandler godoc
+// @Summary      Set the default payment method
+// @Description  Makes one of the authenticated user's cards the default and clears the flag on all others.
+// @Tags         User & Profile
+// @Produce      json
+// @Security     BearerAuth
+// @Param        methodId path     int true "Payment Method ID"
+// @Success      200      {object} models.APIResponse[any] "Default payment method updated successfully"
+// @Failure      400      {object} models.APIResponse[any] "Invalid payment method ID or the card has expired"
+// @Failure      404      {object} models.APIResponse[any] "Payment method not found"
+// @Failure      500      {object} models.APIResponse[any] "Failed to update default payment method"
+// @Router       /payment-methods/{methodId}/default [put]
+func SetDefaultPaymentMethodHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt64("userID")
+		methodID, err := strconv.ParseInt(c.Param("methodId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid payment method ID"})
+			return
+		}
+
+		err = services.SetDefaultPaymentMethod(c.Request.Context(), userID, methodID)
+		if err != nil {
+			if errors.Is(err, services.ErrPaymentMethodNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPaymentMethodExpired) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update default payment method"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Default payment method updated successfully"})
+	}
+}
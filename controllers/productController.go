@@ -13,21 +13,29 @@ import (
 
 // SearchProductsHandler godoc
 // @Summary      Search and filter products
-// @Description  Retrieves a list of products for a tenant, with optional filters for category, tags, price, and sorting.
+// @Description  Retrieves a page of products for a tenant, with optional keyword search, filters for category, tags, price, rating and availability, sorting, and facet counts for filter UIs. Keyword searches look at names, descriptions and tags, tolerate small typos and Arabic or Persian spellings of ی and ک, are ranked by relevance unless another sort is given, and return the matched fragments as highlights. Products of hidden categories are not listed.
 // @Tags         Public - Products
 // @Produce      json
-// @Param        tenantId  path     string false "Tenant ID"
-// @Param        category  query    string false "Filter by main category (e.g., Meal, Drink)"
-// @Param        tags      query    string false "Filter by comma-separated tags (e.g., Pizza,Cheese)"
-// @Param        min_price query    number false "Minimum price filter"
-// @Param        max_price query    number false "Maximum price filter"
-// @Param        sort_by   query    string false "Sort order (e.g., rating_desc)"
-// @Success      200       {object} models.APIResponse[[]models.Product]
-// @Failure      500       {object} models.APIResponse[any] "Failed to search for products"
+// @Param        tenantId   path     string  true  "Tenant ID"
+// @Param        q          query    string  false "Keywords to search for"
+// @Param        category   query    string  false "Filter by category name, including its subcategories (e.g., Meal, Drink)"
+// @Param        tags       query    string  false "Filter by comma-separated tags (e.g., Pizza,Cheese)"
+// @Param        min_price  query    integer false "Minimum price filter, in minor units"
+// @Param        max_price  query    integer false "Maximum price filter, in minor units"
+// @Param        min_rating query    number  false "Only products rated at least this"
+// @Param        available  query    bool    false "Only products that can (true) or cannot (false) be ordered right now"
+// @Param        facets     query    bool    false "Also count the products per category, tag, price range, rating and availability"
+// @Param        sort       query    string  false "Comma separated sort fields: name (default), price, rating, newest, popularity, and relevance (default) for keyword searches; prefix a field with - to reverse it"
+// @Param        sort_by    query    string  false "Deprecated, use sort; rating_desc sorts by rating"
+// @Param        limit      query    integer false "Page size (default 20, at most 100)"
+// @Param        cursor     query    string  false "Cursor from the previous page's pagination.next_cursor"
+// @Success      200        {object} models.APIResponse[[]models.Product]
+// @Failure      400        {object} models.APIResponse[any] "Invalid page size, sort or cursor"
+// @Failure      500        {object} models.APIResponse[any] "Failed to search for products"
 // @Router       /{tenantId}/products [get]
 func SearchProductsHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tenantID := c.GetString("tenantID")
+		tenantID := c.Param("tenantId")
 		filters := make(map[string][]string)
 
 		for key, values := range c.Request.URL.Query() {
@@ -37,12 +45,21 @@ func SearchProductsHandler() gin.HandlerFunc {
 				filters[key] = values
 			}
 		}
-		products, err := services.SearchProducts(c.Request.Context(), tenantID, filters)
+		page, err := pageRequest(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+			return
+		}
+		products, facets, err := services.SearchProducts(c.Request.Context(), tenantID, filters, page)
 		if err != nil {
+			if isPageError(err) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to search for products"})
 			return
 		}
-		c.JSON(http.StatusOK, models.APIResponse[[]models.Product]{Success: true, Data: products})
+		c.JSON(http.StatusOK, models.APIResponse[[]models.Product]{Success: true, Data: products.Items, Pagination: &products.Pagination, Facets: facets})
 	}
 }
 
@@ -57,7 +74,7 @@ func SearchProductsHandler() gin.HandlerFunc {
 // @Router       /{tenantId}/tags [get]
 func GetTagsHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		tenantID := c.GetString("tenantID")
+		tenantID := c.Param("tenantId")
 		tags, err := services.GetTags(c.Request.Context(), tenantID)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve tags"})
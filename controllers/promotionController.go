@@ -0,0 +1,263 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/services"
+	"github.com/gin-gonic/gin"
+)
+
+// GetPromotionsHandler godoc
+// @Summary      List promotions
+// @Description  Allows a tenant admin to list all promo codes of their store, including how often each has been redeemed.
+// @Tags         Admin Panel - Promotions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string true "Tenant ID"
+// @Success      200      {object} models.APIResponse[[]models.Promotion]
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      500      {object} models.APIResponse[any] "Failed to retrieve promotions"
+// @Router       /{tenantId}/admin/promotions [get]
+func GetPromotionsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		promotions, err := services.GetPromotions(c.Request.Context(), tenantID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve promotions"})
+			return
+		}
+		c.JSON(http.StatusOK, models.APIResponse[[]models.Promotion]{Success: true, Data: promotions})
+	}
+}
+
+// CreatePromotionHandler godoc
+// @Summary      Create a promotion
+// @Description  Allows a tenant admin to create a promo code (percentage, fixed amount or free item) with optional limits, validity window and category/tag restrictions. A percentage code uses value; a fixed amount code uses amount, in minor units like min_basket, or value in the major unit of the store's currency as before.
+// @Tags         Admin Panel - Promotions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId  path     string                  true "Tenant ID"
+// @Param        promotion body     models.PromotionPayload true "New Promotion"
+// @Success      201       {object} models.APIResponse[any] "Promotion created successfully"
+// @Failure      400       {object} models.APIResponse[any] "Invalid request body"
+// @Failure      403       {object} models.APIResponse[any] "Forbidden"
+// @Failure      409       {object} models.APIResponse[any] "A promotion with this code already exists"
+// @Failure      500       {object} models.APIResponse[any] "Failed to create promotion"
+// @Router       /{tenantId}/admin/promotions [post]
+func CreatePromotionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+
+		var payload models.PromotionPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		_, err := services.CreatePromotion(c.Request.Context(), tenantID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidPromotion) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPromotionCodeExists) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to create promotion"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, models.APIResponse[any]{Success: true, Message: "Promotion created successfully"})
+	}
+}
+
+// UpdatePromotionHandler godoc
+// @Summary      Update a promotion
+// @Description  Allows a tenant admin to change the rules of an existing promo code.
+// @Tags         Admin Panel - Promotions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId    path     string                  true "Tenant ID"
+// @Param        promotionId path     int                     true "Promotion ID"
+// @Param        promotion   body     models.PromotionPayload true "Updated Promotion"
+// @Success      200         {object} models.APIResponse[any] "Promotion updated successfully"
+// @Failure      400         {object} models.APIResponse[any] "Invalid request body"
+// @Failure      403         {object} models.APIResponse[any] "Forbidden"
+// @Failure      404         {object} models.APIResponse[any] "Promotion not found"
+// @Failure      409         {object} models.APIResponse[any] "A promotion with this code already exists"
+// @Failure      500         {object} models.APIResponse[any] "Failed to update promotion"
+// @Router       /{tenantId}/admin/promotions/{promotionId} [put]
+func UpdatePromotionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		promotionID, err := strconv.ParseInt(c.Param("promotionId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid promotion ID"})
+			return
+		}
+
+		var payload models.PromotionPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		err = services.UpdatePromotion(c.Request.Context(), tenantID, promotionID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidPromotion) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPromotionNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPromotionCodeExists) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update promotion"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Promotion updated successfully"})
+	}
+}
+
+// DeletePromotionHandler godoc
+// @Summary      Delete a promotion
+// @Description  Allows a tenant admin to delete a promo code that has never been redeemed. Redeemed codes must be deactivated instead so their history stays auditable.
+// @Tags         Admin Panel - Promotions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId    path     string true "Tenant ID"
+// @Param        promotionId path     int    true "Promotion ID"
+// @Success      200         {object} models.APIResponse[any] "Promotion deleted successfully"
+// @Failure      400         {object} models.APIResponse[any] "Invalid promotion ID"
+// @Failure      403         {object} models.APIResponse[any] "Forbidden"
+// @Failure      404         {object} models.APIResponse[any] "Promotion not found"
+// @Failure      409         {object} models.APIResponse[any] "Promotion has already been redeemed"
+// @Failure      500         {object} models.APIResponse[any] "Failed to delete promotion"
+// @Router       /{tenantId}/admin/promotions/{promotionId} [delete]
+func DeletePromotionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		promotionID, err := strconv.ParseInt(c.Param("promotionId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid promotion ID"})
+			return
+		}
+
+		err = services.DeletePromotion(c.Request.Context(), tenantID, promotionID)
+		if err != nil {
+			if errors.Is(err, services.ErrPromotionNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPromotionInUse) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to delete promotion"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Promotion deleted successfully"})
+	}
+}
+
+// GetPromotionRedemptionsHandler godoc
+// @Summary      List redemptions of a promotion
+// @Description  Allows a tenant admin to audit which orders used a promo code and how much discount each received.
+// @Tags         Admin Panel - Promotions
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId    path     string true "Tenant ID"
+// @Param        promotionId path     int    true "Promotion ID"
+// @Success      200         {object} models.APIResponse[[]models.PromotionRedemption]
+// @Failure      400         {object} models.APIResponse[any] "Invalid promotion ID"
+// @Failure      403         {object} models.APIResponse[any] "Forbidden"
+// @Failure      500         {object} models.APIResponse[any] "Failed to retrieve redemptions"
+// @Router       /{tenantId}/admin/promotions/{promotionId}/redemptions [get]
+func GetPromotionRedemptionsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		promotionID, err := strconv.ParseInt(c.Param("promotionId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid promotion ID"})
+			return
+		}
+
+		redemptions, err := services.GetPromotionRedemptions(c.Request.Context(), tenantID, promotionID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve redemptions"})
+			return
+		}
+		c.JSON(http.StatusOK, models.APIResponse[[]models.PromotionRedemption]{Success: true, Data: redemptions})
+	}
+}
+
+// ApplyPromoCodeHandler godoc
+// @Summary      Apply a promo code to the cart
+// @Description  Validates a promo code against the current cart and attaches it, returning the cart with its discount breakdown.
+// @Tags         Cart & Checkout
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        promo body     models.ApplyPromoPayload true "Promo code"
+// @Success      200   {object} models.APIResponse[models.Cart] "Promo code applied"
+// @Failure      400   {object} models.APIResponse[any] "Invalid request body, empty cart or promo code cannot be applied"
+// @Failure      500   {object} models.APIResponse[any] "Failed to apply promo code"
+// @Router       /cart/promo [post]
+func ApplyPromoCodeHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt64("userID")
+		tenantID := c.GetString("tenantID")
+
+		var payload models.ApplyPromoPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		cart, err := services.ApplyPromoCode(c.Request.Context(), userID, tenantID, payload.Code)
+		if err != nil {
+			if errors.Is(err, services.ErrPromoNotApplicable) || errors.Is(err, services.ErrCartEmpty) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to apply promo code"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[*models.Cart]{Success: true, Message: "Promo code applied", Data: cart})
+	}
+}
+
+// RemovePromoCodeHandler godoc
+// @Summary      Remove the promo code from the cart
+// @Description  Detaches the promo code currently applied to the user's cart.
+// @Tags         Cart & Checkout
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} models.APIResponse[any] "Promo code removed"
+// @Failure      500 {object} models.APIResponse[any] "Failed to remove promo code"
+// @Router       /cart/promo [delete]
+func RemovePromoCodeHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetInt64("userID")
+
+		if err := services.RemovePromoCode(c.Request.Context(), userID); err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to remove promo code"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Promo code removed"})
+	}
+}
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RefundOrderHandler godoc
+// @Summary      Refund an order
+// @Description  Allows a tenant admin to refund a completed or cancelled order, either completely (no items) or per line and quantity. Line refunds are reduced by the line's share of the order discount. The money goes back through the payment gateway and the customer is notified; once everything is refunded the order moves to Refunded. Card orders can only be refunded once their payment has been captured, so a cancelled card order whose authorization was voided is rejected.
+// @Tags         Admin Panel - Order Management
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string               true "Tenant ID"
+// @Param        orderId  path     int                  true "Order ID"
+// @Param        refund   body     models.RefundPayload true "Refund reason and optional lines"
+// @Success      201      {object} models.APIResponse[[]models.Refund] "Refund issued successfully"
+// @Failure      400      {object} models.APIResponse[any] "Invalid order ID, request body or refund lines"
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      404      {object} models.APIResponse[any] "Order not found"
+// @Failure      409      {object} models.APIResponse[any] "Order is not completed or cancelled, its card payment was never captured, or it has been fully refunded"
+// @Failure      500      {object} models.APIResponse[any] "Failed to refund order"
+// @Failure      502      {object} models.APIResponse[any] "The payment processor could not complete the refund"
+// @Router       /{tenantId}/admin/orders/{orderId}/refunds [post]
+func RefundOrderHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		adminID := c.GetInt64("userID")
+		orderID, err := strconv.ParseInt(c.Param("orderId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid order ID"})
+			return
+		}
+
+		var payload models.RefundPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		refunds, err := services.RefundOrder(c.Request.Context(), tenantID, adminID, orderID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrOrderNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrInvalidRefund) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrOrderNotRefundable) || errors.Is(err, services.ErrNothingToRefund) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPaymentDeclined) || errors.Is(err, services.ErrProcessorFailed) {
+				c.JSON(http.StatusBadGateway, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to refund order"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, models.APIResponse[[]models.Refund]{Success: true, Message: "Refund issued successfully", Data: refunds})
+	}
+}
+
+// GetOrderRefundsHandler godoc
+// @Summary      List the refunds of an order
+// @Description  Allows a tenant admin to see every refund issued for an order, with its lines, amount, reason and operator.
+// @Tags         Admin Panel - Order Management
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string true "Tenant ID"
+// @Param        orderId  path     int    true "Order ID"
+// @Success      200      {object} models.APIResponse[[]models.Refund]
+// @Failure      400      {object} models.APIResponse[any] "Invalid order ID"
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      404      {object} models.APIResponse[any] "Order not found"
+// @Failure      500      {object} models.APIResponse[any] "Failed to retrieve refunds"
+// @Router       /{tenantId}/admin/orders/{orderId}/refunds [get]
+func GetOrderRefundsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		orderID, err := strconv.ParseInt(c.Param("orderId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid order ID"})
+			return
+		}
+
+		refunds, err := services.GetOrderRefunds(c.Request.Context(), tenantID, orderID)
+		if err != nil {
+			if errors.Is(err, services.ErrOrderNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve refunds"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[[]models.Refund]{Success: true, Data: refunds})
+	}
+}
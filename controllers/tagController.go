@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/services"
+	"github.com/gin-gonic/gin"
+)
+
+// GetAdminTagsHandler godoc
+// @Summary      List tags
+// @Description  Allows a tenant admin to list the product tags of their store.
+// @Tags         Admin Panel - Tags
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string true "Tenant ID"
+// @Success      200      {object} models.APIResponse[[]models.Tag]
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      500      {object} models.APIResponse[any] "Failed to retrieve tags"
+// @Router       /{tenantId}/admin/tags [get]
+func GetAdminTagsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		tags, err := services.GetTags(c.Request.Context(), tenantID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve tags"})
+			return
+		}
+		if tags == nil {
+			tags = []models.Tag{}
+		}
+		c.JSON(http.StatusOK, models.APIResponse[[]models.Tag]{Success: true, Data: tags})
+	}
+}
+
+// CreateTagHandler godoc
+// @Summary      Create a tag
+// @Description  Allows a tenant admin to add a product tag to their store, in an existing category given by category_id or main_category. Names are unique per store regardless of case and cannot contain commas.
+// @Tags         Admin Panel - Tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string            true "Tenant ID"
+// @Param        tag      body     models.TagPayload true "New Tag"
+// @Success      201      {object} models.APIResponse[models.Tag] "Tag created successfully"
+// @Failure      400      {object} models.APIResponse[any] "Invalid request body, tag name or category"
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      409      {object} models.APIResponse[any] "A tag with this name already exists"
+// @Failure      500      {object} models.APIResponse[any] "Failed to create tag"
+// @Router       /{tenantId}/admin/tags [post]
+func CreateTagHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+
+		var payload models.TagPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		tag, err := services.CreateTag(c.Request.Context(), tenantID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidTag) || errors.Is(err, services.ErrInvalidCategory) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrTagExists) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to create tag"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, models.APIResponse[models.Tag]{Success: true, Message: "Tag created successfully", Data: *tag})
+	}
+}
+
+// UpdateTagHandler godoc
+// @Summary      Update a tag
+// @Description  Allows a tenant admin to rename a tag or move it to another category. A new name is applied to every product with the tag and to promotions restricted to it. Renaming a tag to the name of another tag is refused; merge them instead.
+// @Tags         Admin Panel - Tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string            true "Tenant ID"
+// @Param        tagId    path     int               true "Tag ID"
+// @Param        tag      body     models.TagPayload true "Updated Tag"
+// @Success      200      {object} models.APIResponse[any] "Tag updated successfully"
+// @Failure      400      {object} models.APIResponse[any] "Invalid request body, tag name or category"
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      404      {object} models.APIResponse[any] "Tag not found"
+// @Failure      409      {object} models.APIResponse[any] "A tag with this name already exists"
+// @Failure      500      {object} models.APIResponse[any] "Failed to update tag"
+// @Router       /{tenantId}/admin/tags/{tagId} [put]
+func UpdateTagHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		tagID, err := strconv.ParseInt(c.Param("tagId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid tag ID"})
+			return
+		}
+
+		var payload models.TagPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		err = services.UpdateTag(c.Request.Context(), tenantID, tagID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidTag) || errors.Is(err, services.ErrInvalidCategory) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrTagNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrTagExists) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to update tag"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Tag updated successfully"})
+	}
+}
+
+// MergeTagHandler godoc
+// @Summary      Merge a tag into another
+// @Description  Allows a tenant admin to fold a tag into another one. Products with the tag get the other tag instead, promotions restricted to it are restricted to the other tag, and the tag is deleted.
+// @Tags         Admin Panel - Tags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string                 true "Tenant ID"
+// @Param        tagId    path     int                    true "ID of the tag to merge away"
+// @Param        merge    body     models.MergeTagPayload true "Tag to merge into"
+// @Success      200      {object} models.APIResponse[any] "Tags merged successfully"
+// @Failure      400      {object} models.APIResponse[any] "Invalid request body"
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      404      {object} models.APIResponse[any] "Tag not found"
+// @Failure      500      {object} models.APIResponse[any] "Failed to merge tags"
+// @Router       /{tenantId}/admin/tags/{tagId}/merge [post]
+func MergeTagHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		tagID, err := strconv.ParseInt(c.Param("tagId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid tag ID"})
+			return
+		}
+
+		var payload models.MergeTagPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		err = services.MergeTag(c.Request.Context(), tenantID, tagID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidTag) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrTagNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to merge tags"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Tags merged successfully"})
+	}
+}
+
+// DeleteTagHandler godoc
+// @Summary      Delete a tag
+// @Description  Allows a tenant admin to delete a tag, removing it from all products. Tags that promotions are restricted to cannot be deleted; merge them into another tag or change the promotions first.
+// @Tags         Admin Panel - Tags
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenantId path     string true "Tenant ID"
+// @Param        tagId    path     int    true "Tag ID"
+// @Success      200      {object} models.APIResponse[any] "Tag deleted successfully"
+// @Failure      403      {object} models.APIResponse[any] "Forbidden"
+// @Failure      404      {object} models.APIResponse[any] "Tag not found"
+// @Failure      409      {object} models.APIResponse[any] "Tag is used by promotions"
+// @Failure      500      {object} models.APIResponse[any] "Failed to delete tag"
+// @Router       /{tenantId}/admin/tags/{tagId} [delete]
+func DeleteTagHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+		tagID, err := strconv.ParseInt(c.Param("tagId"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid tag ID"})
+			return
+		}
+
+		err = services.DeleteTag(c.Request.Context(), tenantID, tagID)
+		if err != nil {
+			if errors.Is(err, services.ErrTagNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrTagInUse) {
+				c.JSON(http.StatusConflict, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to delete tag"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Tag deleted successfully"})
+	}
+}
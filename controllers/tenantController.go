@@ -3,6 +3,7 @@ package controllers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/AryaTabani/Dorivo/models"
 	"github.com/AryaTabani/Dorivo/services"
@@ -49,3 +50,43 @@ func GetTenantConfigHandler() gin.HandlerFunc {
 		c.JSON(http.StatusOK, response)
 	}
 }
+
+// GetAvailabilityHandler godoc
+// @Summary      Get store availability
+// @Description  Tells whether the store takes orders right now, when it opens next if it is closed, and lists the next time slots an order can be scheduled for, taking opening hours, closures, the preparation lead time and slot capacity into account. Times are in UTC; timezone is the store's own zone.
+// @Tags         Public
+// @Produce      json
+// @Param        tenantId path     string true  "Tenant ID"
+// @Param        limit    query    int    false "Maximum number of slots to return (default and maximum 200)"
+// @Success      200      {object} models.APIResponse[models.Availability]
+// @Failure      400      {object} models.APIResponse[any] "Invalid limit"
+// @Failure      404      {object} models.APIResponse[any] "Tenant not found"
+// @Failure      500      {object} models.APIResponse[any] "Failed to retrieve availability"
+// @Router       /{tenantId}/availability [get]
+func GetAvailabilityHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+
+		limit := 0
+		if raw := c.Query("limit"); raw != "" {
+			var err error
+			limit, err = strconv.Atoi(raw)
+			if err != nil || limit < 1 {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid limit"})
+				return
+			}
+		}
+
+		availability, err := services.GetAvailability(c.Request.Context(), tenantID, limit)
+		if err != nil {
+			if errors.Is(err, services.ErrTenantNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to retrieve availability"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[*models.Availability]{Success: true, Data: availability})
+	}
+}
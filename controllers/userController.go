@@ -13,12 +13,13 @@ import (
 
 // RegisterHandler godoc
 // @Summary      Register a new user
-// @Description  Creates a new user account for a specific tenant.
+// @Description  Creates a new user account for a specific tenant. If an X-Cart-Token header is sent, the items of that guest cart are moved into the new account's cart.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
-// @Param        tenantId path     string                 true "Tenant ID"
-// @Param        user     body     models.RegisterPayload true "User Registration Info"
+// @Param        tenantId     path   string                 true  "Tenant ID"
+// @Param        user         body   models.RegisterPayload true  "User Registration Info"
+// @Param        X-Cart-Token header string                 false "Guest cart to move into the new account"
 // @Success      201      {object} models.APIResponse[any] "User created successfully"
 // @Failure      400      {object} models.APIResponse[any] "Invalid request body"
 // @Failure      409      {object} models.APIResponse[any] "User with this email already exists"
@@ -37,7 +38,7 @@ func RegisterHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, response)
 			return
 		}
-		_, err := services.RegisterUser(c.Request.Context(), tenantID, &payload)
+		_, err := services.RegisterUser(c.Request.Context(), tenantID, &payload, c.GetHeader(cartTokenHeader))
 		if err != nil {
 			if errors.Is(err, services.ErrUserExists) {
 				response := models.APIResponse[any]{
@@ -64,12 +65,13 @@ func RegisterHandler() gin.HandlerFunc {
 
 // LoginHandler godoc
 // @Summary      Log in a user
-// @Description  Authenticates a user for a specific tenant and returns a JWT token.
+// @Description  Authenticates a user for a specific tenant and returns a JWT token. If an X-Cart-Token header is sent, the items of that guest cart are merged into the user's cart.
 // @Tags         Authentication
 // @Accept       json
 // @Produce      json
-// @Param        tenantId    path     string              true "Tenant ID"
-// @Param        credentials body     models.LoginPayload true "User Login Credentials"
+// @Param        tenantId     path   string              true  "Tenant ID"
+// @Param        credentials  body   models.LoginPayload true  "User Login Credentials"
+// @Param        X-Cart-Token header string              false "Guest cart to merge into the user's cart"
 // @Success      200         {object} models.APIResponse[models.LoginResponse] "Login successful"
 // @Failure      400         {object} models.APIResponse[any] "Invalid request body"
 // @Failure      401         {object} models.APIResponse[any] "Invalid credentials"
@@ -89,7 +91,7 @@ func LoginHandler() gin.HandlerFunc {
 			return
 		}
 
-		token, err := services.LoginUser(c.Request.Context(), tenantID, &payload)
+		token, err := services.LoginUser(c.Request.Context(), tenantID, &payload, c.GetHeader(cartTokenHeader))
 		if err != nil {
 			if errors.Is(err, services.ErrInvalidCredentials) {
 				response := models.APIResponse[any]{
@@ -114,6 +116,83 @@ func LoginHandler() gin.HandlerFunc {
 	}
 }
 
+// ForgotPasswordHandler godoc
+// @Summary      Request a password reset
+// @Description  Sends a single-use password reset link to the given e-mail if it belongs to a user of the tenant. The link opens the tenant's passwordResetUrl, or PASSWORD_RESET_URL for tenants without one. The response is the same whether or not the address is registered.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        tenantId path     string                       true "Tenant ID"
+// @Param        email    body     models.ForgotPasswordPayload true "Account e-mail"
+// @Success      200      {object} models.APIResponse[any] "If the e-mail is registered, a reset link has been sent"
+// @Failure      400      {object} models.APIResponse[any] "Invalid request body"
+// @Failure      404      {object} models.APIResponse[any] "Tenant not found"
+// @Failure      500      {object} models.APIResponse[any] "Failed to request password reset"
+// @Failure      503      {object} models.APIResponse[any] "Password reset is not set up for this store"
+// @Router       /{tenantId}/forgot-password [post]
+func ForgotPasswordHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+
+		var payload models.ForgotPasswordPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		if err := services.RequestPasswordReset(c.Request.Context(), tenantID, payload.Email); err != nil {
+			if errors.Is(err, services.ErrTenantNotFound) {
+				c.JSON(http.StatusNotFound, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			if errors.Is(err, services.ErrPasswordResetUnavailable) {
+				c.JSON(http.StatusServiceUnavailable, models.APIResponse[any]{Success: false, Error: services.ErrPasswordResetUnavailable.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to request password reset"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "If the e-mail is registered, a reset link has been sent"})
+	}
+}
+
+// ResetPasswordHandler godoc
+// @Summary      Reset password with a token
+// @Description  Sets a new password using the token from a password reset e-mail. All outstanding reset tokens of the user are invalidated.
+// @Tags         Authentication
+// @Accept       json
+// @Produce      json
+// @Param        tenantId path     string                      true "Tenant ID"
+// @Param        reset    body     models.ResetPasswordPayload true "Reset token and new password"
+// @Success      200      {object} models.APIResponse[any] "Password reset successfully"
+// @Failure      400      {object} models.APIResponse[any] "Invalid request body or invalid/expired token"
+// @Failure      500      {object} models.APIResponse[any] "Failed to reset password"
+// @Router       /{tenantId}/reset-password [post]
+func ResetPasswordHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID := c.Param("tenantId")
+
+		var payload models.ResetPasswordPayload
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: "Invalid request body: " + err.Error()})
+			return
+		}
+
+		err := services.ResetPassword(c.Request.Context(), tenantID, &payload)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidResetToken) {
+				c.JSON(http.StatusBadRequest, models.APIResponse[any]{Success: false, Error: err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, models.APIResponse[any]{Success: false, Error: "Failed to reset password"})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.APIResponse[any]{Success: true, Message: "Password reset successfully"})
+	}
+}
+
 // GetProfileHandler godoc
 // @Summary      Get user profile
 // @Description  Retrieves the profile information for the currently authenticated user.
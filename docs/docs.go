@@ -26,12 +26,7 @@ const docTemplate = `{
     "paths": {
         "/addresses": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieves a list of all saved delivery addresses for the authenticated user.",
+                "description": "Retrieves a list of all saved delivery addresses for the authenticated user, the default address first.",
                 "produces": [
                     "application/json"
                 ],
@@ -52,15 +47,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Adds a new delivery address to the authenticated user's profile.",
+                ]
+            },
+            "post": {
+                "description": "Adds a new delivery address to the authenticated user's profile. Street and city are required. Latitude and longitude are optional but must be given together; stores with delivery zones only deliver to addresses that have them. The first address, or one sent with is_default, becomes the default address.",
                 "consumes": [
                     "application/json"
                 ],
@@ -86,7 +81,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Address added successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-models_Address"
                         }
                     },
                     "400": {
@@ -101,17 +96,79 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/addresses/{addressId}": {
-            "delete": {
+            "put": {
+                "description": "Replaces the fields of an address belonging to the authenticated user. Setting is_default makes it the default address and leaving it out keeps its current mark; orders already placed keep the address they were placed with.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User \u0026 Profile"
+                ],
+                "summary": "Update an address",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Address ID",
+                        "name": "addressId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Address Information",
+                        "name": "address",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddAddressPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_Address"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid address ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Address not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update address",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Deletes a specific address belonging to the authenticated user.",
+                ]
+            },
+            "delete": {
+                "description": "Deletes a specific address belonging to the authenticated user. When it was the default, the newest remaining address becomes the default.",
                 "produces": [
                     "application/json"
                 ],
@@ -153,17 +210,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/cart": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Retrieves the full contents of the user's shopping cart, with calculated totals.",
+                ]
+            }
+        },
+        "/cart": {
+            "get": {
+                "description": "Retrieves the full contents of the user's shopping cart, with calculated totals. Lines are priced at the current prices, discount prices included, and also show the prices they were added at; price_changed marks lines and carts whose price moved since. The tenant's taxes, service charges and fees are listed in charges and included in grand_total, except taxes already included in the prices.",
                 "produces": [
                     "application/json"
                 ],
@@ -184,17 +241,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/cart/items": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Adds a product with selected options and quantity to the user's shopping cart.",
+                ]
+            }
+        },
+        "/cart/items": {
+            "post": {
+                "description": "Adds a product with selected options and quantity to the user's shopping cart. If the cart already holds the product with exactly these options, that line's quantity is increased instead. The options are checked against the product's option groups; every problem is listed per group in data. Products or options that are unavailable or do not have enough stock are rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -224,7 +281,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or option selection",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_OptionSelectionProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or option not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Item is unavailable or out of stock",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
@@ -235,17 +304,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/cart/items/{itemId}": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Updates the quantity of a specific item in the user's cart.",
+                ]
+            }
+        },
+        "/cart/items/{itemId}": {
+            "put": {
+                "description": "Updates the quantity of a specific item in the user's cart and, when option_ids is given, replaces its option selection. If the line then matches another line of the same product and options, the two are merged.",
                 "consumes": [
                     "application/json"
                 ],
@@ -255,7 +324,7 @@ const docTemplate = `{
                 "tags": [
                     "Cart \u0026 Checkout"
                 ],
-                "summary": "Update item quantity",
+                "summary": "Update a cart item",
                 "parameters": [
                     {
                         "type": "integer",
@@ -265,8 +334,8 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "New Quantity",
-                        "name": "quantity",
+                        "description": "New quantity and optional option selection",
+                        "name": "item",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -276,15 +345,15 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item quantity updated",
+                        "description": "Cart item updated",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or item ID",
+                        "description": "Invalid request body, item ID or option selection",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-array_models_OptionSelectionProblem"
                         }
                     },
                     "404": {
@@ -293,20 +362,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
+                    "409": {
+                        "description": "Item is unavailable or out of stock",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
                     "500": {
                         "description": "Failed to update item",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "delete": {
                 "description": "Removes a specific item entirely from the user's shopping cart.",
                 "produces": [
                     "application/json"
@@ -349,79 +424,97 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/favorites": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/cart/promo": {
+            "post": {
+                "description": "Validates a promo code against the current cart and attaches it, returning the cart with its discount breakdown.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieves a list of all products that the authenticated user has marked as a favorite.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Favorites"
+                    "Cart \u0026 Checkout"
+                ],
+                "summary": "Apply a promo code to the cart",
+                "parameters": [
+                    {
+                        "description": "Promo code",
+                        "name": "promo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ApplyPromoPayload"
+                        }
+                    }
                 ],
-                "summary": "Get user's favorite products",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Promo code applied",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_Product"
+                            "$ref": "#/definitions/models.APIResponse-models_Cart"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, empty cart or promo code cannot be applied",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve favorites",
+                        "description": "Failed to apply promo code",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/notifications": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Retrieves a list of all notifications for the currently authenticated user.",
+                ]
+            },
+            "delete": {
+                "description": "Detaches the promo code currently applied to the user's cart.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications \u0026 Settings"
+                    "Cart \u0026 Checkout"
                 ],
-                "summary": "Get user's notifications",
+                "summary": "Remove the promo code from the cart",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Promo code removed",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_Notification"
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve notifications",
+                        "description": "Failed to remove promo code",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/notifications/read": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Marks one or more notifications as read for the authenticated user.",
+                ]
+            }
+        },
+        "/checkout": {
+            "post": {
+                "description": "Re-prices the authenticated user's cart on the server, applies its promo code, checks the fulfilment type, adds the tenant's taxes, service charges, fees and the delivery zone's fee, turns it into a new order and empties the cart, all in one transaction. Orders are delivered to address_id, picked up, or eaten in at table_number as the tenant allows; without fulfilment_type an order with an address is delivered and one without is picked up. When the tenant has delivery zones the address needs coordinates inside one of them and the order must reach the zone's minimum; the zone and its ETA are stored on the order. Without scheduled_for the order is made as soon as possible, which needs the store to stay open until the preparation lead time has passed and takes up a place in the slot the order is ready in; otherwise scheduled_for must be the start of a free slot from the store's availability. The options of every line are re-checked against the current option groups and stock is reserved for tracked products and options. When a payment method is given the order total is authorized on it and captured once the order is completed. If the cart total differs from the prices the items were added at, the checkout is rejected with the changed lines in data until it is repeated with accept_price_changes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -429,62 +522,97 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications \u0026 Settings"
+                    "Cart \u0026 Checkout"
                 ],
-                "summary": "Mark notifications as read",
+                "summary": "Check out the cart",
                 "parameters": [
                     {
-                        "description": "Notification IDs to mark as read",
-                        "name": "ids",
+                        "description": "Fulfilment type, delivery address or table, time slot and payment method",
+                        "name": "checkout",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MarkReadPayload"
+                            "$ref": "#/definitions/models.CheckoutPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Notifications marked as read",
+                    "201": {
+                        "description": "Order placed successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-models_Order"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body, empty cart, expired card or a fulfilment type, address or table that does not fit the store",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "402": {
+                        "description": "The payment was declined",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Address or payment method not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "The applied promo code is no longer valid, an item is out of stock, the options of a cart line no longer fit its product, prices changed and were not accepted, the cart changed while the payment was authorized, the store is closed or the time slot is not available",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to mark notifications as read",
+                        "description": "Failed to place order",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "502": {
+                        "description": "The payment processor could not complete the request",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/orders": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Retrieves a list of orders for the authenticated user, filterable by status.",
+                ]
+            }
+        },
+        "/favorites": {
+            "get": {
+                "description": "Retrieves a page of the products that the authenticated user has marked as a favorite, most recently favorited first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Favorites"
                 ],
-                "summary": "Get user's order history",
+                "summary": "Get user's favorite products",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Filter orders by status (e.g., Active, Completed, Cancelled)",
-                        "name": "status",
+                        "description": "Cursor from the previous page's pagination.next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields: newest (default, when favorited), price, rating, name, popularity; prefix a field with - to reverse it",
+                        "name": "sort",
                         "in": "query"
                     }
                 ],
@@ -492,96 +620,89 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_OrderSummaryView"
+                            "$ref": "#/definitions/models.APIResponse-array_models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid page size, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve orders",
+                        "description": "Failed to retrieve favorites",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/orders/{orderId}/cancel": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows an authenticated user to cancel one of their own active orders.",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/notifications": {
+            "get": {
+                "description": "Retrieves a page of the notifications of the currently authenticated user, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Notifications \u0026 Settings"
                 ],
-                "summary": "Cancel an active order",
+                "summary": "Get user's notifications",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
-                        "name": "orderId",
-                        "in": "path",
-                        "required": true
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Reason for cancellation",
-                        "name": "reason",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CancelOrderPayload"
-                        }
+                        "type": "string",
+                        "description": "Cursor from the previous page's pagination.next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field: newest (default); prefix it with - to reverse it",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order cancelled successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-array_models_Notification"
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID or request body",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
-                        }
-                    },
-                    "404": {
-                        "description": "Order not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
-                        }
-                    },
-                    "409": {
-                        "description": "Order cannot be cancelled",
+                        "description": "Invalid page size, sort or cursor",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to cancel order",
+                        "description": "Failed to retrieve notifications",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/orders/{orderId}/review": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows an authenticated user to leave a rating and comment for one of their own completed orders.",
+                ]
+            }
+        },
+        "/notifications/read": {
+            "put": {
+                "description": "Marks one or more notifications as read for the authenticated user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -589,326 +710,407 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Notifications \u0026 Settings"
                 ],
-                "summary": "Leave a review for an order",
+                "summary": "Mark notifications as read",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "orderId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Rating and comment for the order",
-                        "name": "review",
+                        "description": "Notification IDs to mark as read",
+                        "name": "ids",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LeaveReviewPayload"
+                            "$ref": "#/definitions/models.MarkReadPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Review submitted successfully",
+                    "200": {
+                        "description": "Notifications marked as read",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID or request body",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
-                        }
-                    },
-                    "404": {
-                        "description": "Order not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
-                        }
-                    },
-                    "409": {
-                        "description": "Order is not completed or a review already exists",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to leave review",
+                        "description": "Failed to mark notifications as read",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/payment-methods": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Retrieves a list of all saved payment methods for the authenticated user.",
+                ]
+            }
+        },
+        "/orders": {
+            "get": {
+                "description": "Retrieves a list of orders for the authenticated user, filterable by one or more statuses. Without a filter all open orders are returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User \u0026 Profile"
+                    "Orders"
+                ],
+                "summary": "Get user's order history",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter orders by status, repeated or comma separated (e.g., Pending,Preparing or Active for all open orders)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page's pagination.next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields: newest (default), price; prefix a field with - to reverse it",
+                        "name": "sort",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user's payment methods",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_PaymentMethod"
+                            "$ref": "#/definitions/models.APIResponse-array_models_OrderSummaryView"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order status, page size, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve payment methods",
+                        "description": "Failed to retrieve orders",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Adds a new payment method to the user's profile using a token from a payment processor.",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/orders/{orderId}": {
+            "get": {
+                "description": "Retrieves one of the authenticated user's orders with its line items, chosen options, price breakdown, delivery address, payment method, review and status history.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User \u0026 Profile"
+                    "Orders"
                 ],
-                "summary": "Add a new payment method",
+                "summary": "Get the details of an order",
                 "parameters": [
                     {
-                        "description": "Payment Processor Token",
-                        "name": "token",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.AddPaymentMethodPayload"
-                        }
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Payment method added successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-models_OrderDetails"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to add payment method",
+                        "description": "Failed to retrieve order",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/payment-methods/{methodId}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/orders/{orderId}/cancel": {
+            "post": {
+                "description": "Allows an authenticated user to cancel one of their own orders as long as it is still Pending or Accepted.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Deletes a specific payment method belonging to the authenticated user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User \u0026 Profile"
+                    "Orders"
                 ],
-                "summary": "Delete a payment method",
+                "summary": "Cancel an active order",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Payment Method ID",
-                        "name": "methodId",
+                        "description": "Order ID",
+                        "name": "orderId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reason for cancellation",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CancelOrderPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Payment method deleted successfully",
+                        "description": "Order cancelled successfully",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "400": {
-                        "description": "Invalid payment method ID",
+                        "description": "Invalid order ID or request body",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "404": {
-                        "description": "Payment method not found",
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Order cannot be cancelled",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete payment method",
+                        "description": "Failed to cancel order",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "502": {
+                        "description": "The payment processor could not release the payment",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/products/{productId}/favorite": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/orders/{orderId}/reorder": {
+            "post": {
+                "description": "Puts the lines of one of the user's past orders back into their cart. Lines whose product or options were deleted, that are out of stock or whose price changed are skipped and listed, unless price changes are accepted.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Adds a specific product to the authenticated user's list of favorites.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Favorites"
+                    "Orders"
                 ],
-                "summary": "Add a product to favorites",
+                "summary": "Reorder a past order",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "description": "Order ID",
+                        "name": "orderId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reorder options",
+                        "name": "options",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReorderPayload"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Product added to favorites",
+                    "200": {
+                        "description": "The updated cart and the lines that were skipped",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-models_ReorderResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID",
+                        "description": "Invalid order ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to add to favorites",
+                        "description": "Failed to reorder",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            }
+        },
+        "/orders/{orderId}/review": {
+            "post": {
+                "description": "Allows an authenticated user to leave a rating and comment for one of their own completed orders.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Removes a specific product from the authenticated user's list of favorites.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Favorites"
+                    "Orders"
                 ],
-                "summary": "Remove a product from favorites",
+                "summary": "Leave a review for an order",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "description": "Order ID",
+                        "name": "orderId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Rating and comment for the order",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LeaveReviewPayload"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Product removed from favorites",
+                    "201": {
+                        "description": "Review submitted successfully",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID",
+                        "description": "Invalid order ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not completed or a review already exists",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to remove from favorites",
+                        "description": "Failed to leave review",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/profile": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Retrieves the profile information for the currently authenticated user.",
+                ]
+            }
+        },
+        "/payment-methods": {
+            "get": {
+                "description": "Retrieves a list of all saved payment methods for the authenticated user, default card first, flagging cards that have expired.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "User \u0026 Profile"
                 ],
-                "summary": "Get user profile",
+                "summary": "Get user's payment methods",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_User"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-array_models_PaymentMethod"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve profile",
+                        "description": "Failed to retrieve payment methods",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Updates the profile information for the currently authenticated user.",
+                ]
+            },
+            "post": {
+                "description": "Adds a new payment method to the user's profile using a token from a payment processor. The card brand, last four digits and expiry are looked up with the processor; expired cards are rejected. The first card becomes the default.",
                 "consumes": [
                     "application/json"
                 ],
@@ -918,209 +1120,283 @@ const docTemplate = `{
                 "tags": [
                     "User \u0026 Profile"
                 ],
-                "summary": "Update user profile",
+                "summary": "Add a new payment method",
                 "parameters": [
                     {
-                        "description": "Updated profile information",
-                        "name": "profile",
+                        "description": "Payment Processor Token",
+                        "name": "token",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateProfilePayload"
+                            "$ref": "#/definitions/models.AddPaymentMethodPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Profile updated successfully",
+                    "201": {
+                        "description": "Payment method added successfully",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body, unknown card token or expired card",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to update profile",
+                        "description": "Failed to add payment method",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "502": {
+                        "description": "The payment processor could not complete the request",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Permanently deletes the account of the currently authenticated user. This action is irreversible.",
+                ]
+            }
+        },
+        "/payment-methods/{methodId}": {
+            "delete": {
+                "description": "Deletes a specific payment method belonging to the authenticated user. When the default card is deleted the newest remaining card becomes the default, preferring cards that have not expired.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "User \u0026 Profile"
                 ],
-                "summary": "Delete user account",
+                "summary": "Delete a payment method",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "methodId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Account deleted successfully",
+                        "description": "Payment method deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment method ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payment method not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete account",
+                        "description": "Failed to delete payment method",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/profile/change-password": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows an authenticated user to change their password by providing their current password.",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/payment-methods/{methodId}/default": {
+            "put": {
+                "description": "Makes one of the authenticated user's cards the default and clears the flag on all others.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "User \u0026 Profile"
                 ],
-                "summary": "Change user password",
+                "summary": "Set the default payment method",
                 "parameters": [
                     {
-                        "description": "Current and new password",
-                        "name": "passwords",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ChangePasswordPayload"
-                        }
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "methodId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Password changed successfully",
+                        "description": "Default payment method updated successfully",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid payment method ID or the card has expired",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "401": {
-                        "description": "The current password is incorrect",
+                    "404": {
+                        "description": "Payment method not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to change password",
+                        "description": "Failed to update default payment method",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/profile/notification-settings": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Retrieves the notification preferences for the currently authenticated user.",
+                ]
+            }
+        },
+        "/products/{productId}/favorite": {
+            "post": {
+                "description": "Adds a specific product to the authenticated user's list of favorites.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications \u0026 Settings"
+                    "Favorites"
+                ],
+                "summary": "Add a product to favorites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get notification settings",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Product added to favorites",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_NotificationPreferences"
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve settings",
+                        "description": "Failed to add to favorites",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Updates the notification preferences for the currently authenticated user.",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            },
+            "delete": {
+                "description": "Removes a specific product from the authenticated user's list of favorites.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications \u0026 Settings"
+                    "Favorites"
                 ],
-                "summary": "Update notification settings",
+                "summary": "Remove a product from favorites",
                 "parameters": [
                     {
-                        "description": "New notification settings",
-                        "name": "settings",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.NotificationPreferences"
-                        }
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Settings updated successfully",
+                        "description": "Product removed from favorites",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid product ID",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to update settings",
+                        "description": "Failed to remove from favorites",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/superadmin/login": {
-            "post": {
-                "description": "Authenticates a super admin and returns a special JWT for platform management.",
+        "/profile": {
+            "get": {
+                "description": "Retrieves the profile information for the currently authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User \u0026 Profile"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_User"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve profile",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Updates the profile information for the currently authenticated user.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1128,25 +1404,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Super Admin - Authentication"
+                    "User \u0026 Profile"
                 ],
-                "summary": "Super Admin Login",
+                "summary": "Update user profile",
                 "parameters": [
                     {
-                        "description": "Super Admin Credentials",
-                        "name": "credentials",
+                        "description": "Updated profile information",
+                        "name": "profile",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.SuperAdminLoginPayload"
+                            "$ref": "#/definitions/models.UpdateProfilePayload"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "Profile updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_LoginResponse"
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "400": {
@@ -1155,58 +1431,52 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "401": {
-                        "description": "Invalid credentials",
+                    "500": {
+                        "description": "Failed to update profile",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/superadmin/tenants": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Retrieves a list of all tenants on the platform.",
+                ]
+            },
+            "delete": {
+                "description": "Permanently deletes the account of the currently authenticated user. This action is irreversible.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Super Admin - Tenant Management"
+                    "User \u0026 Profile"
                 ],
-                "summary": "Get all tenants",
+                "summary": "Delete user account",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_Tenant"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                        "description": "Account deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve tenants",
+                        "description": "Failed to delete account",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows a super admin to create a new tenant on the platform.",
+                ]
+            }
+        },
+        "/profile/change-password": {
+            "put": {
+                "description": "Allows an authenticated user to change their password by providing their current password.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1214,23 +1484,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Super Admin - Tenant Management"
+                    "User \u0026 Profile"
                 ],
-                "summary": "Create a new tenant",
+                "summary": "Change user password",
                 "parameters": [
                     {
-                        "description": "New Tenant Information",
-                        "name": "tenant",
+                        "description": "Current and new password",
+                        "name": "passwords",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateTenantPayload"
+                            "$ref": "#/definitions/models.ChangePasswordPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Tenant created successfully",
+                    "200": {
+                        "description": "Password changed successfully",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
@@ -1241,116 +1511,109 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "The current password is incorrect",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to create tenant",
+                        "description": "Failed to change password",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/superadmin/tenants/{tenantId}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Permanently deletes a tenant and all of their associated data from the platform.",
+                ]
+            }
+        },
+        "/profile/notification-settings": {
+            "get": {
+                "description": "Retrieves the notification preferences for the currently authenticated user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Super Admin - Tenant Management"
-                ],
-                "summary": "Delete a tenant",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Tenant ID to delete",
-                        "name": "tenantId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Notifications \u0026 Settings"
                 ],
+                "summary": "Get notification settings",
                 "responses": {
                     "200": {
-                        "description": "Tenant deleted successfully",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-models_NotificationPreferences"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete tenant",
+                        "description": "Failed to retrieve settings",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/tenant/{tenantId}": {
-            "get": {
-                "description": "Retrieves the public configuration for a specific tenant, such as name, logo, theme, and contact info.",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Updates the notification preferences for the currently authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Public"
+                    "Notifications \u0026 Settings"
                 ],
-                "summary": "Get tenant configuration",
+                "summary": "Update notification settings",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "The unique name of the tenant",
-                        "name": "tenantId",
-                        "in": "path",
-                        "required": true
+                        "description": "New notification settings",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationPreferences"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Configuration fetched successfully",
+                        "description": "Settings updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_TenantConfig"
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "404": {
-                        "description": "Tenant not found",
+                    "400": {
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Could not retrieve tenant configuration",
+                        "description": "Failed to update settings",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/{tenantId}/admin/config": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows a tenant admin to update their own store's configuration (e.g., name, theme, contact info).",
+                ]
+            }
+        },
+        "/superadmin/login": {
+            "post": {
+                "description": "Authenticates a super admin and returns a special JWT for platform management.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1358,32 +1621,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Panel - Configuration"
+                    "Super Admin - Authentication"
                 ],
-                "summary": "Update tenant configuration",
+                "summary": "Super Admin Login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated Tenant Configuration",
-                        "name": "config",
+                        "description": "Super Admin Credentials",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.TenantConfig"
+                            "$ref": "#/definitions/models.SuperAdminLoginPayload"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tenant configuration updated successfully",
+                        "description": "Login successful",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-models_LoginResponse"
                         }
                     },
                     "400": {
@@ -1392,14 +1648,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to update tenant configuration",
+                    "401": {
+                        "description": "Invalid credentials",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
@@ -1407,35 +1657,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/{tenantId}/admin/customers": {
+        "/superadmin/tenants": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Allows a tenant admin to view a list of all customers who have registered with their store.",
+                "description": "Retrieves a list of all tenants on the platform.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Panel - Customer Management"
-                ],
-                "summary": "Get all customers for the tenant",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantId",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Super Admin - Tenant Management"
                 ],
+                "summary": "Get all tenants",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_User"
+                            "$ref": "#/definitions/models.APIResponse-array_models_Tenant"
                         }
                     },
                     "403": {
@@ -1445,43 +1681,52 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve customers",
+                        "description": "Failed to retrieve tenants",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/{tenantId}/admin/dashboard/stats": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
+                ]
+            },
+            "post": {
+                "description": "Allows a super admin to create a new tenant on the platform.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieves key performance statistics for the tenant's store, such as total revenue and orders today.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Panel - Dashboard"
+                    "Super Admin - Tenant Management"
                 ],
-                "summary": "Get dashboard analytics",
+                "summary": "Create a new tenant",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantId",
-                        "in": "path",
-                        "required": true
+                        "description": "New Tenant Information",
+                        "name": "tenant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateTenantPayload"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Tenant created successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_DashboardStats"
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "403": {
@@ -1491,49 +1736,43 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve dashboard stats",
+                        "description": "Failed to create tenant",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/{tenantId}/admin/orders": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows a tenant admin to view all orders placed for their store, filterable by status.",
+                ]
+            }
+        },
+        "/superadmin/tenants/{tenantId}": {
+            "delete": {
+                "description": "Permanently deletes a tenant and all of their associated data from the platform.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Panel - Order Management"
+                    "Super Admin - Tenant Management"
                 ],
-                "summary": "Get all orders for the tenant",
+                "summary": "Delete a tenant",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
+                        "description": "Tenant ID to delete",
                         "name": "tenantId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter orders by status (e.g., Active, Completed, Cancelled)",
-                        "name": "status",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Tenant deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_Order"
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "403": {
@@ -1543,69 +1782,85 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve orders",
+                        "description": "Failed to delete tenant",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/{tenantId}/admin/orders/{orderId}/status": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows a tenant admin to update the status of a specific order (e.g., to 'Preparing', 'Completed').",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/tenant/{tenantId}": {
+            "get": {
+                "description": "Retrieves the public configuration for a specific tenant, such as name, logo, theme, and contact info.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Panel - Order Management"
+                    "Public"
                 ],
-                "summary": "Update an order's status",
+                "summary": "Get tenant configuration",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tenant ID",
+                        "description": "The unique name of the tenant",
                         "name": "tenantId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Order ID",
-                        "name": "orderId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "New Order Status",
-                        "name": "status",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateOrderStatusPayload"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order status updated successfully",
+                        "description": "Configuration fetched successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_TenantConfig"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "500": {
+                        "description": "Could not retrieve tenant configuration",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/admin/categories": {
+            "get": {
+                "description": "Allows a tenant admin to list all categories of their store, hidden ones included, in display order. Nesting is given by parent_id.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Categories"
+                ],
+                "summary": "List categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_Category"
+                        }
                     },
                     "403": {
                         "description": "Forbidden",
@@ -1613,23 +1868,21 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "404": {
-                        "description": "Order not found",
+                    "500": {
+                        "description": "Failed to retrieve categories",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/{tenantId}/admin/products": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows a tenant admin to create a new product for their store.",
+                ]
+            },
+            "post": {
+                "description": "Allows a tenant admin to add a category, optionally under a parent category. Names are unique per store regardless of case. available_from and available_until (HH:MM, in the store's time zone) limit when the category and everything below it can be ordered; a window ending at or before its start runs past midnight.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1637,9 +1890,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Panel - Product Management"
+                    "Admin Panel - Categories"
                 ],
-                "summary": "Create a new product",
+                "summary": "Create a category",
                 "parameters": [
                     {
                         "type": "string",
@@ -1649,24 +1902,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "New Product Data",
-                        "name": "product",
+                        "description": "New Category",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProductPayload"
+                            "$ref": "#/definitions/models.CategoryPayload"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Product created successfully",
+                        "description": "Category created successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_CreateProductResponse"
+                            "$ref": "#/definitions/models.APIResponse-models_Category"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or category",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
@@ -1677,23 +1930,29 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
+                    "409": {
+                        "description": "A category with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to create product",
+                        "description": "Failed to create category",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/{tenantId}/admin/products/{productId}": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows a tenant admin to update the details of an existing product.",
+                ]
+            }
+        },
+        "/{tenantId}/admin/categories/{categoryId}": {
+            "put": {
+                "description": "Allows a tenant admin to change a category, including moving it under another parent. A new name is applied to the category's products and tags and to the promotions and taxes limited to it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1701,9 +1960,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Panel - Product Management"
+                    "Admin Panel - Categories"
                 ],
-                "summary": "Update an existing product",
+                "summary": "Update a category",
                 "parameters": [
                     {
                         "type": "string",
@@ -1714,30 +1973,30 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "description": "Category ID",
+                        "name": "categoryId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Product Data",
-                        "name": "product",
+                        "description": "Updated Category",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ProductPayload"
+                            "$ref": "#/definitions/models.CategoryPayload"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Product created successfully",
+                    "200": {
+                        "description": "Category updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_CreateProductResponse"
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or category",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
@@ -1748,28 +2007,40 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "A category with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to update product",
+                        "description": "Failed to update category",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Allows a tenant admin to delete a product from their store.",
+                ]
+            },
+            "delete": {
+                "description": "Allows a tenant admin to delete an empty category. Categories that still have products, tags or subcategories, or that promotions or taxes are limited to, cannot be deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Panel - Product Management"
+                    "Admin Panel - Categories"
                 ],
-                "summary": "Delete a product",
+                "summary": "Delete a category",
                 "parameters": [
                     {
                         "type": "string",
@@ -1780,15 +2051,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "description": "Category ID",
+                        "name": "categoryId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deleted successfully",
+                        "description": "Category deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
@@ -1799,25 +2070,45 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Category is still in use",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to delete product",
+                        "description": "Failed to delete category",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/{tenantId}/faqs": {
-            "get": {
-                "description": "Retrieves a list of frequently asked questions for a specific tenant, optionally filtered by category.",
+        "/{tenantId}/admin/config": {
+            "put": {
+                "description": "Allows a tenant admin to update their own store's configuration (e.g., name, theme, contact info, currency and rounding rule). All prices are kept in minor units of the currency, so the currency can only be changed while the store has no products, promotions or orders; leaving it out keeps the current one. Taxes (inclusive or exclusive, optionally limited to main categories), service charges and fixed fees configured here apply to carts and new orders; placed orders keep the charges they were placed with. fulfilmentTypes limits how orders are fulfilled (delivery and pickup by default); deliveryZones, each a radiusKm around the store location or a polygon, set where the store delivers with a fee, minimum order and ETA per zone. openingHours (per weekday, in timezone), closures, prepMinutes, slotMinutes, scheduleDays and slotCapacity decide when orders are taken and which slots can be scheduled.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Public"
+                    "Admin Panel - Configuration"
                 ],
-                "summary": "Get FAQs for a tenant",
+                "summary": "Update tenant configuration",
                 "parameters": [
                     {
                         "type": "string",
@@ -1827,132 +2118,94 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter FAQs by category",
-                        "name": "category",
-                        "in": "query"
+                        "description": "Updated Tenant Configuration",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TenantConfig"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Tenant configuration updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_FAQ"
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "500": {
-                        "description": "Failed to retrieve FAQs",
+                    "400": {
+                        "description": "Invalid request body or delivery zone",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
-                    }
-                }
-            }
-        },
-        "/{tenantId}/login": {
-            "post": {
-                "description": "Authenticates a user for a specific tenant and returns a JWT token.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Log in a user",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Tenant ID",
-                        "name": "tenantId",
-                        "in": "path",
-                        "required": true
                     },
-                    {
-                        "description": "User Login Credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.LoginPayload"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Login successful",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_LoginResponse"
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "404": {
+                        "description": "Tenant not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
-                    "401": {
-                        "description": "Invalid credentials",
+                    "409": {
+                        "description": "The currency cannot be changed",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Login failed",
+                        "description": "Failed to update tenant configuration",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/{tenantId}/products": {
+        "/{tenantId}/admin/customers": {
             "get": {
-                "description": "Retrieves a list of products for a tenant, with optional filters for category, tags, price, and sorting.",
+                "description": "Allows a tenant admin to page through the customers who have registered with their store.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Public - Products"
+                    "Admin Panel - Customer Management"
                 ],
-                "summary": "Search and filter products",
+                "summary": "Get all customers for the tenant",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Tenant ID",
                         "name": "tenantId",
-                        "in": "path"
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Filter by main category (e.g., Meal, Drink)",
-                        "name": "category",
+                        "type": "integer",
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by comma-separated tags (e.g., Pizza,Cheese)",
-                        "name": "tags",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Minimum price filter",
-                        "name": "min_price",
-                        "in": "query"
-                    },
-                    {
-                        "type": "number",
-                        "description": "Maximum price filter",
-                        "name": "max_price",
+                        "description": "Cursor from the previous page's pagination.next_cursor",
+                        "name": "cursor",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort order (e.g., rating_desc)",
-                        "name": "sort_by",
+                        "description": "Comma separated sort fields: newest (default), name; prefix a field with - to reverse it",
+                        "name": "sort",
                         "in": "query"
                     }
                 ],
@@ -1960,28 +2213,45 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_Product"
+                            "$ref": "#/definitions/models.APIResponse-array_models_User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid page size, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to search for products",
+                        "description": "Failed to retrieve customers",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/{tenantId}/products/bestsellers": {
+        "/{tenantId}/admin/dashboard/stats": {
             "get": {
-                "description": "Retrieves a list of the most popular products for a tenant, based on sales volume.",
+                "description": "Retrieves key performance statistics for the tenant's store, such as total revenue (net of refunds), refunded amount and orders today.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Public - Products"
+                    "Admin Panel - Dashboard"
                 ],
-                "summary": "Get best-selling products",
+                "summary": "Get dashboard analytics",
                 "parameters": [
                     {
                         "type": "string",
@@ -1995,28 +2265,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_Product"
+                            "$ref": "#/definitions/models.APIResponse-models_DashboardStats"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve best sellers",
+                        "description": "Failed to retrieve dashboard stats",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/{tenantId}/products/featured": {
-            "get": {
-                "description": "Retrieves the main promotional product for a tenant, often used for a large banner.",
+        "/{tenantId}/admin/options/{optionId}/stock": {
+            "put": {
+                "description": "Allows a tenant admin to set the stock of a single option (e.g. an add-on) and mark it as available or unavailable. Leaving stock_quantity empty stops tracking stock for the option.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Public - Products"
+                    "Admin Panel - Product Management"
                 ],
-                "summary": "Get the featured product",
+                "summary": "Update option stock",
                 "parameters": [
                     {
                         "type": "string",
@@ -2024,40 +2308,73 @@ const docTemplate = `{
                         "name": "tenantId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Option ID",
+                        "name": "optionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock level and availability",
+                        "name": "stock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Stock updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_Product"
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid option ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "404": {
-                        "description": "No featured product found",
+                        "description": "Option not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve featured product",
+                        "description": "Failed to update stock",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/{tenantId}/products/recommended": {
+        "/{tenantId}/admin/orders": {
             "get": {
-                "description": "Retrieves a list of products marked as 'recommended' by the tenant admin.",
+                "description": "Allows a tenant admin to view all orders placed for their store, filterable by one or more statuses. Without a filter all open orders are returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Public - Products"
+                    "Admin Panel - Order Management"
                 ],
-                "summary": "Get recommended products",
+                "summary": "Get all orders for the tenant",
                 "parameters": [
                     {
                         "type": "string",
@@ -2065,34 +2382,79 @@ const docTemplate = `{
                         "name": "tenantId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Filter orders by status, repeated or comma separated (e.g., Pending,Preparing or Active for all open orders)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page's pagination.next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields: newest (default), price; prefix a field with - to reverse it",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_Product"
+                            "$ref": "#/definitions/models.APIResponse-array_models_Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order status, page size, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve recommended products",
+                        "description": "Failed to retrieve orders",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/{tenantId}/products/{productId}": {
+        "/{tenantId}/admin/orders/{orderId}": {
             "get": {
-                "description": "Retrieves detailed information for a single product, including its customization options.",
+                "description": "Allows a tenant admin to view an order with its line items, chosen options, price breakdown, delivery address, payment method, cancellation reason, review and status history.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Public - Products"
+                    "Admin Panel - Order Management"
                 ],
-                "summary": "Get product details",
+                "summary": "Get the details of an order",
                 "parameters": [
                     {
                         "type": "string",
@@ -2103,8 +2465,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "productId",
+                        "description": "Order ID",
+                        "name": "orderId",
                         "in": "path",
                         "required": true
                     }
@@ -2113,33 +2475,107 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-models_Product"
+                            "$ref": "#/definitions/models.APIResponse-models_OrderDetails"
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID",
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve product details",
+                        "description": "Failed to retrieve order",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        },
-        "/{tenantId}/register": {
-            "post": {
-                "description": "Creates a new user account for a specific tenant.",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/orders/{orderId}/refunds": {
+            "get": {
+                "description": "Allows a tenant admin to see every refund issued for an order, with its lines, amount, reason and operator.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Order Management"
+                ],
+                "summary": "List the refunds of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_Refund"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve refunds",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Allows a tenant admin to refund a completed or cancelled order, either completely (no items) or per line and quantity. Line refunds are reduced by the line's share of the order discount. The money goes back through the payment gateway and the customer is notified; once everything is refunded the order moves to Refunded. Card orders can only be refunded once their payment has been captured, so a cancelled card order whose authorization was voided is rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2147,9 +2583,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin Panel - Order Management"
                 ],
-                "summary": "Register a new user",
+                "summary": "Refund an order",
                 "parameters": [
                     {
                         "type": "string",
@@ -2159,53 +2595,86 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "User Registration Info",
-                        "name": "user",
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Refund reason and optional lines",
+                        "name": "refund",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RegisterPayload"
+                            "$ref": "#/definitions/models.RefundPayload"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "User created successfully",
+                        "description": "Refund issued successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-any"
+                            "$ref": "#/definitions/models.APIResponse-array_models_Refund"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid order ID, request body or refund lines",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "409": {
-                        "description": "User with this email already exists",
+                        "description": "Order is not completed or cancelled, its card payment was never captured, or it has been fully refunded",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to create user",
+                        "description": "Failed to refund order",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "502": {
+                        "description": "The payment processor could not complete the refund",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/{tenantId}/tags": {
-            "get": {
-                "description": "Retrieves a list of all available tags for a specific tenant, used for building filter UIs.",
+        "/{tenantId}/admin/orders/{orderId}/status": {
+            "put": {
+                "description": "Allows a tenant admin to move an order to its next status (Pending → Accepted → Preparing → ReadyForPickup/OutForDelivery → Completed, or Cancelled/Refunded). Only delivery orders go OutForDelivery. Illegal transitions are rejected and every change is recorded in the order's status history. Completing an order captures its authorized payment, cancelling it voids the authorization and refunding it pays back whatever has not been refunded yet. Card orders can only be refunded once their payment has been captured.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Public - Products"
+                    "Admin Panel - Order Management"
                 ],
-                "summary": "Get all filter tags",
+                "summary": "Update an order's status",
                 "parameters": [
                     {
                         "type": "string",
@@ -2213,953 +2682,5505 @@ const docTemplate = `{
                         "name": "tenantId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New Order Status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateOrderStatusPayload"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Order status updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse-array_models_Tag"
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID, request body or status",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "The order cannot move to this status",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve tags",
+                        "description": "Failed to update order status",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "502": {
+                        "description": "The payment could not be captured or voided",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse-any"
                         }
                     }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "models.APIResponse-any": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "error": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.APIResponse-array_models_Address": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Address"
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
-                },
-                "error": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
-                }
+                ]
             }
         },
-        "models.APIResponse-array_models_FAQ": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.FAQ"
+        "/{tenantId}/admin/products": {
+            "post": {
+                "description": "Allows a tenant admin to create a new product for their store. Prices are in minor units of the store's currency. The product goes into an existing category, given by category_id or by name in main_category. Tags are given by name; tags the store does not have yet are created in the product's category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New Product Data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductPayload"
+                        }
                     }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_CreateProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, tag or category",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create product",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/products/{productId}": {
+            "put": {
+                "description": "Allows a tenant admin to update the details of an existing product. Listing tags replaces the product's tags, creating missing ones; leaving them out keeps the current tags.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Update an existing product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Product Data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_CreateProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, tag or category",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update product",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Allows a tenant admin to delete a product from their store.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete product",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/products/{productId}/option-groups": {
+            "get": {
+                "description": "Allows a tenant admin to list the option groups of a product with their options, in display order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "List option groups of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_OptionGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve option groups",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Allows a tenant admin to add an option group (e.g. size or extra toppings) to a product, optionally with its options. selection_type is single or multiple; a single choice group allows exactly one selection. A group with min_selections above zero is required.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Create an option group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New option group",
+                        "name": "optionGroup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OptionGroupPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Option group created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_OptionGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID, request body or selection limits",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create option group",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/products/{productId}/option-groups/{groupId}": {
+            "put": {
+                "description": "Allows a tenant admin to change the name, selection limits and position of an option group. Options sent in the body are ignored; use the option endpoints to change them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Update an option group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Option group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated option group",
+                        "name": "optionGroup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OptionGroupPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Option group updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, request body or selection limits",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Option group not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update option group",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Allows a tenant admin to delete an option group and all of its options. Carts holding these options lose them; past orders keep their option names.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Delete an option group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Option group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Option group deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Option group not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete option group",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/products/{productId}/option-groups/{groupId}/options": {
+            "post": {
+                "description": "Allows a tenant admin to add an option to an option group. Marking it as default in a single choice group replaces the previous default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Add an option to a group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Option group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New option",
+                        "name": "option",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OptionPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Option created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_Option"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, request body or too many defaults",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Option group not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create option",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/products/{productId}/option-groups/{groupId}/options/{optionId}": {
+            "put": {
+                "description": "Allows a tenant admin to change the name, price, default flag, position and availability of an option.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Update an option",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Option group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Option ID",
+                        "name": "optionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated option",
+                        "name": "option",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.OptionPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Option updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID, request body or too many defaults",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Option group or option not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update option",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Allows a tenant admin to remove an option from a group. A group cannot drop below its min_selections.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Delete an option",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Option group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Option ID",
+                        "name": "optionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Option deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or the group would have too few options",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Option group or option not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete option",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/products/{productId}/stock": {
+            "put": {
+                "description": "Allows a tenant admin to set the stock of a product and mark it as available or unavailable. Leaving stock_quantity empty stops tracking stock for the product. Admins are notified when the stock drops to the low stock threshold (5 by default).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Product Management"
+                ],
+                "summary": "Update product stock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock level and availability",
+                        "name": "stock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stock updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update stock",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/promotions": {
+            "get": {
+                "description": "Allows a tenant admin to list all promo codes of their store, including how often each has been redeemed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Promotions"
+                ],
+                "summary": "List promotions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_Promotion"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve promotions",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Allows a tenant admin to create a promo code (percentage, fixed amount or free item) with optional limits, validity window and category/tag restrictions. A percentage code uses value; a fixed amount code uses amount, in minor units like min_basket, or value in the major unit of the store's currency as before.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Promotions"
+                ],
+                "summary": "Create a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New Promotion",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PromotionPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Promotion created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "A promotion with this code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create promotion",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/promotions/{promotionId}": {
+            "put": {
+                "description": "Allows a tenant admin to change the rules of an existing promo code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Promotions"
+                ],
+                "summary": "Update a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "promotionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Promotion",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PromotionPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "A promotion with this code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update promotion",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Allows a tenant admin to delete a promo code that has never been redeemed. Redeemed codes must be deactivated instead so their history stays auditable.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Promotions"
+                ],
+                "summary": "Delete a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "promotionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Promotion has already been redeemed",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete promotion",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/promotions/{promotionId}/redemptions": {
+            "get": {
+                "description": "Allows a tenant admin to audit which orders used a promo code and how much discount each received.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Promotions"
+                ],
+                "summary": "List redemptions of a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "promotionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_PromotionRedemption"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve redemptions",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/tags": {
+            "get": {
+                "description": "Allows a tenant admin to list the product tags of their store.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Tags"
+                ],
+                "summary": "List tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_Tag"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tags",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Allows a tenant admin to add a product tag to their store, in an existing category given by category_id or main_category. Names are unique per store regardless of case and cannot contain commas.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Tags"
+                ],
+                "summary": "Create a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New Tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TagPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Tag created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, tag name or category",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "A tag with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/tags/{tagId}": {
+            "put": {
+                "description": "Allows a tenant admin to rename a tag or move it to another category. A new name is applied to every product with the tag and to promotions restricted to it. Renaming a tag to the name of another tag is refused; merge them instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Tags"
+                ],
+                "summary": "Update a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Tag",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TagPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, tag name or category",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "A tag with this name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Allows a tenant admin to delete a tag, removing it from all products. Tags that promotions are restricted to cannot be deleted; merge them into another tag or change the promotions first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Tags"
+                ],
+                "summary": "Delete a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tag ID",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tag deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Tag is used by promotions",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete tag",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/admin/tags/{tagId}/merge": {
+            "post": {
+                "description": "Allows a tenant admin to fold a tag into another one. Products with the tag get the other tag instead, promotions restricted to it are restricted to the other tag, and the tag is deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Panel - Tags"
+                ],
+                "summary": "Merge a tag into another",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the tag to merge away",
+                        "name": "tagId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag to merge into",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeTagPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tags merged successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to merge tags",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/{tenantId}/availability": {
+            "get": {
+                "description": "Tells whether the store takes orders right now, when it opens next if it is closed, and lists the next time slots an order can be scheduled for, taking opening hours, closures, the preparation lead time and slot capacity into account. Times are in UTC; timezone is the store's own zone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Get store availability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of slots to return (default and maximum 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_Availability"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve availability",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/categories": {
+            "get": {
+                "description": "Retrieves the visible categories of a store nested under their parents, in display order. available_now tells whether a category, and every category above it, is served at the moment; categories with serving hours (such as a breakfast menu) are only served between available_from and available_until in the store's time zone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public - Products"
+                ],
+                "summary": "Get the category tree",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_Category"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve categories",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/faqs": {
+            "get": {
+                "description": "Retrieves a list of frequently asked questions for a specific tenant, optionally filtered by category.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
+                ],
+                "summary": "Get FAQs for a tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter FAQs by category",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_FAQ"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve FAQs",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/forgot-password": {
+            "post": {
+                "description": "Sends a single-use password reset link to the given e-mail if it belongs to a user of the tenant. The link opens the tenant's passwordResetUrl, or PASSWORD_RESET_URL for tenants without one. The response is the same whether or not the address is registered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Account e-mail",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ForgotPasswordPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "If the e-mail is registered, a reset link has been sent",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to request password reset",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "503": {
+                        "description": "Password reset is not set up for this store",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/guest/cart": {
+            "get": {
+                "description": "Retrieves the cart of a visitor without an account, with calculated totals, taxes and fees, current and snapshot prices. A missing, unknown or expired token returns an empty cart.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart \u0026 Checkout"
+                ],
+                "summary": "Get a guest cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "X-Cart-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_Cart"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve cart",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/guest/cart/items": {
+            "post": {
+                "description": "Adds a product to the cart of a visitor without an account, with the same option and stock checks as the user cart. Without a valid X-Cart-Token a new guest cart is started. The cart token is returned in data and in the X-Cart-Token response header; send it with later guest cart requests and on login or registration to keep the items. Guest carts expire after a week without changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart \u0026 Checkout"
+                ],
+                "summary": "Add an item to a guest cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "X-Cart-Token",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Item to Add",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddToCartPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item added to cart",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_GuestCartResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or option selection",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_OptionSelectionProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Product or option not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Item is unavailable or out of stock",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to add item to cart",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/guest/cart/items/{itemId}": {
+            "put": {
+                "description": "Updates the quantity and, when option_ids is given, the option selection of a line in a guest cart. Lines that end up identical are merged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart \u0026 Checkout"
+                ],
+                "summary": "Update a guest cart item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "X-Cart-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cart Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New quantity and optional option selection",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateCartItemPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cart item updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, item ID or option selection",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_OptionSelectionProblem"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart item not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Item is unavailable or out of stock",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update item",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a line from a guest cart.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart \u0026 Checkout"
+                ],
+                "summary": "Remove item from a guest cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "X-Cart-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cart Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item removed from cart",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart item not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to remove item",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/guest/checkout": {
+            "post": {
+                "description": "Turns a guest cart into an order without an account. The cart is re-priced and re-checked like a user checkout, stock is reserved and the order is paid at the store. Guests pick their order up or, with a table_number, eat in, as the tenant allows, either as soon as possible or in a slot chosen with scheduled_for. Taxes, service charges and fees are added as for a user checkout. Promo codes are not available to guests. Price changes since the items were added must be accepted with accept_price_changes. The confirmation and later status updates are sent to the given e-mail address, and the guest cart is removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart \u0026 Checkout"
+                ],
+                "summary": "Check out a guest cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "X-Cart-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Contact details",
+                        "name": "checkout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GuestCheckoutPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order placed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, empty cart or a fulfilment type the store does not offer",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "An item is out of stock, the options of a cart line no longer fit its product, prices changed and were not accepted, the store is closed or the time slot is not available",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to place order",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/login": {
+            "post": {
+                "description": "Authenticates a user for a specific tenant and returns a JWT token. If an X-Cart-Token header is sent, the items of that guest cart are merged into the user's cart.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Log in a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User Login Credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginPayload"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart to merge into the user's cart",
+                        "name": "X-Cart-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Login failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/products": {
+            "get": {
+                "description": "Retrieves a page of products for a tenant, with optional keyword search, filters for category, tags, price, rating and availability, sorting, and facet counts for filter UIs. Keyword searches look at names, descriptions and tags, tolerate small typos and Arabic or Persian spellings of ی and ک, are ranked by relevance unless another sort is given, and return the matched fragments as highlights. Products of hidden categories are not listed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public - Products"
+                ],
+                "summary": "Search and filter products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keywords to search for",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by category name, including its subcategories (e.g., Meal, Drink)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by comma-separated tags (e.g., Pizza,Cheese)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum price filter, in minor units",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum price filter, in minor units",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only products rated at least this",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only products that can (true) or cannot (false) be ordered right now",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also count the products per category, tag, price range, rating and availability",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated sort fields: name (default), price, rating, newest, popularity, and relevance (default) for keyword searches; prefix a field with - to reverse it",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated, use sort; rating_desc sorts by rating",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page's pagination.next_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid page size, sort or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to search for products",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/products/bestsellers": {
+            "get": {
+                "description": "Retrieves a list of the most popular products for a tenant, based on sales volume.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public - Products"
+                ],
+                "summary": "Get best-selling products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_Product"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve best sellers",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/products/featured": {
+            "get": {
+                "description": "Retrieves the main promotional product for a tenant, often used for a large banner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public - Products"
+                ],
+                "summary": "Get the featured product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_Product"
+                        }
+                    },
+                    "404": {
+                        "description": "No featured product found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve featured product",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/products/recommended": {
+            "get": {
+                "description": "Retrieves a list of products marked as 'recommended' by the tenant admin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public - Products"
+                ],
+                "summary": "Get recommended products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_Product"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve recommended products",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/products/{productId}": {
+            "get": {
+                "description": "Retrieves detailed information for a single product, including its customization options.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public - Products"
+                ],
+                "summary": "Get product details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-models_Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve product details",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/register": {
+            "post": {
+                "description": "Creates a new user account for a specific tenant. If an X-Cart-Token header is sent, the items of that guest cart are moved into the new account's cart.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User Registration Info",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterPayload"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart to move into the new account",
+                        "name": "X-Cart-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "409": {
+                        "description": "User with this email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to create user",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/reset-password": {
+            "post": {
+                "description": "Sets a new password using the token from a password reset e-mail. All outstanding reset tokens of the user are invalidated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset password with a token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reset token and new password",
+                        "name": "reset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ResetPasswordPayload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password reset successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or invalid/expired token",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reset password",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/{tenantId}/tags": {
+            "get": {
+                "description": "Retrieves a list of all available tags for a specific tenant, used for building filter UIs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public - Products"
+                ],
+                "summary": "Get all filter tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "tenantId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-array_models_Tag"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve tags",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse-any"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APIResponse-any": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_Address": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Address"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_Category": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_FAQ": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FAQ"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_Notification": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Notification"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_OptionGroup": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OptionGroup"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_OptionSelectionProblem": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OptionSelectionProblem"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_Order": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Order"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_OrderSummaryView": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderSummaryView"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_PaymentMethod": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentMethod"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_Product": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_Promotion": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Promotion"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_PromotionRedemption": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PromotionRedemption"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_Refund": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Refund"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_Tag": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_Tenant": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tenant"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-array_models_User": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_Address": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Address"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_Availability": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Availability"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_Cart": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Cart"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_Category": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Category"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_CreateProductResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.CreateProductResponse"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_DashboardStats": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.DashboardStats"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_GuestCartResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.GuestCartResponse"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_LoginResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.LoginResponse"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_NotificationPreferences": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.NotificationPreferences"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_Option": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Option"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_OptionGroup": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.OptionGroup"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_Order": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Order"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_OrderDetails": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.OrderDetails"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_Product": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_ReorderResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.ReorderResult"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_Tag": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Tag"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_TenantConfig": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.TenantConfig"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.APIResponse-models_User": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "facets": {
+                    "$ref": "#/definitions/models.ProductFacets"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AddAddressPayload": {
+            "type": "object",
+            "required": [
+                "city",
+                "name",
+                "street"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "postal_code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "street": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "models.AddPaymentMethodPayload": {
+            "type": "object",
+            "required": [
+                "processor_token"
+            ],
+            "properties": {
+                "processor_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddToCartPayload": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "option_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "models.Address": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApplyPromoPayload": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Availability": {
+            "type": "object",
+            "properties": {
+                "next_open_at": {
+                    "type": "string"
+                },
+                "open": {
+                    "type": "boolean"
+                },
+                "prep_minutes": {
+                    "type": "integer"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimeSlot"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AvailabilityCount": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "unavailable": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CancelOrderPayload": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "models.Cart": {
+            "type": "object",
+            "properties": {
+                "charges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Charge"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "discount_total": {
+                    "type": "integer"
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CartDiscount"
+                    }
+                },
+                "fee_total": {
+                    "type": "integer"
+                },
+                "grand_total": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CartItem"
+                    }
+                },
+                "price_changed": {
+                    "type": "boolean"
+                },
+                "promo_code": {
+                    "type": "string"
+                },
+                "promo_error": {
+                    "type": "string"
+                },
+                "snapshot_subtotal": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "tax_total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CartDiscount": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CartItem": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "main_category": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CartItemOption"
+                    }
+                },
+                "price_changed": {
+                    "type": "boolean"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "snapshot_base_price": {
+                    "type": "integer"
+                },
+                "snapshot_unit_price": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total_price": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CartItemOption": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price_modifier": {
+                    "type": "integer"
+                },
+                "snapshot_price_modifier": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "available_from": {
+                    "type": "string"
+                },
+                "available_now": {
+                    "description": "AvailableNow and Children are only filled in for the public category tree.",
+                    "type": "boolean"
+                },
+                "available_until": {
+                    "type": "string"
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "display_order": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_visible": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CategoryPayload": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "available_from": {
+                    "type": "string"
+                },
+                "available_until": {
+                    "type": "string"
+                },
+                "display_order": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "is_visible": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ChangePasswordPayload": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "models.Charge": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "inclusive": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "$ref": "#/definitions/models.ChargeKind"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ChargeKind": {
+            "type": "string",
+            "enum": [
+                "tax",
+                "service_charge",
+                "fee",
+                "delivery_fee"
+            ],
+            "x-enum-varnames": [
+                "ChargeTax",
+                "ChargeServiceCharge",
+                "ChargeFee",
+                "ChargeDeliveryFee"
+            ]
+        },
+        "models.CheckoutPayload": {
+            "type": "object",
+            "properties": {
+                "accept_price_changes": {
+                    "type": "boolean"
+                },
+                "address_id": {
+                    "type": "integer"
+                },
+                "fulfilment_type": {
+                    "enum": [
+                        "delivery",
+                        "pickup",
+                        "dine_in"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FulfilmentType"
+                        }
+                    ]
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "scheduled_for": {
+                    "type": "string"
+                },
+                "table_number": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "models.Closure": {
+            "type": "object",
+            "required": [
+                "from"
+            ],
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ContactInfo": {
+            "type": "object",
+            "properties": {
+                "customerService": {
+                    "type": "string"
+                },
+                "facebook": {
+                    "type": "string"
+                },
+                "instagram": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                },
+                "whatsapp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateProductResponse": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateTenantPayload": {
+            "type": "object",
+            "required": [
+                "config",
+                "name"
+            ],
+            "properties": {
+                "config": {
+                    "$ref": "#/definitions/models.TenantConfig"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DashboardStats": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "orders_today": {
+                    "type": "integer"
+                },
+                "total_customers": {
+                    "type": "integer"
+                },
+                "total_refunded": {
+                    "type": "integer"
+                },
+                "total_revenue": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DeliveryZone": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "etaMinutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "fee": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minimumOrder": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "polygon": {
+                    "type": "array",
+                    "minItems": 3,
+                    "items": {
+                        "$ref": "#/definitions/models.GeoPoint"
+                    }
+                },
+                "radiusKm": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.FAQ": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "question": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Fee": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "models.ForgotPasswordPayload": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FulfilmentType": {
+            "type": "string",
+            "enum": [
+                "delivery",
+                "pickup",
+                "dine_in"
+            ],
+            "x-enum-varnames": [
+                "FulfilmentDelivery",
+                "FulfilmentPickup",
+                "FulfilmentDineIn"
+            ]
+        },
+        "models.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "type": "number"
+                },
+                "lng": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.GuestCartResponse": {
+            "type": "object",
+            "properties": {
+                "cart_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GuestCheckoutPayload": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "accept_price_changes": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "fulfilment_type": {
+                    "enum": [
+                        "pickup",
+                        "dine_in"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FulfilmentType"
+                        }
+                    ]
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "scheduled_for": {
+                    "type": "string"
+                },
+                "table_number": {
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "models.LeaveReviewPayload": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                }
+            }
+        },
+        "models.LoginPayload": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MarkReadPayload": {
+            "type": "object",
+            "required": [
+                "notification_ids"
+            ],
+            "properties": {
+                "notification_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.MergeTagPayload": {
+            "type": "object",
+            "required": [
+                "into_tag_id"
+            ],
+            "properties": {
+                "into_tag_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_read": {
+                    "type": "boolean"
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.RawJSONObject"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NotificationPreferences": {
+            "type": "object",
+            "properties": {
+                "cashback": {
+                    "type": "boolean"
+                },
+                "general_notifications": {
+                    "type": "boolean"
+                },
+                "payments": {
+                    "type": "boolean"
+                },
+                "sound": {
+                    "type": "boolean"
+                },
+                "special_offers": {
+                    "type": "boolean"
+                },
+                "vibrate": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.OpeningHours": {
+            "type": "object",
+            "required": [
+                "close",
+                "open"
+            ],
+            "properties": {
+                "close": {
+                    "type": "string"
+                },
+                "day": {
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 0
+                },
+                "open": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Option": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price_modifier": {
+                    "type": "integer"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "stock_quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OptionGroup": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_required": {
+                    "type": "boolean"
+                },
+                "max_selections": {
+                    "type": "integer"
+                },
+                "min_selections": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Option"
+                    }
+                },
+                "selection_type": {
+                    "$ref": "#/definitions/models.SelectionType"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OptionGroupPayload": {
+            "type": "object",
+            "required": [
+                "name",
+                "selection_type"
+            ],
+            "properties": {
+                "is_required": {
+                    "type": "boolean"
+                },
+                "max_selections": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "min_selections": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OptionPayload"
+                    }
+                },
+                "selection_type": {
+                    "enum": [
+                        "single",
+                        "multiple"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SelectionType"
+                        }
+                    ]
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OptionPayload": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "is_available": {
+                    "type": "boolean"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "price_modifier": {
+                    "type": "integer"
                 },
-                "error": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
+                "sort_order": {
+                    "type": "integer"
                 },
-                "success": {
-                    "type": "boolean"
+                "stock_quantity": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "models.APIResponse-array_models_Notification": {
+        "models.OptionSelectionProblem": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Notification"
-                    }
+                "cart_item_id": {
+                    "type": "integer"
                 },
-                "error": {
+                "group_id": {
+                    "type": "integer"
+                },
+                "group_name": {
                     "type": "string"
                 },
                 "message": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "option_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.APIResponse-array_models_Order": {
+        "models.Order": {
             "type": "object",
             "properties": {
-                "data": {
+                "address_id": {
+                    "type": "integer"
+                },
+                "charges": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Order"
+                        "$ref": "#/definitions/models.Charge"
                     }
                 },
-                "error": {
+                "created_at": {
                     "type": "string"
                 },
-                "message": {
+                "currency": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.APIResponse-array_models_OrderSummaryView": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.OrderSummaryView"
-                    }
+                "delivery_address": {
+                    "$ref": "#/definitions/models.Address"
                 },
-                "error": {
+                "delivery_zone": {
                     "type": "string"
                 },
-                "message": {
+                "discount_total": {
+                    "type": "integer"
+                },
+                "eta_minutes": {
+                    "type": "integer"
+                },
+                "fee_total": {
+                    "type": "integer"
+                },
+                "fulfilment_type": {
+                    "$ref": "#/definitions/models.FulfilmentType"
+                },
+                "guest_email": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.APIResponse-array_models_PaymentMethod": {
-            "type": "object",
-            "properties": {
-                "data": {
+                "guest_phone": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PaymentMethod"
+                        "$ref": "#/definitions/models.OrderItem"
                     }
                 },
-                "error": {
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "promo_code": {
                     "type": "string"
                 },
-                "message": {
+                "refunded_total": {
+                    "type": "integer"
+                },
+                "scheduled_for": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "table_number": {
+                    "type": "string"
+                },
+                "tax_total": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.APIResponse-array_models_Product": {
+        "models.OrderDetails": {
             "type": "object",
             "properties": {
-                "data": {
+                "address_id": {
+                    "type": "integer"
+                },
+                "cancellation_reason": {
+                    "type": "string"
+                },
+                "charges": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Product"
+                        "$ref": "#/definitions/models.Charge"
                     }
                 },
-                "error": {
+                "created_at": {
                     "type": "string"
                 },
-                "message": {
+                "currency": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.APIResponse-array_models_Tag": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Tag"
-                    }
+                "delivery_address": {
+                    "$ref": "#/definitions/models.Address"
                 },
-                "error": {
+                "delivery_zone": {
                     "type": "string"
                 },
-                "message": {
+                "discount_total": {
+                    "type": "integer"
+                },
+                "eta_minutes": {
+                    "type": "integer"
+                },
+                "fee_total": {
+                    "type": "integer"
+                },
+                "fulfilment_type": {
+                    "$ref": "#/definitions/models.FulfilmentType"
+                },
+                "guest_email": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.APIResponse-array_models_Tenant": {
-            "type": "object",
-            "properties": {
-                "data": {
+                "guest_phone": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Tenant"
+                        "$ref": "#/definitions/models.OrderItem"
                     }
                 },
-                "error": {
-                    "type": "string"
+                "payment_method": {
+                    "$ref": "#/definitions/models.PaymentMethod"
                 },
-                "message": {
-                    "type": "string"
+                "payment_method_id": {
+                    "type": "integer"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.APIResponse-array_models_User": {
-            "type": "object",
-            "properties": {
-                "data": {
+                "payments": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.User"
+                        "$ref": "#/definitions/models.PaymentTransaction"
                     }
                 },
-                "error": {
+                "promo_code": {
                     "type": "string"
                 },
-                "message": {
-                    "type": "string"
+                "refunded_total": {
+                    "type": "integer"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.APIResponse-models_Cart": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.Cart"
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Refund"
+                    }
                 },
-                "error": {
-                    "type": "string"
+                "review": {
+                    "$ref": "#/definitions/models.Review"
                 },
-                "message": {
+                "scheduled_for": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.APIResponse-models_CreateProductResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.CreateProductResponse"
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
                 },
-                "error": {
-                    "type": "string"
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderStatusChange"
+                    }
                 },
-                "message": {
+                "subtotal": {
+                    "type": "integer"
+                },
+                "table_number": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "tax_total": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.APIResponse-models_DashboardStats": {
+        "models.OrderItem": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.DashboardStats"
+                "base_price": {
+                    "type": "integer"
                 },
-                "error": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "message": {
+                "image_url": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "models.APIResponse-models_LoginResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.LoginResponse"
+                "line_total": {
+                    "type": "integer"
                 },
-                "error": {
+                "name": {
                     "type": "string"
                 },
-                "message": {
-                    "type": "string"
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderItemOption"
+                    }
                 },
-                "success": {
-                    "type": "boolean"
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
                 }
             }
         },
-        "models.APIResponse-models_NotificationPreferences": {
+        "models.OrderItemOption": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.NotificationPreferences"
+                "id": {
+                    "type": "integer"
                 },
-                "error": {
+                "name": {
                     "type": "string"
                 },
-                "message": {
-                    "type": "string"
+                "option_id": {
+                    "type": "integer"
                 },
-                "success": {
-                    "type": "boolean"
+                "price_modifier": {
+                    "type": "integer"
                 }
             }
         },
-        "models.APIResponse-models_Product": {
+        "models.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "Pending",
+                "Accepted",
+                "Preparing",
+                "ReadyForPickup",
+                "OutForDelivery",
+                "Completed",
+                "Cancelled",
+                "Refunded"
+            ],
+            "x-enum-varnames": [
+                "OrderStatusPending",
+                "OrderStatusAccepted",
+                "OrderStatusPreparing",
+                "OrderStatusReadyForPickup",
+                "OrderStatusOutForDelivery",
+                "OrderStatusCompleted",
+                "OrderStatusCancelled",
+                "OrderStatusRefunded"
+            ]
+        },
+        "models.OrderStatusChange": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.Product"
+                "changed_by": {
+                    "type": "integer"
                 },
-                "error": {
+                "created_at": {
                     "type": "string"
                 },
-                "message": {
+                "from_status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "note": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "to_status": {
+                    "$ref": "#/definitions/models.OrderStatus"
                 }
             }
         },
-        "models.APIResponse-models_TenantConfig": {
+        "models.OrderSummaryView": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.TenantConfig"
+                "created_at": {
+                    "type": "string"
                 },
-                "error": {
+                "currency": {
                     "type": "string"
                 },
-                "message": {
+                "id": {
+                    "type": "integer"
+                },
+                "item_count": {
+                    "type": "integer"
+                },
+                "primary_item_img": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "primary_item_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.OrderStatus"
+                },
+                "total_price": {
+                    "type": "integer"
                 }
             }
         },
-        "models.APIResponse-models_User": {
+        "models.Pagination": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/models.User"
+                "has_more": {
+                    "type": "boolean"
                 },
-                "error": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer"
                 },
-                "message": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "models.AddAddressPayload": {
+        "models.PaymentMethod": {
             "type": "object",
-            "required": [
-                "address",
-                "name"
-            ],
             "properties": {
-                "address": {
+                "card_brand": {
                     "type": "string"
                 },
-                "name": {
+                "expiry_month": {
+                    "type": "integer"
+                },
+                "expiry_year": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "is_expired": {
+                    "type": "boolean"
+                },
+                "last_four": {
                     "type": "string"
                 }
             }
         },
-        "models.AddPaymentMethodPayload": {
-            "type": "object",
-            "required": [
-                "processor_token"
+        "models.PaymentOperation": {
+            "type": "string",
+            "enum": [
+                "authorize",
+                "capture",
+                "void",
+                "refund"
+            ],
+            "x-enum-varnames": [
+                "PaymentAuthorize",
+                "PaymentCapture",
+                "PaymentVoid",
+                "PaymentRefund"
+            ]
+        },
+        "models.PaymentStatus": {
+            "type": "string",
+            "enum": [
+                "succeeded",
+                "failed"
             ],
+            "x-enum-varnames": [
+                "PaymentSucceeded",
+                "PaymentFailed"
+            ]
+        },
+        "models.PaymentTransaction": {
+            "type": "object",
             "properties": {
-                "processor_token": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "gateway_reference": {
                     "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "operation": {
+                    "$ref": "#/definitions/models.PaymentOperation"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "payment_method_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.PaymentStatus"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.AddToCartPayload": {
-            "type": "object",
-            "required": [
-                "product_id",
-                "quantity"
+        "models.Plan": {
+            "type": "string",
+            "enum": [
+                "BASE",
+                "PRO",
+                "VIP"
             ],
+            "x-enum-varnames": [
+                "PlanBase",
+                "PlanPro",
+                "PlanVip"
+            ]
+        },
+        "models.PriceRangeCount": {
+            "type": "object",
             "properties": {
-                "option_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "count": {
+                    "type": "integer"
                 },
-                "product_id": {
+                "max": {
                     "type": "integer"
                 },
-                "quantity": {
-                    "type": "integer",
-                    "minimum": 1
+                "min": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Address": {
+        "models.Product": {
             "type": "object",
             "properties": {
-                "address": {
+                "description": {
                     "type": "string"
                 },
+                "discount_price": {
+                    "type": "integer"
+                },
+                "highlights": {
+                    "description": "Highlights is only filled in for keyword searches.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchHighlight"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "is_featured": {
+                    "type": "boolean"
+                },
+                "is_recommended": {
+                    "type": "boolean"
+                },
+                "main_category": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
+                },
+                "option_groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OptionGroup"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "stock_quantity": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "models.CancelOrderPayload": {
+        "models.ProductFacets": {
             "type": "object",
             "properties": {
-                "reason": {
-                    "type": "string",
-                    "maxLength": 255
+                "availability": {
+                    "$ref": "#/definitions/models.AvailabilityCount"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetCount"
+                    }
+                },
+                "price_ranges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PriceRangeCount"
+                    }
+                },
+                "ratings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RatingCount"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetCount"
+                    }
                 }
             }
         },
-        "models.Cart": {
+        "models.ProductPayload": {
             "type": "object",
+            "required": [
+                "name",
+                "price"
+            ],
             "properties": {
-                "grand_total": {
-                    "type": "number"
+                "category_id": {
+                    "type": "integer"
                 },
-                "items": {
+                "description": {
+                    "type": "string"
+                },
+                "discount_price": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_featured": {
+                    "type": "boolean"
+                },
+                "is_recommended": {
+                    "type": "boolean"
+                },
+                "main_category": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.CartItem"
+                        "type": "string"
                     }
                 }
             }
         },
-        "models.CartItem": {
+        "models.Promotion": {
             "type": "object",
             "properties": {
-                "base_price": {
-                    "type": "number"
+                "amount": {
+                    "type": "integer"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "free_product_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_uses": {
+                    "type": "integer"
                 },
-                "id": {
+                "max_uses_per_user": {
                     "type": "integer"
                 },
-                "image_url": {
-                    "type": "string"
+                "min_basket": {
+                    "type": "integer"
                 },
-                "name": {
+                "starts_at": {
                     "type": "string"
                 },
-                "options": {
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.CartItemOption"
+                        "type": "string"
                     }
                 },
-                "product_id": {
-                    "type": "integer"
+                "type": {
+                    "$ref": "#/definitions/models.PromotionType"
                 },
-                "quantity": {
+                "usage_count": {
                     "type": "integer"
                 },
-                "total_price": {
-                    "type": "number"
-                }
-            }
-        },
-        "models.CartItemOption": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "price_modifier": {
+                "value": {
                     "type": "number"
                 }
             }
         },
-        "models.ChangePasswordPayload": {
+        "models.PromotionPayload": {
             "type": "object",
             "required": [
-                "current_password",
-                "new_password"
+                "code",
+                "type"
             ],
             "properties": {
-                "current_password": {
-                    "type": "string"
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "new_password": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
                     "type": "string",
-                    "minLength": 8
-                }
-            }
-        },
-        "models.ContactInfo": {
-            "type": "object",
-            "properties": {
-                "customerService": {
-                    "type": "string"
+                    "maxLength": 64
                 },
-                "facebook": {
+                "description": {
                     "type": "string"
                 },
-                "instagram": {
+                "ends_at": {
                     "type": "string"
                 },
-                "website": {
-                    "type": "string"
+                "free_product_id": {
+                    "type": "integer"
                 },
-                "whatsapp": {
+                "is_active": {
+                    "description": "IsActive defaults to true for a new promotion and keeps the stored value on update when omitted.",
+                    "type": "boolean"
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "max_uses_per_user": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "min_basket": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "starts_at": {
                     "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "enum": [
+                        "percentage",
+                        "fixed_amount",
+                        "free_item"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PromotionType"
+                        }
+                    ]
+                },
+                "value": {
+                    "type": "number",
+                    "minimum": 0
                 }
             }
         },
-        "models.CreateProductResponse": {
+        "models.PromotionRedemption": {
             "type": "object",
             "properties": {
-                "product_id": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discount_amount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "integer"
+                },
+                "promotion_id": {
+                    "type": "integer"
+                },
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "models.CreateTenantPayload": {
-            "type": "object",
-            "required": [
-                "config",
-                "name"
+        "models.PromotionType": {
+            "type": "string",
+            "enum": [
+                "percentage",
+                "fixed_amount",
+                "free_item"
             ],
-            "properties": {
-                "config": {
-                    "$ref": "#/definitions/models.TenantConfig"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
+            "x-enum-varnames": [
+                "PromotionPercentage",
+                "PromotionFixedAmount",
+                "PromotionFreeItem"
+            ]
         },
-        "models.DashboardStats": {
+        "models.RatingCount": {
             "type": "object",
             "properties": {
-                "orders_today": {
+                "count": {
                     "type": "integer"
                 },
-                "total_customers": {
+                "min_rating": {
                     "type": "integer"
-                },
-                "total_revenue": {
-                    "type": "number"
                 }
             }
         },
-        "models.FAQ": {
+        "models.RawJSONObject": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "models.Refund": {
             "type": "object",
             "properties": {
-                "answer": {
-                    "type": "string"
+                "amount": {
+                    "type": "integer"
                 },
-                "category": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "question": {
+                "order_id": {
+                    "type": "integer"
+                },
+                "order_item_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
                     "type": "string"
+                },
+                "refunded_by": {
+                    "type": "integer"
                 }
             }
         },
-        "models.LeaveReviewPayload": {
+        "models.RefundItemPayload": {
             "type": "object",
             "required": [
-                "rating"
+                "order_item_id",
+                "quantity"
             ],
             "properties": {
-                "comment": {
-                    "type": "string"
+                "order_item_id": {
+                    "type": "integer"
                 },
-                "rating": {
+                "quantity": {
                     "type": "integer",
-                    "maximum": 5,
                     "minimum": 1
                 }
             }
         },
-        "models.LoginPayload": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.MarkReadPayload": {
+        "models.RefundPayload": {
             "type": "object",
             "required": [
-                "notification_ids"
+                "reason"
             ],
             "properties": {
-                "notification_ids": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/models.RefundItemPayload"
                     }
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "models.Notification": {
+        "models.RegisterPayload": {
             "type": "object",
+            "required": [
+                "email",
+                "full_name",
+                "password"
+            ],
             "properties": {
-                "content": {
+                "date_of_birth": {
                     "type": "string"
                 },
-                "created_at": {
+                "email": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "is_read": {
-                    "type": "boolean"
-                },
-                "metadata": {
-                    "$ref": "#/definitions/models.RawJSONObject"
-                },
-                "title": {
+                "full_name": {
                     "type": "string"
                 },
-                "type": {
+                "mobile_number": {
                     "type": "string"
-                }
-            }
-        },
-        "models.NotificationPreferences": {
-            "type": "object",
-            "properties": {
-                "cashback": {
-                    "type": "boolean"
                 },
-                "general_notifications": {
-                    "type": "boolean"
-                },
-                "payments": {
-                    "type": "boolean"
-                },
-                "sound": {
-                    "type": "boolean"
-                },
-                "special_offers": {
-                    "type": "boolean"
-                },
-                "vibrate": {
-                    "type": "boolean"
+                "password": {
+                    "type": "string",
+                    "minLength": 8
                 }
             }
         },
-        "models.Option": {
+        "models.ReorderPayload": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "price_modifier": {
-                    "type": "number"
+                "accept_price_changes": {
+                    "type": "boolean"
                 }
             }
         },
-        "models.OptionGroup": {
+        "models.ReorderResult": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+                "cart": {
+                    "$ref": "#/definitions/models.Cart"
                 },
-                "options": {
+                "skipped": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Option"
+                        "$ref": "#/definitions/models.SkippedReorderLine"
                     }
-                },
-                "selection_type": {
-                    "type": "string"
                 }
             }
         },
-        "models.Order": {
+        "models.ReorderSkipReason": {
+            "type": "string",
+            "enum": [
+                "product_deleted",
+                "option_deleted",
+                "options_changed",
+                "unavailable",
+                "price_changed"
+            ],
+            "x-enum-varnames": [
+                "ReorderProductDeleted",
+                "ReorderOptionDeleted",
+                "ReorderOptionsChanged",
+                "ReorderUnavailable",
+                "ReorderPriceChanged"
+            ]
+        },
+        "models.ResetPasswordPayload": {
             "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer",
-                    "format": "int64"
-                },
-                "status": {
-                    "type": "string"
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
                 },
-                "tenantID": {
+                "token": {
                     "type": "string"
-                },
-                "totalPrice": {
-                    "type": "number",
-                    "format": "float64"
-                },
-                "userID": {
-                    "type": "integer",
-                    "format": "int64"
                 }
             }
         },
-        "models.OrderSummaryView": {
+        "models.Review": {
             "type": "object",
             "properties": {
+                "comment": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "item_count": {
+                "order_id": {
                     "type": "integer"
                 },
-                "primary_item_img": {
-                    "type": "string"
-                },
-                "primary_item_name": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
+                "rating": {
+                    "type": "integer"
                 },
-                "total_price": {
-                    "type": "number"
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.PaymentMethod": {
+        "models.RoundingMode": {
+            "type": "string",
+            "enum": [
+                "half_up",
+                "half_even",
+                "down",
+                "up",
+                "half_up"
+            ],
+            "x-enum-varnames": [
+                "RoundHalfUp",
+                "RoundHalfEven",
+                "RoundDown",
+                "RoundUp",
+                "DefaultRounding"
+            ]
+        },
+        "models.SearchHighlight": {
             "type": "object",
             "properties": {
-                "card_brand": {
+                "field": {
                     "type": "string"
                 },
-                "expiry_month": {
-                    "type": "integer"
-                },
-                "expiry_year": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "last_four": {
+                "fragment": {
                     "type": "string"
                 }
             }
         },
-        "models.Plan": {
+        "models.SelectionType": {
             "type": "string",
             "enum": [
-                "BASE",
-                "PRO",
-                "VIP"
+                "single",
+                "multiple"
             ],
             "x-enum-varnames": [
-                "PlanBase",
-                "PlanPro",
-                "PlanVip"
+                "SelectionSingle",
+                "SelectionMultiple"
             ]
         },
-        "models.Product": {
+        "models.ServiceCharge": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "discount_price": {
-                    "type": "number"
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
                 },
-                "id": {
+                "rate": {
+                    "type": "number",
+                    "maximum": 100
+                }
+            }
+        },
+        "models.SkippedReorderLine": {
+            "type": "object",
+            "properties": {
+                "current_price": {
                     "type": "integer"
                 },
-                "image_url": {
-                    "type": "string"
-                },
-                "is_featured": {
-                    "type": "boolean"
-                },
-                "is_recommended": {
-                    "type": "boolean"
-                },
-                "main_category": {
+                "message": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "option_groups": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.OptionGroup"
-                    }
+                "order_item_id": {
+                    "type": "integer"
                 },
-                "price": {
-                    "type": "number"
+                "ordered_price": {
+                    "type": "integer"
                 },
-                "rating": {
-                    "type": "number"
+                "quantity": {
+                    "type": "integer"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "reason": {
+                    "$ref": "#/definitions/models.ReorderSkipReason"
                 }
             }
         },
-        "models.ProductPayload": {
+        "models.StockPayload": {
             "type": "object",
             "required": [
-                "main_category",
-                "name",
-                "price"
+                "is_available"
             ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "discount_price": {
-                    "type": "number"
-                },
-                "image_url": {
-                    "type": "string"
-                },
-                "is_featured": {
-                    "type": "boolean"
-                },
-                "is_recommended": {
+                "is_available": {
                     "type": "boolean"
                 },
-                "main_category": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
+                "low_stock_threshold": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "price": {
-                    "type": "number"
+                "stock_quantity": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "models.RawJSONObject": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "models.RegisterPayload": {
+        "models.SuperAdminLoginPayload": {
             "type": "object",
             "required": [
                 "email",
-                "full_name",
                 "password"
             ],
             "properties": {
-                "date_of_birth": {
-                    "type": "string"
-                },
                 "email": {
                     "type": "string"
                 },
-                "full_name": {
+                "password": {
                     "type": "string"
+                }
+            }
+        },
+        "models.Tag": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
                 },
-                "mobile_number": {
+                "id": {
+                    "type": "integer"
+                },
+                "main_category": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "models.SuperAdminLoginPayload": {
+        "models.TagPayload": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "name"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "category_id": {
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string"
+                "main_category": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 150
                 }
             }
         },
-        "models.Tag": {
+        "models.TaxRate": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "id": {
-                    "type": "integer"
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "main_category": {
-                    "type": "string"
+                "inclusive": {
+                    "type": "boolean"
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "rate": {
+                    "type": "number",
+                    "maximum": 100
                 }
             }
         },
@@ -3181,15 +8202,44 @@ const docTemplate = `{
         "models.TenantConfig": {
             "type": "object",
             "properties": {
+                "closures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Closure"
+                    }
+                },
                 "contactInfo": {
                     "$ref": "#/definitions/models.ContactInfo"
                 },
+                "currency": {
+                    "description": "Currency is the ISO 4217 code all prices of the tenant are in; Rounding decides how percentages and shares of\namounts are rounded to whole minor units. They default to USD and half_up.",
+                    "type": "string"
+                },
                 "defaultTheme": {
                     "$ref": "#/definitions/models.Theme"
                 },
+                "deliveryZones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeliveryZone"
+                    }
+                },
                 "features": {
                     "$ref": "#/definitions/models.RawJSONObject"
                 },
+                "fees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Fee"
+                    }
+                },
+                "fulfilmentTypes": {
+                    "description": "FulfilmentTypes are the ways orders can be fulfilled; without any, delivery and pickup are offered. Store is\nwhere radius delivery zones are measured from. Without delivery zones the tenant delivers to any address for free.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FulfilmentType"
+                    }
+                },
                 "logo": {
                     "type": "string"
                 },
@@ -3199,11 +8249,74 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "openingHours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OpeningHours"
+                    }
+                },
+                "passwordResetUrl": {
+                    "description": "PasswordResetURL is the page of the tenant's storefront that password reset links open. Without it the links\npoint to PASSWORD_RESET_URL, which suits tenants that share one storefront.",
+                    "type": "string"
+                },
                 "plan": {
                     "$ref": "#/definitions/models.Plan"
                 },
+                "prepMinutes": {
+                    "description": "PrepMinutes is the lead time before a scheduled order can be ready. Scheduled orders pick a slot of SlotMinutes\n(15 by default) up to ScheduleDays ahead (7 by default); SlotCapacity limits the orders per slot, 0 means no limit.",
+                    "type": "integer",
+                    "maximum": 1440,
+                    "minimum": 0
+                },
+                "rounding": {
+                    "enum": [
+                        "half_up",
+                        "half_even",
+                        "down",
+                        "up"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RoundingMode"
+                        }
+                    ]
+                },
+                "scheduleDays": {
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 0
+                },
+                "serviceCharges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ServiceCharge"
+                    }
+                },
+                "slotCapacity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "slotMinutes": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 5
+                },
+                "store": {
+                    "$ref": "#/definitions/models.GeoPoint"
+                },
+                "taxes": {
+                    "description": "Taxes, ServiceCharges and Fees are applied to every cart and order after discounts.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaxRate"
+                    }
+                },
                 "themeColors": {
                     "$ref": "#/definitions/models.ThemeColors"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA time zone the opening hours and closures are in, UTC by default. Without opening hours the\nstore is open around the clock, except on closures.",
+                    "type": "string"
                 }
             }
         },
@@ -3235,12 +8348,32 @@ const docTemplate = `{
                 }
             }
         },
+        "models.TimeSlot": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UpdateCartItemPayload": {
             "type": "object",
             "required": [
                 "quantity"
             ],
             "properties": {
+                "option_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "quantity": {
                     "type": "integer",
                     "minimum": 1
@@ -3253,8 +8386,12 @@ const docTemplate = `{
                 "status"
             ],
             "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                },
                 "status": {
-                    "type": "string"
+                    "$ref": "#/definitions/models.OrderStatus"
                 }
             }
         },
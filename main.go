@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	db "github.com/AryaTabani/Dorivo/DB"
 	"github.com/AryaTabani/Dorivo/controllers"
 	_ "github.com/AryaTabani/Dorivo/docs"
 	"github.com/AryaTabani/Dorivo/middleware"
+	"github.com/AryaTabani/Dorivo/services"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	swaggerFiles "github.com/swaggo/files"
@@ -40,18 +42,34 @@ func main() {
 	}
 	db.InitDB()
 	db.InitRedis()
+	if err := services.InitMailer(); err != nil {
+		log.Fatalf("Mail is not configured: %v", err)
+	}
+	if err := services.InitPaymentGateway(); err != nil {
+		log.Fatalf("Payment gateway is not configured: %v", err)
+	}
+	services.StartBackgroundJobs(context.Background())
 	router := gin.Default()
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	router.GET("/tenant/:tenantId", controllers.GetTenantConfigHandler())
 	router.POST("/:tenantId/register", controllers.RegisterHandler())
 	router.POST("/:tenantId/login", controllers.LoginHandler())
+	router.POST("/:tenantId/forgot-password", controllers.ForgotPasswordHandler())
+	router.POST("/:tenantId/reset-password", controllers.ResetPasswordHandler())
 	router.GET("/:tenantId/faqs", controllers.GetFAQsHandler())
+	router.GET("/:tenantId/availability", controllers.GetAvailabilityHandler())
 	router.GET("/:tenantId/products", controllers.SearchProductsHandler())
 	router.GET("/:tenantId/tags", controllers.GetTagsHandler())
+	router.GET("/:tenantId/categories", controllers.GetCategoryTreeHandler())
 	router.GET("/:tenantId/products/:productId", controllers.GetProductDetailsHandler())
 	router.GET("/:tenantId/products/bestsellers", controllers.GetBestSellersHandler())
 	router.GET("/:tenantId/products/featured", controllers.GetFeaturedProductHandler())
 	router.GET("/:tenantId/products/recommended", controllers.GetRecommendedProductsHandler())
+	router.GET("/:tenantId/guest/cart", controllers.GetGuestCartHandler())
+	router.POST("/:tenantId/guest/cart/items", controllers.AddToGuestCartHandler())
+	router.PUT("/:tenantId/guest/cart/items/:itemId", controllers.UpdateGuestCartItemHandler())
+	router.DELETE("/:tenantId/guest/cart/items/:itemId", controllers.RemoveGuestCartItemHandler())
+	router.POST("/:tenantId/guest/checkout", controllers.GuestCheckoutHandler())
 	router.POST("/superadmin/login", controllers.SuperAdminLoginHandler())
 
 	userAuthGroup := router.Group("/")
@@ -62,14 +80,18 @@ func main() {
 
 		userAuthGroup.GET("/addresses", controllers.GetAddressesHandler())
 		userAuthGroup.POST("/addresses", controllers.AddAddressHandler())
+		userAuthGroup.PUT("/addresses/:addressId", controllers.UpdateAddressHandler())
 		userAuthGroup.DELETE("/addresses/:addressId", controllers.DeleteAddressHandler())
 
 		userAuthGroup.GET("/payment-methods", controllers.GetPaymentMethodsHandler())
 		userAuthGroup.POST("/payment-methods", controllers.AddPaymentMethodHandler())
 		userAuthGroup.DELETE("/payment-methods/:methodId", controllers.DeletePaymentMethodHandler())
+		userAuthGroup.PUT("/payment-methods/:methodId/default", controllers.SetDefaultPaymentMethodHandler())
 
 		userAuthGroup.GET("/orders", controllers.GetMyOrdersHandler())
+		userAuthGroup.GET("/orders/:orderId", controllers.GetOrderDetailsHandler())
 		userAuthGroup.POST("/orders/:orderId/cancel", controllers.CancelOrderHandler())
+		userAuthGroup.POST("/orders/:orderId/reorder", controllers.ReorderHandler())
 		userAuthGroup.POST("/orders/:orderId/review", controllers.LeaveReviewHandler())
 
 		userAuthGroup.GET("/profile/notification-settings", controllers.GetNotificationsSettingHandler())
@@ -84,6 +106,9 @@ func main() {
 		userAuthGroup.POST("/cart/items", controllers.AddToCartHandler())
 		userAuthGroup.PUT("/cart/items/:itemId", controllers.UpdateCartItemHandler())
 		userAuthGroup.DELETE("/cart/items/:itemId", controllers.RemoveCartItemHandler())
+		userAuthGroup.POST("/cart/promo", controllers.ApplyPromoCodeHandler())
+		userAuthGroup.DELETE("/cart/promo", controllers.RemovePromoCodeHandler())
+		userAuthGroup.POST("/checkout", controllers.CheckoutHandler())
 
 		userAuthGroup.GET("/favorites", controllers.GetFavoritesHandler())
 		userAuthGroup.POST("/products/:productId/favorite", controllers.AddToFavoritesHandler())
@@ -96,10 +121,37 @@ func main() {
 		adminGroup.POST("/products", controllers.CreateProductHandler())
 		adminGroup.PUT("/products/:productId", controllers.UpdateProductHandler())
 		adminGroup.DELETE("/products/:productId", controllers.DeleteProductHandler())
+		adminGroup.PUT("/products/:productId/stock", controllers.UpdateProductStockHandler())
+		adminGroup.PUT("/options/:optionId/stock", controllers.UpdateOptionStockHandler())
+		adminGroup.GET("/products/:productId/option-groups", controllers.GetOptionGroupsHandler())
+		adminGroup.POST("/products/:productId/option-groups", controllers.CreateOptionGroupHandler())
+		adminGroup.PUT("/products/:productId/option-groups/:groupId", controllers.UpdateOptionGroupHandler())
+		adminGroup.DELETE("/products/:productId/option-groups/:groupId", controllers.DeleteOptionGroupHandler())
+		adminGroup.POST("/products/:productId/option-groups/:groupId/options", controllers.CreateOptionHandler())
+		adminGroup.PUT("/products/:productId/option-groups/:groupId/options/:optionId", controllers.UpdateOptionHandler())
+		adminGroup.DELETE("/products/:productId/option-groups/:groupId/options/:optionId", controllers.DeleteOptionHandler())
+		adminGroup.GET("/tags", controllers.GetAdminTagsHandler())
+		adminGroup.POST("/tags", controllers.CreateTagHandler())
+		adminGroup.PUT("/tags/:tagId", controllers.UpdateTagHandler())
+		adminGroup.DELETE("/tags/:tagId", controllers.DeleteTagHandler())
+		adminGroup.POST("/tags/:tagId/merge", controllers.MergeTagHandler())
+		adminGroup.GET("/categories", controllers.GetCategoriesHandler())
+		adminGroup.POST("/categories", controllers.CreateCategoryHandler())
+		adminGroup.PUT("/categories/:categoryId", controllers.UpdateCategoryHandler())
+		adminGroup.DELETE("/categories/:categoryId", controllers.DeleteCategoryHandler())
 		adminGroup.PUT("/config", controllers.UpdateTenantConfigHandler())
 
 		adminGroup.GET("/orders", controllers.GetTenantOrdersHandler())
+		adminGroup.GET("/orders/:orderId", controllers.AdminGetOrderDetailsHandler())
 		adminGroup.PUT("/orders/:orderId/status", controllers.UpdateOrderStatusHandler())
+		adminGroup.GET("/orders/:orderId/refunds", controllers.GetOrderRefundsHandler())
+		adminGroup.POST("/orders/:orderId/refunds", controllers.RefundOrderHandler())
+
+		adminGroup.GET("/promotions", controllers.GetPromotionsHandler())
+		adminGroup.POST("/promotions", controllers.CreatePromotionHandler())
+		adminGroup.PUT("/promotions/:promotionId", controllers.UpdatePromotionHandler())
+		adminGroup.DELETE("/promotions/:promotionId", controllers.DeletePromotionHandler())
+		adminGroup.GET("/promotions/:promotionId/redemptions", controllers.GetPromotionRedemptionsHandler())
 
 		adminGroup.GET("/customers", controllers.GetTenantCustomersHandler())
 		adminGroup.GET("/dashboard/stats", controllers.GetDashboardStatsHandler())
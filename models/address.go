@@ -1,13 +1,32 @@
 package models
 
+// Address is a saved delivery address. Orders keep a copy of the address they were placed with, so editing or deleting
+// it later does not change past orders.
 type Address struct {
-	ID      int64  `json:"id"`
-	UserID  int64  `json:"-"`
-	Name    string `json:"name"`
-	Address string `json:"address"`
+	ID         int64    `json:"id"`
+	UserID     int64    `json:"-"`
+	Name       string   `json:"name"`
+	Street     string   `json:"street"`
+	Unit       string   `json:"unit,omitempty"`
+	City       string   `json:"city"`
+	PostalCode string   `json:"postal_code,omitempty"`
+	Notes      string   `json:"notes,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+	IsDefault  bool     `json:"is_default"`
 }
 
+// AddAddressPayload creates or replaces an address. Coordinates are optional but must come together; they are needed
+// for delivery when the tenant has delivery zones. Marking an address as default takes the mark from any other one;
+// leaving is_default out of an update keeps the address's current mark.
 type AddAddressPayload struct {
-	Name    string `json:"name" binding:"required"`
-	Address string `json:"address" binding:"required"`
+	Name       string   `json:"name" binding:"required,max=100"`
+	Street     string   `json:"street" binding:"required,max=255"`
+	Unit       string   `json:"unit" binding:"max=64"`
+	City       string   `json:"city" binding:"required,max=100"`
+	PostalCode string   `json:"postal_code" binding:"max=20"`
+	Notes      string   `json:"notes" binding:"max=500"`
+	Latitude   *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,latitude"`
+	Longitude  *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,longitude"`
+	IsDefault  *bool    `json:"is_default"`
 }
@@ -1,15 +1,34 @@
 package models
 
+// ProductPayload creates or updates a product. The category is given by category_id or by the name of an existing
+// category in main_category. Tags are given by name and created when the store has no such tag yet; leaving tags out
+// of an update keeps the product's tags, while an empty list removes them.
 type ProductPayload struct {
 	Name          string   `json:"name" binding:"required"`
 	Description   string   `json:"description"`
-	Price         float64  `json:"price" binding:"required"`
+	Price         Money    `json:"price" binding:"required"`
 	ImageURL      string   `json:"image_url"`
-	MainCategory  string   `json:"main_category" binding:"required"`
-	DiscountPrice *float64 `json:"discount_price"`
+	CategoryID    *int64   `json:"category_id"`
+	MainCategory  string   `json:"main_category"`
+	DiscountPrice *Money   `json:"discount_price"`
 	IsFeatured    bool     `json:"is_featured"`
 	IsRecommended bool     `json:"is_recommended"`
+	Tags          []string `json:"tags"`
+}
+
+// TagPayload creates or renames a tag. Renaming rewrites the tag on products and promotions alike. Like a product, a
+// tag belongs to the category given by category_id or by name in main_category.
+type TagPayload struct {
+	Name         string `json:"name" binding:"required,max=150"`
+	CategoryID   *int64 `json:"category_id"`
+	MainCategory string `json:"main_category" binding:"max=255"`
+}
+
+// MergeTagPayload moves every product and promotion of a tag over to another tag and deletes it.
+type MergeTagPayload struct {
+	IntoTagID int64 `json:"into_tag_id" binding:"required"`
 }
 type UpdateOrderStatusPayload struct {
-	Status string `json:"status" binding:"required"`
+	Status OrderStatus `json:"status" binding:"required"`
+	Note   string      `json:"note" binding:"max=255"`
 }
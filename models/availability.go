@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Availability tells customers whether a store takes orders right now and which slots they can schedule an order for.
+type Availability struct {
+	Open        bool       `json:"open"`
+	Timezone    string     `json:"timezone"`
+	NextOpenAt  *time.Time `json:"next_open_at,omitempty"`
+	PrepMinutes int        `json:"prep_minutes"`
+	Slots       []TimeSlot `json:"slots"`
+}
+
+// TimeSlot is a slot a scheduled order can be placed for. Remaining is left out when slots have no capacity limit;
+// orders made as soon as possible take up a place in the slot they are ready in as well.
+type TimeSlot struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Remaining *int      `json:"remaining,omitempty"`
+}
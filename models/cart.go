@@ -1,32 +1,99 @@
 package models
 
+import "time"
+
 type AddToCartPayload struct {
 	ProductID int64   `json:"product_id" binding:"required"`
 	Quantity  int     `json:"quantity" binding:"required,min=1"`
 	OptionIDs []int64 `json:"option_ids"`
 }
 
+// UpdateCartItemPayload changes a cart line. When option_ids is left out the current options are kept.
 type UpdateCartItemPayload struct {
-	Quantity int `json:"quantity" binding:"required,min=1"`
+	Quantity  int      `json:"quantity" binding:"required,min=1"`
+	OptionIDs *[]int64 `json:"option_ids"`
+}
+
+// CartLine is a cart item reduced to what identifies it: its product and the sorted set of chosen options.
+type CartLine struct {
+	ID        int64
+	ProductID int64
+	Quantity  int
+	OptionIDs []int64
 }
 
+// CartItemOption is a chosen option of a cart line. The snapshot modifier is the one in effect when it was chosen.
 type CartItemOption struct {
-	Name          string  `json:"name"`
-	PriceModifier float64 `json:"price_modifier"`
+	ID                    int64  `json:"id"`
+	Name                  string `json:"name"`
+	PriceModifier         Money  `json:"price_modifier"`
+	SnapshotPriceModifier Money  `json:"snapshot_price_modifier"`
 }
 
+// CartItem is a cart line priced at the current prices. The snapshot prices are the ones in effect when the line was
+// added; price_changed tells whether the unit price moved since.
 type CartItem struct {
-	ID         int64            `json:"id"`
-	ProductID  int64            `json:"product_id"`
-	Name       string           `json:"name"`
-	ImageURL   string           `json:"image_url"`
-	Quantity   int              `json:"quantity"`
-	BasePrice  float64          `json:"base_price"`
-	TotalPrice float64          `json:"total_price"`
-	Options    []CartItemOption `json:"options"`
+	ID                int64            `json:"id"`
+	ProductID         int64            `json:"product_id"`
+	Name              string           `json:"name"`
+	ImageURL          string           `json:"image_url"`
+	MainCategory      string           `json:"main_category"`
+	CategoryID        *int64           `json:"category_id,omitempty"`
+	Tags              []string         `json:"tags,omitempty"`
+	Quantity          int              `json:"quantity"`
+	BasePrice         Money            `json:"base_price"`
+	SnapshotBasePrice Money            `json:"snapshot_base_price"`
+	UnitPrice         Money            `json:"unit_price"`
+	SnapshotUnitPrice Money            `json:"snapshot_unit_price"`
+	PriceChanged      bool             `json:"price_changed"`
+	TotalPrice        Money            `json:"total_price"`
+	Options           []CartItemOption `json:"options"`
 }
 
+// CartPriceChange describes a cart line whose unit price differs from the one it was added at.
+type CartPriceChange struct {
+	CartItemID        int64  `json:"cart_item_id"`
+	Name              string `json:"name"`
+	SnapshotUnitPrice Money  `json:"snapshot_unit_price"`
+	UnitPrice         Money  `json:"unit_price"`
+}
+
+type CartDiscount struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Amount      Money  `json:"amount"`
+}
+
+// Cart is the priced content of a cart. tax_total also counts taxes that are already included in the prices, while
+// grand_total only adds the exclusive taxes, service charges and fees to the discounted subtotal.
 type Cart struct {
-	Items      []CartItem `json:"items"`
-	GrandTotal float64    `json:"grand_total"`
+	Items            []CartItem     `json:"items"`
+	Currency         string         `json:"currency"`
+	Subtotal         Money          `json:"subtotal"`
+	SnapshotSubtotal Money          `json:"snapshot_subtotal"`
+	PriceChanged     bool           `json:"price_changed"`
+	PromoCode        string         `json:"promo_code,omitempty"`
+	PromoError       string         `json:"promo_error,omitempty"`
+	Discounts        []CartDiscount `json:"discounts"`
+	DiscountTotal    Money          `json:"discount_total"`
+	Charges          []Charge       `json:"charges"`
+	TaxTotal         Money          `json:"tax_total"`
+	FeeTotal         Money          `json:"fee_total"`
+	GrandTotal       Money          `json:"grand_total"`
+}
+
+// GuestCartResponse returns the token of the guest cart an item was added to.
+type GuestCartResponse struct {
+	CartToken string `json:"cart_token"`
+}
+
+// GuestCheckoutPayload holds the contact details of a visitor checking out without an account. Guests have no saved
+// addresses, so their orders are picked up unless they eat in.
+type GuestCheckoutPayload struct {
+	Email              string         `json:"email" binding:"required,email,max=150"`
+	Phone              string         `json:"phone" binding:"max=50"`
+	FulfilmentType     FulfilmentType `json:"fulfilment_type" binding:"omitempty,oneof=pickup dine_in"`
+	TableNumber        string         `json:"table_number" binding:"max=32"`
+	ScheduledFor       *time.Time     `json:"scheduled_for"`
+	AcceptPriceChanges bool           `json:"accept_price_changes"`
 }
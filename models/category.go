@@ -0,0 +1,31 @@
+package models
+
+// Category groups products on a menu. Categories nest under a parent and are listed by DisplayOrder, then name.
+// A category with AvailableFrom and AvailableUntil is only served between those times of day in the tenant's time
+// zone, such as a breakfast menu; a window that ends at or before its start runs past midnight. Hiding a category,
+// or limiting its hours, applies to its subcategories too.
+type Category struct {
+	ID             int64   `json:"id"`
+	ParentID       *int64  `json:"parent_id,omitempty"`
+	Name           string  `json:"name"`
+	ImageURL       string  `json:"image_url"`
+	DisplayOrder   int     `json:"display_order"`
+	IsVisible      bool    `json:"is_visible"`
+	AvailableFrom  *string `json:"available_from,omitempty"`
+	AvailableUntil *string `json:"available_until,omitempty"`
+	// AvailableNow and Children are only filled in for the public category tree.
+	AvailableNow bool       `json:"available_now"`
+	Children     []Category `json:"children,omitempty"`
+}
+
+// CategoryPayload creates or updates a category. Categories are visible unless is_visible is false. Renaming a
+// category renames it on its products and tags and in the promotions and taxes limited to it.
+type CategoryPayload struct {
+	Name           string  `json:"name" binding:"required,max=255"`
+	ParentID       *int64  `json:"parent_id"`
+	ImageURL       string  `json:"image_url" binding:"max=255"`
+	DisplayOrder   int     `json:"display_order"`
+	IsVisible      *bool   `json:"is_visible"`
+	AvailableFrom  *string `json:"available_from" binding:"omitempty,datetime=15:04"`
+	AvailableUntil *string `json:"available_until" binding:"omitempty,datetime=15:04"`
+}
@@ -1,7 +1,9 @@
 package models
 
 type DashboardStats struct {
-	TotalRevenue   float64 `json:"total_revenue"`
-	OrdersToday    int     `json:"orders_today"`
-	TotalCustomers int     `json:"total_customers"`
+	Currency       string `json:"currency"`
+	TotalRevenue   Money  `json:"total_revenue"`
+	TotalRefunded  Money  `json:"total_refunded"`
+	OrdersToday    int    `json:"orders_today"`
+	TotalCustomers int    `json:"total_customers"`
 }
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the minor unit of a currency, such as cents for USD or yen for JPY. Keeping amounts as whole
+// minor units makes cart and order arithmetic exact; only percentages and proportional shares need rounding, and they
+// use the tenant's rounding rule. In API responses money is always an integer number of minor units, next to the ISO
+// 4217 code of its currency.
+type Money int64
+
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "half_up"
+	RoundHalfEven RoundingMode = "half_even"
+	RoundDown     RoundingMode = "down"
+	RoundUp       RoundingMode = "up"
+)
+
+const (
+	DefaultCurrency              = "USD"
+	DefaultRounding RoundingMode = RoundHalfUp
+)
+
+// currencyExponents lists the ISO 4217 currencies whose minor unit is not a hundredth of the major unit.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyExponent returns how many decimal places the minor unit of a currency has.
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// CurrencyExponents returns the currencies whose minor unit is not a hundredth of the major unit, with the number of
+// decimal places of each. Every other currency has two.
+func CurrencyExponents() map[string]int {
+	return maps.Clone(currencyExponents)
+}
+
+// MinorUnits converts an amount in the major unit of a currency, such as 12.5 for 12.50 USD, to minor units, rounding
+// half up.
+func MinorUnits(amount float64, currency string) Money {
+	value, ok := percentRat(amount)
+	if !ok {
+		return 0
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(CurrencyExponent(currency))), nil)
+	return roundRat(value.Mul(value, new(big.Rat).SetInt(scale)), RoundHalfUp)
+}
+
+// Times returns the amount for the given quantity.
+func (m Money) Times(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// Percent returns percent per cent of m, rounded to a whole minor unit.
+func (m Money) Percent(percent float64, mode RoundingMode) Money {
+	rate, ok := percentRat(percent)
+	if !ok {
+		return 0
+	}
+	amount := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(m)), rate)
+	return roundRat(amount.Quo(amount, big.NewRat(100, 1)), mode)
+}
+
+// IncludedPercent returns the part of m that is a percent surcharge already contained in it, such as the VAT in a
+// VAT-inclusive price: m * percent / (100 + percent), rounded to a whole minor unit.
+func (m Money) IncludedPercent(percent float64, mode RoundingMode) Money {
+	rate, ok := percentRat(percent)
+	if !ok {
+		return 0
+	}
+	amount := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(m)), rate)
+	return roundRat(amount.Quo(amount, rate.Add(rate, big.NewRat(100, 1))), mode)
+}
+
+func percentRat(percent float64) (*big.Rat, bool) {
+	// Going through the shortest decimal form keeps 10.1 from turning into 10.0999... before rounding.
+	return new(big.Rat).SetString(strconv.FormatFloat(percent, 'f', -1, 64))
+}
+
+// Share returns the part/whole share of m, rounded to a whole minor unit. A zero whole yields m itself.
+func (m Money) Share(part, whole Money, mode RoundingMode) Money {
+	if whole == 0 {
+		return m
+	}
+	amount := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(int64(part))), big.NewInt(int64(whole)))
+	return roundRat(amount, mode)
+}
+
+// Format renders m in the major unit of the currency, e.g. "12.50 USD" or "1500 JPY".
+func (m Money) Format(currency string) string {
+	currency = strings.ToUpper(currency)
+	exponent := CurrencyExponent(currency)
+	sign, amount := "", int64(m)
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	if exponent == 0 {
+		return fmt.Sprintf("%s%d %s", sign, amount, currency)
+	}
+	scale := int64(1)
+	for i := 0; i < exponent; i++ {
+		scale *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, exponent, amount%scale, currency)
+}
+
+func roundRat(amount *big.Rat, mode RoundingMode) Money {
+	num, den := amount.Num(), amount.Denom()
+	quotient, remainder := new(big.Int).QuoRem(num, den, new(big.Int))
+	if remainder.Sign() == 0 {
+		return Money(quotient.Int64())
+	}
+
+	awayFromZero := false
+	switch mode {
+	case RoundDown:
+	case RoundUp:
+		awayFromZero = true
+	default:
+		// Compare twice the remainder with the denominator to find out which side of the half we are on.
+		twice := new(big.Int).Abs(remainder)
+		switch twice.Lsh(twice, 1).Cmp(den) {
+		case 1:
+			awayFromZero = true
+		case 0:
+			awayFromZero = mode != RoundHalfEven || quotient.Bit(0) == 1
+		}
+	}
+	if awayFromZero {
+		quotient.Add(quotient, big.NewInt(int64(num.Sign())))
+	}
+	return Money(quotient.Int64())
+}
@@ -0,0 +1,40 @@
+package models
+
+type SelectionType string
+
+const (
+	SelectionSingle   SelectionType = "single"
+	SelectionMultiple SelectionType = "multiple"
+)
+
+// OptionGroupPayload creates or replaces an option group. Options are only read when the group is created; afterwards
+// they are managed through their own endpoints. A missing max_selections means a multiple choice group has no upper limit.
+type OptionGroupPayload struct {
+	Name          string          `json:"name" binding:"required,max=255"`
+	SelectionType SelectionType   `json:"selection_type" binding:"required,oneof=single multiple"`
+	MinSelections int             `json:"min_selections" binding:"min=0"`
+	MaxSelections *int            `json:"max_selections" binding:"omitempty,min=1"`
+	IsRequired    bool            `json:"is_required"`
+	SortOrder     int             `json:"sort_order"`
+	Options       []OptionPayload `json:"options" binding:"dive"`
+}
+
+// OptionPayload creates or replaces a single option. Options are available unless is_available is false.
+type OptionPayload struct {
+	Name          string `json:"name" binding:"required,max=255"`
+	PriceModifier Money  `json:"price_modifier"`
+	IsDefault     bool   `json:"is_default"`
+	SortOrder     int    `json:"sort_order"`
+	IsAvailable   *bool  `json:"is_available"`
+	StockQuantity *int   `json:"stock_quantity" binding:"omitempty,min=0"`
+}
+
+// OptionSelectionProblem describes why the options chosen for a product do not satisfy one of its option groups.
+// CartItemID is only set when a cart line is re-checked at checkout.
+type OptionSelectionProblem struct {
+	CartItemID int64  `json:"cart_item_id,omitempty"`
+	GroupID    int64  `json:"group_id,omitempty"`
+	GroupName  string `json:"group_name,omitempty"`
+	OptionID   int64  `json:"option_id,omitempty"`
+	Message    string `json:"message"`
+}
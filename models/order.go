@@ -2,25 +2,171 @@ package models
 
 import "time"
 
+type OrderStatus string
+
+const (
+	OrderStatusPending        OrderStatus = "Pending"
+	OrderStatusAccepted       OrderStatus = "Accepted"
+	OrderStatusPreparing      OrderStatus = "Preparing"
+	OrderStatusReadyForPickup OrderStatus = "ReadyForPickup"
+	OrderStatusOutForDelivery OrderStatus = "OutForDelivery"
+	OrderStatusCompleted      OrderStatus = "Completed"
+	OrderStatusCancelled      OrderStatus = "Cancelled"
+	OrderStatusRefunded       OrderStatus = "Refunded"
+)
+
+type FulfilmentType string
+
+const (
+	FulfilmentDelivery FulfilmentType = "delivery"
+	FulfilmentPickup   FulfilmentType = "pickup"
+	FulfilmentDineIn   FulfilmentType = "dine_in"
+)
+
+// Order is a placed order. SlotStart is the time slot it takes up, which orders made as soon as possible have as well;
+// it is only used to keep slots within their capacity.
 type Order struct {
-	ID         int64
-	UserID     int64
-	TenantID   string
-	Status     string
-	TotalPrice float64
-	CreatedAt  time.Time
+	ID              int64          `json:"id"`
+	UserID          int64          `json:"user_id,omitempty"`
+	TenantID        string         `json:"-"`
+	GuestEmail      string         `json:"guest_email,omitempty"`
+	GuestPhone      string         `json:"guest_phone,omitempty"`
+	Status          OrderStatus    `json:"status"`
+	Currency        string         `json:"currency"`
+	TotalPrice      Money          `json:"total_price"`
+	DiscountTotal   Money          `json:"discount_total"`
+	TaxTotal        Money          `json:"tax_total"`
+	FeeTotal        Money          `json:"fee_total"`
+	RefundedTotal   Money          `json:"refunded_total"`
+	PromoCode       string         `json:"promo_code,omitempty"`
+	AddressID       *int64         `json:"address_id,omitempty"`
+	DeliveryAddress *Address       `json:"delivery_address,omitempty"`
+	PaymentMethodID *int64         `json:"payment_method_id,omitempty"`
+	FulfilmentType  FulfilmentType `json:"fulfilment_type"`
+	TableNumber     string         `json:"table_number,omitempty"`
+	DeliveryZone    string         `json:"delivery_zone,omitempty"`
+	EtaMinutes      *int           `json:"eta_minutes,omitempty"`
+	ScheduledFor    *time.Time     `json:"scheduled_for,omitempty"`
+	SlotStart       *time.Time     `json:"-"`
+	CreatedAt       time.Time      `json:"created_at"`
+	Items           []OrderItem    `json:"items,omitempty"`
+	Charges         []Charge       `json:"charges,omitempty"`
+}
+
+type ChargeKind string
+
+const (
+	ChargeTax           ChargeKind = "tax"
+	ChargeServiceCharge ChargeKind = "service_charge"
+	ChargeFee           ChargeKind = "fee"
+	ChargeDeliveryFee   ChargeKind = "delivery_fee"
+)
+
+// Charge is a tax, service charge or fee line of a cart or order. Inclusive taxes are already part of the item prices
+// and do not add to the total.
+type Charge struct {
+	Kind      ChargeKind `json:"kind"`
+	Name      string     `json:"name"`
+	Rate      *float64   `json:"rate,omitempty"`
+	Inclusive bool       `json:"inclusive,omitempty"`
+	Amount    Money      `json:"amount"`
+}
+
+type OrderItemOption struct {
+	ID            int64  `json:"id"`
+	OptionID      *int64 `json:"option_id,omitempty"`
+	Name          string `json:"name"`
+	PriceModifier Money  `json:"price_modifier"`
+}
+
+type OrderItem struct {
+	ID        int64             `json:"id"`
+	ProductID *int64            `json:"product_id,omitempty"`
+	Name      string            `json:"name"`
+	ImageURL  string            `json:"image_url"`
+	Quantity  int               `json:"quantity"`
+	BasePrice Money             `json:"base_price"`
+	Price     Money             `json:"price"`
+	LineTotal Money             `json:"line_total"`
+	Options   []OrderItemOption `json:"options"`
+}
+
+type OrderStatusChange struct {
+	FromStatus OrderStatus `json:"from_status,omitempty"`
+	ToStatus   OrderStatus `json:"to_status"`
+	ChangedBy  *int64      `json:"changed_by,omitempty"`
+	Note       string      `json:"note,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+type OrderDetails struct {
+	Order
+	Subtotal           Money                `json:"subtotal"`
+	PaymentMethod      *PaymentMethod       `json:"payment_method,omitempty"`
+	CancellationReason string               `json:"cancellation_reason,omitempty"`
+	Review             *Review              `json:"review,omitempty"`
+	StatusHistory      []OrderStatusChange  `json:"status_history"`
+	Payments           []PaymentTransaction `json:"payments"`
+	Refunds            []Refund             `json:"refunds"`
+}
+
+// CheckoutPayload holds the fulfilment and payment details of a checkout: delivery to AddressID, pickup, or dine-in at
+// TableNumber. Without a fulfilment type the order is delivered when an address is given and picked up otherwise.
+// scheduled_for picks a future slot; without it the order is made as soon as possible. accept_price_changes confirms
+// that the customer agrees to pay the current prices when they differ from the ones the items were added at.
+type CheckoutPayload struct {
+	FulfilmentType     FulfilmentType `json:"fulfilment_type" binding:"omitempty,oneof=delivery pickup dine_in"`
+	AddressID          *int64         `json:"address_id"`
+	TableNumber        string         `json:"table_number" binding:"max=32"`
+	PaymentMethodID    *int64         `json:"payment_method_id"`
+	ScheduledFor       *time.Time     `json:"scheduled_for"`
+	AcceptPriceChanges bool           `json:"accept_price_changes"`
 }
 
 type OrderSummaryView struct {
-	ID              int64     `json:"id"`
-	TotalPrice      float64   `json:"total_price"`
-	ItemCount       int       `json:"item_count"`
-	Status          string    `json:"status"`
-	CreatedAt       time.Time `json:"created_at"`
-	PrimaryItemName string    `json:"primary_item_name"`
-	PrimaryItemImg  string    `json:"primary_item_img"`
+	ID              int64       `json:"id"`
+	Currency        string      `json:"currency"`
+	TotalPrice      Money       `json:"total_price"`
+	ItemCount       int         `json:"item_count"`
+	Status          OrderStatus `json:"status"`
+	CreatedAt       time.Time   `json:"created_at"`
+	PrimaryItemName string      `json:"primary_item_name"`
+	PrimaryItemImg  string      `json:"primary_item_img"`
 }
 
 type CancelOrderPayload struct {
 	Reason string `json:"reason" binding:"max=255"`
 }
+
+// ReorderPayload repeats a past order. accept_price_changes also adds the lines whose price changed since, at today's
+// price; without it those lines are skipped.
+type ReorderPayload struct {
+	AcceptPriceChanges bool `json:"accept_price_changes"`
+}
+
+type ReorderSkipReason string
+
+const (
+	ReorderProductDeleted ReorderSkipReason = "product_deleted"
+	ReorderOptionDeleted  ReorderSkipReason = "option_deleted"
+	ReorderOptionsChanged ReorderSkipReason = "options_changed"
+	ReorderUnavailable    ReorderSkipReason = "unavailable"
+	ReorderPriceChanged   ReorderSkipReason = "price_changed"
+)
+
+// SkippedReorderLine is a line of a past order that could not be put back into the cart. CurrentPrice is only set
+// when the line was skipped because its unit price changed.
+type SkippedReorderLine struct {
+	OrderItemID  int64             `json:"order_item_id"`
+	Name         string            `json:"name"`
+	Quantity     int               `json:"quantity"`
+	Reason       ReorderSkipReason `json:"reason"`
+	Message      string            `json:"message"`
+	OrderedPrice Money             `json:"ordered_price"`
+	CurrentPrice *Money            `json:"current_price,omitempty"`
+}
+
+type ReorderResult struct {
+	Cart    *Cart                `json:"cart"`
+	Skipped []SkippedReorderLine `json:"skipped"`
+}
@@ -0,0 +1,43 @@
+package models
+
+// PageRequest is a page of a list as asked for by the client: at most Limit items after the opaque Cursor handed out
+// with the previous page, ordered by Sort. Sort is a comma-separated list of field names; each field sorts in its
+// natural direction (cheapest, best rated, newest, A to Z, most ordered first) and a leading "-" reverses it.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+	Sort   string
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// PageQuery is a validated page request. After holds the sort keys of the last item of the previous page, the item
+// ID last, and Offset the number of items already returned for lists that are paged in memory. A Limit of 0 asks for
+// every item in sort order.
+type PageQuery struct {
+	Limit     int
+	Sort      []SortField
+	SortKey   string
+	After     []interface{}
+	Offset    int
+	WithTotal bool
+}
+
+// Pagination describes the page a list response holds. NextCursor is only set when there are more items; Total is
+// only counted for the first page.
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      *int   `json:"total,omitempty"`
+}
+
+// Page is one page of a list. NextKey holds the sort keys of its last item until they are turned into a cursor.
+type Page[T any] struct {
+	Items      []T
+	Pagination Pagination
+	NextKey    []interface{}
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type PaymentMethod struct {
 	ID             int64  `json:"id"`
 	UserID         int64  `json:"-"`
@@ -8,8 +10,40 @@ type PaymentMethod struct {
 	LastFour       string `json:"last_four"`
 	ExpiryMonth    int    `json:"expiry_month"`
 	ExpiryYear     int    `json:"expiry_year"`
+	IsDefault      bool   `json:"is_default"`
+	IsExpired      bool   `json:"is_expired"`
 }
 
 type AddPaymentMethodPayload struct {
 	ProcessorToken string `json:"processor_token" binding:"required"`
 }
+
+type PaymentOperation string
+
+const (
+	PaymentAuthorize PaymentOperation = "authorize"
+	PaymentCapture   PaymentOperation = "capture"
+	PaymentVoid      PaymentOperation = "void"
+	PaymentRefund    PaymentOperation = "refund"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentSucceeded PaymentStatus = "succeeded"
+	PaymentFailed    PaymentStatus = "failed"
+)
+
+// PaymentTransaction is one entry of the payments ledger: a single call to the payment gateway and its outcome.
+type PaymentTransaction struct {
+	ID               int64            `json:"id"`
+	OrderID          *int64           `json:"order_id,omitempty"`
+	UserID           int64            `json:"user_id"`
+	PaymentMethodID  *int64           `json:"payment_method_id,omitempty"`
+	Operation        PaymentOperation `json:"operation"`
+	Status           PaymentStatus    `json:"status"`
+	Amount           Money            `json:"amount"`
+	GatewayReference string           `json:"gateway_reference,omitempty"`
+	FailureReason    string           `json:"failure_reason,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+}
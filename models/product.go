@@ -5,30 +5,109 @@ type Product struct {
 	TenantID      string        `json:"-"`
 	Name          string        `json:"name"`
 	Description   string        `json:"description"`
-	Price         float64       `json:"price"`
+	Price         Money         `json:"price"`
 	Rating        float64       `json:"rating"`
 	ImageURL      string        `json:"image_url"`
 	MainCategory  string        `json:"main_category"`
-	DiscountPrice *float64      `json:"discount_price,omitempty"`
+	DiscountPrice *Money        `json:"discount_price,omitempty"`
 	IsFeatured    bool          `json:"is_featured"`
 	IsRecommended bool          `json:"is_recommended"`
+	IsAvailable   bool          `json:"is_available"`
+	StockQuantity *int          `json:"stock_quantity,omitempty"`
 	Tags          []string      `json:"tags,omitempty"`
 	OptionGroups  []OptionGroup `json:"option_groups,omitempty"`
+	// Highlights is only filled in for keyword searches.
+	Highlights []SearchHighlight `json:"highlights,omitempty"`
+}
+
+// SearchHighlight is a piece of a product field that matched a keyword search. Fragment is HTML-escaped, with the
+// matched words wrapped in <em> tags.
+type SearchHighlight struct {
+	Field    string `json:"field"`
+	Fragment string `json:"fragment"`
 }
 
 type Tag struct {
 	ID           int64  `json:"id"`
 	Name         string `json:"name"`
+	CategoryID   *int64 `json:"category_id,omitempty"`
 	MainCategory string `json:"main_category"`
 }
 type Option struct {
-	ID            int64   `json:"id"`
-	Name          string  `json:"name"`
-	PriceModifier float64 `json:"price_modifier"`
+	ID            int64  `json:"id"`
+	OptionGroupID int64  `json:"-"`
+	Name          string `json:"name"`
+	PriceModifier Money  `json:"price_modifier"`
+	IsDefault     bool   `json:"is_default"`
+	SortOrder     int    `json:"sort_order"`
+	IsAvailable   bool   `json:"is_available"`
+	StockQuantity *int   `json:"stock_quantity,omitempty"`
 }
 type OptionGroup struct {
-	ID            int64    `json:"id"`
-	Name          string   `json:"name"`
-	SelectionType string   `json:"selection_type"`
-	Options       []Option `json:"options"`
+	ID            int64         `json:"id"`
+	ProductID     int64         `json:"-"`
+	Name          string        `json:"name"`
+	SelectionType SelectionType `json:"selection_type"`
+	MinSelections int           `json:"min_selections"`
+	MaxSelections *int          `json:"max_selections,omitempty"`
+	IsRequired    bool          `json:"is_required"`
+	SortOrder     int           `json:"sort_order"`
+	Options       []Option      `json:"options"`
+}
+
+// StockLevel is the inventory state of a product or option. A nil StockQuantity means stock is not tracked.
+type StockLevel struct {
+	Name              string
+	StockQuantity     *int
+	IsAvailable       bool
+	LowStockThreshold int
+}
+
+// StockReservation is stock taken by an order for either a product or one of its options.
+type StockReservation struct {
+	ProductID *int64
+	OptionID  *int64
+	Quantity  int
+}
+
+// StockPayload replaces the inventory settings of a product or option. Leaving stock_quantity out stops tracking stock.
+type StockPayload struct {
+	StockQuantity     *int  `json:"stock_quantity" binding:"omitempty,min=0"`
+	IsAvailable       *bool `json:"is_available" binding:"required"`
+	LowStockThreshold *int  `json:"low_stock_threshold" binding:"omitempty,min=0"`
+}
+
+// ProductFacets counts the products of a search per filter choice. Each facet is counted with every other filter of
+// the search applied but its own, so the other choices of a facet stay visible; tags, which narrow down together,
+// are counted with all filters. Choices without products are left out.
+type ProductFacets struct {
+	Categories   []FacetCount      `json:"categories"`
+	Tags         []FacetCount      `json:"tags"`
+	PriceRanges  []PriceRangeCount `json:"price_ranges"`
+	Ratings      []RatingCount     `json:"ratings"`
+	Availability AvailabilityCount `json:"availability"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// PriceRangeCount counts the products priced from Min to Max inclusive, which can be passed on as min_price and
+// max_price.
+type PriceRangeCount struct {
+	Min   Money `json:"min"`
+	Max   Money `json:"max"`
+	Count int   `json:"count"`
+}
+
+// RatingCount counts the products rated MinRating or better.
+type RatingCount struct {
+	MinRating int `json:"min_rating"`
+	Count     int `json:"count"`
+}
+
+type AvailabilityCount struct {
+	Available   int `json:"available"`
+	Unavailable int `json:"unavailable"`
 }
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+type PromotionType string
+
+const (
+	PromotionPercentage  PromotionType = "percentage"
+	PromotionFixedAmount PromotionType = "fixed_amount"
+	PromotionFreeItem    PromotionType = "free_item"
+)
+
+// Promotion is a promo code. Value is the percentage of a percentage promotion; Amount is the discount of a
+// fixed_amount promotion in minor units. Payloads may still give a fixed discount as value in the major unit of the
+// store's currency, as before amounts were kept in minor units; it is converted to amount when saved.
+type Promotion struct {
+	ID             int64         `json:"id"`
+	TenantID       string        `json:"-"`
+	Code           string        `json:"code"`
+	Description    string        `json:"description"`
+	Type           PromotionType `json:"type"`
+	Value          float64       `json:"value"`
+	Amount         Money         `json:"amount"`
+	FreeProductID  *int64        `json:"free_product_id,omitempty"`
+	MinBasket      *Money        `json:"min_basket,omitempty"`
+	MaxUses        *int          `json:"max_uses,omitempty"`
+	MaxUsesPerUser *int          `json:"max_uses_per_user,omitempty"`
+	StartsAt       *time.Time    `json:"starts_at,omitempty"`
+	EndsAt         *time.Time    `json:"ends_at,omitempty"`
+	Categories     []string      `json:"categories"`
+	Tags           []string      `json:"tags"`
+	IsActive       bool          `json:"is_active"`
+	UsageCount     int           `json:"usage_count"`
+}
+
+type PromotionPayload struct {
+	Code           string        `json:"code" binding:"required,max=64"`
+	Description    string        `json:"description"`
+	Type           PromotionType `json:"type" binding:"required,oneof=percentage fixed_amount free_item"`
+	Value          float64       `json:"value" binding:"min=0"`
+	Amount         Money         `json:"amount" binding:"min=0"`
+	FreeProductID  *int64        `json:"free_product_id"`
+	MinBasket      *Money        `json:"min_basket" binding:"omitempty,min=0"`
+	MaxUses        *int          `json:"max_uses" binding:"omitempty,min=1"`
+	MaxUsesPerUser *int          `json:"max_uses_per_user" binding:"omitempty,min=1"`
+	StartsAt       *time.Time    `json:"starts_at"`
+	EndsAt         *time.Time    `json:"ends_at"`
+	Categories     []string      `json:"categories"`
+	Tags           []string      `json:"tags"`
+	// IsActive defaults to true for a new promotion and keeps the stored value on update when omitted.
+	IsActive *bool `json:"is_active"`
+}
+
+type ApplyPromoPayload struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type PromotionRedemption struct {
+	ID             int64     `json:"id"`
+	PromotionID    int64     `json:"promotion_id"`
+	UserID         int64     `json:"user_id"`
+	OrderID        int64     `json:"order_id"`
+	Code           string    `json:"code"`
+	DiscountAmount Money     `json:"discount_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+}
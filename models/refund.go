@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type Refund struct {
+	ID          int64     `json:"id"`
+	OrderID     int64     `json:"order_id"`
+	OrderItemID *int64    `json:"order_item_id,omitempty"`
+	Quantity    int       `json:"quantity,omitempty"`
+	Amount      Money     `json:"amount"`
+	Reason      string    `json:"reason"`
+	RefundedBy  *int64    `json:"refunded_by,omitempty"`
+	PaymentID   *int64    `json:"payment_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RefundItemPayload struct {
+	OrderItemID int64 `json:"order_item_id" binding:"required"`
+	Quantity    int   `json:"quantity" binding:"required,min=1"`
+}
+
+// RefundPayload refunds the listed order lines, or everything that has not been refunded yet when Items is empty.
+type RefundPayload struct {
+	Reason string              `json:"reason" binding:"required,max=255"`
+	Items  []RefundItemPayload `json:"items" binding:"dive"`
+}
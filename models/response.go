@@ -1,10 +1,13 @@
 package models
 
+// APIResponse is the envelope of every response. Facets is only set by product searches that ask for them.
 type APIResponse[T any] struct {
-	Success bool   `json:"success"`
-	Message string `json:"message,omitempty"`
-	Data    T      `json:"data,omitempty"`
-	Error   string `json:"error,omitempty"`
+	Success    bool           `json:"success"`
+	Message    string         `json:"message,omitempty"`
+	Data       T              `json:"data,omitempty"`
+	Pagination *Pagination    `json:"pagination,omitempty"`
+	Facets     *ProductFacets `json:"facets,omitempty"`
+	Error      string         `json:"error,omitempty"`
 }
 
 type LoginResponse struct {
@@ -1,14 +1,17 @@
 package models
 
+import "time"
+
 type LeaveReviewPayload struct {
 	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
 	Comment string `json:"comment"`
 }
 
 type Review struct {
-	ID      int64
-	OrderID int64
-	UserID  int64
-	Rating  int64
-	Comment string
+	ID        int64     `json:"id"`
+	OrderID   int64     `json:"order_id"`
+	UserID    int64     `json:"user_id"`
+	Rating    int64     `json:"rating"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
 }
@@ -30,6 +30,86 @@ type TenantConfig struct {
 	ThemeColors  ThemeColors   `json:"themeColors"`
 	ContactInfo  ContactInfo   `json:"contactInfo"`
 	Features     RawJSONObject `json:"features"`
+	// Currency is the ISO 4217 code all prices of the tenant are in; Rounding decides how percentages and shares of
+	// amounts are rounded to whole minor units. They default to USD and half_up.
+	Currency string       `json:"currency" binding:"omitempty,iso4217"`
+	Rounding RoundingMode `json:"rounding" binding:"omitempty,oneof=half_up half_even down up"`
+	// Taxes, ServiceCharges and Fees are applied to every cart and order after discounts.
+	Taxes          []TaxRate       `json:"taxes,omitempty" binding:"omitempty,dive"`
+	ServiceCharges []ServiceCharge `json:"serviceCharges,omitempty" binding:"omitempty,dive"`
+	Fees           []Fee           `json:"fees,omitempty" binding:"omitempty,dive"`
+	// FulfilmentTypes are the ways orders can be fulfilled; without any, delivery and pickup are offered. Store is
+	// where radius delivery zones are measured from. Without delivery zones the tenant delivers to any address for free.
+	FulfilmentTypes []FulfilmentType `json:"fulfilmentTypes,omitempty" binding:"omitempty,dive,oneof=delivery pickup dine_in"`
+	Store           *GeoPoint        `json:"store,omitempty"`
+	DeliveryZones   []DeliveryZone   `json:"deliveryZones,omitempty" binding:"omitempty,dive"`
+	// Timezone is the IANA time zone the opening hours and closures are in, UTC by default. Without opening hours the
+	// store is open around the clock, except on closures.
+	Timezone     string         `json:"timezone,omitempty" binding:"omitempty,timezone"`
+	OpeningHours []OpeningHours `json:"openingHours,omitempty" binding:"omitempty,dive"`
+	Closures     []Closure      `json:"closures,omitempty" binding:"omitempty,dive"`
+	// PrepMinutes is the lead time before a scheduled order can be ready. Scheduled orders pick a slot of SlotMinutes
+	// (15 by default) up to ScheduleDays ahead (7 by default); SlotCapacity limits the orders per slot, 0 means no limit.
+	PrepMinutes  int `json:"prepMinutes,omitempty" binding:"gte=0,lte=1440"`
+	SlotMinutes  int `json:"slotMinutes,omitempty" binding:"omitempty,gte=5,lte=240"`
+	ScheduleDays int `json:"scheduleDays,omitempty" binding:"gte=0,lte=60"`
+	SlotCapacity int `json:"slotCapacity,omitempty" binding:"gte=0"`
+	// PasswordResetURL is the page of the tenant's storefront that password reset links open. Without it the links
+	// point to PASSWORD_RESET_URL, which suits tenants that share one storefront.
+	PasswordResetURL string `json:"passwordResetUrl,omitempty" binding:"omitempty,url"`
+}
+
+// OpeningHours is an opening window on a weekday (0 is Sunday). A window that closes at or before its opening time
+// runs past midnight, so 18:00 to 02:00 or 00:00 to 00:00 are both valid.
+type OpeningHours struct {
+	Day   int    `json:"day" binding:"gte=0,lte=6"`
+	Open  string `json:"open" binding:"required,datetime=15:04"`
+	Close string `json:"close" binding:"required,datetime=15:04"`
+}
+
+// Closure closes the store from From through To, or only on From when To is empty. Dates are in the tenant's time zone.
+type Closure struct {
+	From   string `json:"from" binding:"required,datetime=2006-01-02"`
+	To     string `json:"to,omitempty" binding:"omitempty,datetime=2006-01-02"`
+	Reason string `json:"reason,omitempty" binding:"max=255"`
+}
+
+type GeoPoint struct {
+	Lat float64 `json:"lat" binding:"latitude"`
+	Lng float64 `json:"lng" binding:"longitude"`
+}
+
+// DeliveryZone is an area the tenant delivers to, either a circle of RadiusKm around the store or a Polygon of at
+// least three points. When zones overlap the first one listed wins. Fee is added to the order and MinimumOrder is
+// checked against the item subtotal, both in minor units.
+type DeliveryZone struct {
+	Name         string     `json:"name" binding:"required,max=64"`
+	RadiusKm     float64    `json:"radiusKm,omitempty" binding:"omitempty,gt=0"`
+	Polygon      []GeoPoint `json:"polygon,omitempty" binding:"omitempty,min=3,dive"`
+	Fee          Money      `json:"fee" binding:"gte=0"`
+	MinimumOrder Money      `json:"minimumOrder" binding:"gte=0"`
+	EtaMinutes   int        `json:"etaMinutes" binding:"gte=0"`
+}
+
+// TaxRate is a percentage tax on the items of the listed categories, or on all items when none are listed. An
+// inclusive tax is already part of the prices and is only reported; an exclusive tax is added on top.
+type TaxRate struct {
+	Name       string   `json:"name" binding:"required,max=64"`
+	Rate       float64  `json:"rate" binding:"gt=0,lte=100"`
+	Inclusive  bool     `json:"inclusive"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// ServiceCharge is a percentage of the discounted item total added to every order.
+type ServiceCharge struct {
+	Name string  `json:"name" binding:"required,max=64"`
+	Rate float64 `json:"rate" binding:"gt=0,lte=100"`
+}
+
+// Fee is a fixed amount, in minor units, added to every order, such as a packaging fee.
+type Fee struct {
+	Name   string `json:"name" binding:"required,max=64"`
+	Amount Money  `json:"amount" binding:"gt=0"`
 }
 
 type Tenant struct {
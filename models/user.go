@@ -26,6 +26,10 @@ type LoginPayload struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type ForgotPasswordPayload struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
 type ResetPasswordPayload struct {
 	Token       string `json:"token" binding:"required"`
 	NewPassword string `json:"new_password" binding:"required,min=8"`
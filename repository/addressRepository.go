@@ -2,41 +2,94 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	db "github.com/AryaTabani/Dorivo/DB"
 	"github.com/AryaTabani/Dorivo/models"
 )
 
-func CreateAddress(ctx context.Context, userID int64, payload *models.AddAddressPayload) error {
-	query := `INSERT INTO user_addresses (user_id, name, address) VALUES (?, ?, ?)`
-	_, err := db.DB.ExecContext(ctx, query, userID, payload.Name, payload.Address)
+func CreateAddress(ctx context.Context, tx *sql.Tx, userID int64, payload *models.AddAddressPayload, isDefault bool) (int64, error) {
+	query := `
+		INSERT INTO user_addresses (user_id, name, street, unit, city, postal_code, notes, latitude, longitude, is_default)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := tx.ExecContext(ctx, query, userID, payload.Name, payload.Street, payload.Unit, payload.City, payload.PostalCode, payload.Notes,
+		payload.Latitude, payload.Longitude, isDefault)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// UpdateAddress replaces the fields of an address. The default mark is moved with SetDefaultAddress.
+func UpdateAddress(ctx context.Context, tx *sql.Tx, addressID int64, payload *models.AddAddressPayload) error {
+	query := `
+		UPDATE user_addresses
+		SET name = ?, street = ?, unit = ?, city = ?, postal_code = ?, notes = ?, latitude = ?, longitude = ?
+		WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, query, payload.Name, payload.Street, payload.Unit, payload.City, payload.PostalCode, payload.Notes,
+		payload.Latitude, payload.Longitude, addressID)
 	return err
 }
 
-func GetAddressesByUserID(ctx context.Context, userID int64) ([]models.Address, error) {
-	query := `SELECT id, name, address FROM user_addresses WHERE user_id = ? ORDER BY id DESC`
-	rows, err := db.DB.QueryContext(ctx, query, userID)
+// SetDefaultAddress makes addressID the user's only default address; an addressID of 0 just clears the mark.
+func SetDefaultAddress(ctx context.Context, tx *sql.Tx, userID, addressID int64) error {
+	query := `UPDATE user_addresses SET is_default = (id = ?) WHERE user_id = ?`
+	_, err := tx.ExecContext(ctx, query, addressID, userID)
+	return err
+}
+
+const addressColumns = `id, user_id, name, street, unit, city, postal_code, notes, latitude, longitude, is_default`
+
+func scanAddress(row rowScanner) (*models.Address, error) {
+	var addr models.Address
+	var latitude, longitude sql.NullFloat64
+	err := row.Scan(&addr.ID, &addr.UserID, &addr.Name, &addr.Street, &addr.Unit, &addr.City, &addr.PostalCode, &addr.Notes,
+		&latitude, &longitude, &addr.IsDefault)
+	if err != nil {
+		return nil, err
+	}
+	if latitude.Valid && longitude.Valid {
+		addr.Latitude, addr.Longitude = &latitude.Float64, &longitude.Float64
+	}
+	return &addr, nil
+}
+
+// GetAddressesByUserID lists the user's addresses, the default one first. Inside a transaction the rows are locked so
+// the default mark can be moved safely.
+func GetAddressesByUserID(ctx context.Context, tx *sql.Tx, userID int64) ([]models.Address, error) {
+	query := `SELECT ` + addressColumns + ` FROM user_addresses WHERE user_id = ? ORDER BY is_default DESC, id DESC`
+	if tx != nil {
+		query += ` FOR UPDATE`
+	}
+	rows, err := executor(tx).QueryContext(ctx, query, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var addresses []models.Address
+	addresses := make([]models.Address, 0)
 	for rows.Next() {
-		var addr models.Address
-		if err := rows.Scan(&addr.ID, &addr.Name, &addr.Address); err != nil {
+		addr, err := scanAddress(rows)
+		if err != nil {
 			return nil, err
 		}
-		addresses = append(addresses, addr)
+		addresses = append(addresses, *addr)
 	}
-	return addresses, nil
+	return addresses, rows.Err()
 }
 
-func DeleteAddress(ctx context.Context, userID int64, addressID int64) (int64, error) {
+func DeleteAddress(ctx context.Context, tx *sql.Tx, userID int64, addressID int64) (int64, error) {
 	query := `DELETE FROM user_addresses WHERE id = ? AND user_id = ?`
-	result, err := db.DB.ExecContext(ctx, query, addressID, userID)
+	result, err := tx.ExecContext(ctx, query, addressID, userID)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
+
+func GetAddressByIDAndUserID(ctx context.Context, addressID, userID int64) (*models.Address, error) {
+	query := `SELECT ` + addressColumns + ` FROM user_addresses WHERE id = ? AND user_id = ?`
+	return scanAddress(db.DB.QueryRowContext(ctx, query, addressID, userID))
+}
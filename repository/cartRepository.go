@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
 
 	db "github.com/AryaTabani/Dorivo/DB"
 	"github.com/AryaTabani/Dorivo/models"
@@ -27,9 +29,31 @@ func FindOrCreateCartByUserID(ctx context.Context, tx *sql.Tx, userID int64) (in
 	return cartID, err
 }
 
+// effectivePrice is the price a product currently sells at: its discount price when that is lower than the list price.
+const effectivePrice = `CASE WHEN p.discount_price IS NOT NULL AND p.discount_price < p.price THEN p.discount_price ELSE p.price END`
+
+// GetCurrentUnitPrice returns what one unit of a tenant's product with the given options sells at today.
+func GetCurrentUnitPrice(ctx context.Context, tenantID string, productID int64, optionIDs []int64) (models.Money, error) {
+	query := `SELECT ` + effectivePrice + ` FROM products p WHERE p.id = ? AND p.tenant_id = ?`
+	args := []interface{}{productID, tenantID}
+	if len(optionIDs) > 0 {
+		query = `SELECT ` + effectivePrice + ` + COALESCE((SELECT SUM(price_modifier) FROM options WHERE id IN (?` + strings.Repeat(",?", len(optionIDs)-1) + `)), 0)
+			FROM products p WHERE p.id = ? AND p.tenant_id = ?`
+		args = args[:0]
+		for _, optionID := range optionIDs {
+			args = append(args, optionID)
+		}
+		args = append(args, productID, tenantID)
+	}
+	var price models.Money
+	err := db.DB.QueryRowContext(ctx, query, args...).Scan(&price)
+	return price, err
+}
+
+// AddItem adds a line to a cart, keeping the current product and option prices as its price snapshot.
 func AddItem(ctx context.Context, tx *sql.Tx, cartID int64, payload *models.AddToCartPayload) error {
-	itemQuery := `INSERT INTO cart_items (cart_id, product_id, quantity) VALUES (?, ?, ?)`
-	res, err := tx.ExecContext(ctx, itemQuery, cartID, payload.ProductID, payload.Quantity)
+	itemQuery := `INSERT INTO cart_items (cart_id, product_id, quantity, unit_price) SELECT ?, p.id, ?, ` + effectivePrice + ` FROM products p WHERE p.id = ?`
+	res, err := tx.ExecContext(ctx, itemQuery, cartID, payload.Quantity, payload.ProductID)
 	if err != nil {
 		return err
 	}
@@ -37,100 +61,292 @@ func AddItem(ctx context.Context, tx *sql.Tx, cartID int64, payload *models.AddT
 	if err != nil {
 		return err
 	}
+	return insertCartItemOptions(ctx, tx, cartItemID, payload.OptionIDs)
+}
+
+func insertCartItemOptions(ctx context.Context, tx *sql.Tx, cartItemID int64, optionIDs []int64) error {
+	if len(optionIDs) == 0 {
+		return nil
+	}
+	optionsQuery := `INSERT INTO cart_item_options (cart_item_id, option_id, price_modifier) SELECT ?, id, price_modifier FROM options WHERE id IN (?` + strings.Repeat(",?", len(optionIDs)-1) + `)`
+	args := []interface{}{cartItemID}
+	for _, optionID := range optionIDs {
+		args = append(args, optionID)
+	}
+	_, err := tx.ExecContext(ctx, optionsQuery, args...)
+	return err
+}
+
+// RefreshCartItemPrices replaces the price snapshot of a cart line with the current product and option prices.
+func RefreshCartItemPrices(ctx context.Context, tx *sql.Tx, cartItemID int64) error {
+	itemQuery := `UPDATE cart_items ci JOIN products p ON ci.product_id = p.id SET ci.unit_price = ` + effectivePrice + ` WHERE ci.id = ?`
+	if _, err := tx.ExecContext(ctx, itemQuery, cartItemID); err != nil {
+		return err
+	}
+	optionsQuery := `UPDATE cart_item_options cio JOIN options o ON cio.option_id = o.id SET cio.price_modifier = o.price_modifier WHERE cio.cart_item_id = ?`
+	_, err := tx.ExecContext(ctx, optionsQuery, cartItemID)
+	return err
+}
+
+// ReplaceCartItemOptions swaps the option selection of a cart line.
+func ReplaceCartItemOptions(ctx context.Context, tx *sql.Tx, cartItemID int64, optionIDs []int64) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM cart_item_options WHERE cart_item_id = ?`, cartItemID); err != nil {
+		return err
+	}
+	return insertCartItemOptions(ctx, tx, cartItemID, optionIDs)
+}
+
+// GetCartLines returns the lines of a cart holding the given product, keyed by cart item ID, with the quantity and the
+// sorted IDs of the chosen options. It is used to find a line with the same option set before adding a new one.
+func GetCartLines(ctx context.Context, tx *sql.Tx, cartID, productID int64) (map[int64]*models.CartLine, error) {
+	lines, err := queryCartLines(ctx, tx, "ci.cart_id = ? AND ci.product_id = ?", cartID, productID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*models.CartLine, len(lines))
+	for i := range lines {
+		byID[lines[i].ID] = &lines[i]
+	}
+	return byID, nil
+}
 
-	if len(payload.OptionIDs) > 0 {
-		optionsQuery := `INSERT INTO cart_item_options (cart_item_id, option_id) VALUES `
-		var args []interface{}
-		for _, optionID := range payload.OptionIDs {
-			optionsQuery += `(?, ?),`
-			args = append(args, cartItemID, optionID)
+// GetAllCartLines returns every line of a cart in the order it was added.
+func GetAllCartLines(ctx context.Context, tx *sql.Tx, cartID int64) ([]models.CartLine, error) {
+	return queryCartLines(ctx, tx, "ci.cart_id = ?", cartID)
+}
+
+func queryCartLines(ctx context.Context, tx *sql.Tx, condition string, args ...interface{}) ([]models.CartLine, error) {
+	query := `
+		SELECT ci.id, ci.product_id, ci.quantity, cio.option_id
+		FROM cart_items ci
+		LEFT JOIN cart_item_options cio ON ci.id = cio.cart_item_id
+		WHERE ` + condition + `
+		ORDER BY ci.id, cio.option_id
+	`
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []models.CartLine
+	for rows.Next() {
+		var line models.CartLine
+		var optionID sql.NullInt64
+		if err := rows.Scan(&line.ID, &line.ProductID, &line.Quantity, &optionID); err != nil {
+			return nil, err
 		}
-		optionsQuery = optionsQuery[:len(optionsQuery)-1]
-		_, err = tx.ExecContext(ctx, optionsQuery, args...)
-		if err != nil {
-			return err
+		if len(lines) == 0 || lines[len(lines)-1].ID != line.ID {
+			line.OptionIDs = make([]int64, 0)
+			lines = append(lines, line)
+		}
+		if optionID.Valid {
+			last := &lines[len(lines)-1]
+			last.OptionIDs = append(last.OptionIDs, optionID.Int64)
 		}
 	}
-	return nil
+	return lines, rows.Err()
+}
+
+// GetCartItemProductID returns the product of a line in the given cart.
+func GetCartItemProductID(ctx context.Context, tx *sql.Tx, cartID, itemID int64) (int64, error) {
+	var productID int64
+	query := `SELECT product_id FROM cart_items WHERE id = ? AND cart_id = ?`
+	err := tx.QueryRowContext(ctx, query, itemID, cartID).Scan(&productID)
+	return productID, err
+}
+
+func SetCartItemQuantity(ctx context.Context, tx *sql.Tx, itemID int64, quantity int) error {
+	query := `UPDATE cart_items SET quantity = ? WHERE id = ?`
+	_, err := tx.ExecContext(ctx, query, quantity, itemID)
+	return err
+}
+
+func DeleteCartItem(ctx context.Context, tx *sql.Tx, itemID int64) error {
+	query := `DELETE FROM cart_items WHERE id = ?`
+	_, err := tx.ExecContext(ctx, query, itemID)
+	return err
 }
 
 func GetCartContentsByUserID(ctx context.Context, userID int64) ([]models.CartItem, error) {
+	return getCartContents(ctx, "c.user_id = ?", userID)
+}
+
+func GetCartContentsByCartID(ctx context.Context, cartID int64) ([]models.CartItem, error) {
+	return getCartContents(ctx, "c.id = ?", cartID)
+}
+
+// cartItemColumns selects a cart line with its current prices next to the prices it was added at. Lines without options
+// produce a single row with NULL option columns.
+const cartItemColumns = `ci.id, ci.product_id, ci.quantity, p.name, COALESCE(p.image_url, ''), p.main_category, p.category_id, ` + effectivePrice + `, ci.unit_price,
+			o.id, o.name, o.price_modifier, cio.price_modifier,
+			(SELECT GROUP_CONCAT(t.name) FROM product_tags pt JOIN tags t ON pt.tag_id = t.id WHERE pt.product_id = p.id)`
+
+func getCartContents(ctx context.Context, condition string, arg interface{}) ([]models.CartItem, error) {
 	query := `
-		SELECT ci.id, ci.product_id, ci.quantity, p.name, p.image_url, p.price, o.name, o.price_modifier
+		SELECT ` + cartItemColumns + `
 		FROM carts c
 		JOIN cart_items ci ON c.id = ci.cart_id
 		JOIN products p ON ci.product_id = p.id
 		LEFT JOIN cart_item_options cio ON ci.id = cio.cart_item_id
 		LEFT JOIN options o ON cio.option_id = o.id
-		WHERE c.user_id = ?
-		ORDER BY ci.id
+		WHERE ` + condition + `
+		ORDER BY ci.id, o.id
 	`
-	rows, err := db.DB.QueryContext(ctx, query, userID)
+	rows, err := db.DB.QueryContext(ctx, query, arg)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+	return scanCartItems(rows)
+}
 
-	cartItemsMap := make(map[int64]*models.CartItem)
+func scanCartItems(rows *sql.Rows) ([]models.CartItem, error) {
+	var items []models.CartItem
+	indexByID := make(map[int64]int)
 	for rows.Next() {
-		var itemID, productID int64
-		var quantity int
-		var productName, productImageURL string
-		var basePrice float64
-		var optionName sql.NullString
-		var optionPrice sql.NullFloat64
-
-		if err := rows.Scan(&itemID, &productID, &quantity, &productName, &productImageURL, &basePrice, &optionName, &optionPrice); err != nil {
+		var item models.CartItem
+		var optionID sql.NullInt64
+		var optionName, tags sql.NullString
+		var categoryID, optionPrice, optionSnapshotPrice sql.NullInt64
+		if err := rows.Scan(&item.ID, &item.ProductID, &item.Quantity, &item.Name, &item.ImageURL, &item.MainCategory, &categoryID, &item.BasePrice, &item.SnapshotBasePrice,
+			&optionID, &optionName, &optionPrice, &optionSnapshotPrice, &tags); err != nil {
 			return nil, err
 		}
-		if _, ok := cartItemsMap[itemID]; !ok {
-			cartItemsMap[itemID] = &models.CartItem{
-				ID:        itemID,
-				ProductID: productID,
-				Quantity:  quantity,
-				Name:      productName,
-				ImageURL:  productImageURL,
-				BasePrice: basePrice,
-				Options:   make([]models.CartItemOption, 0),
+		if categoryID.Valid {
+			item.CategoryID = &categoryID.Int64
+		}
+		idx, ok := indexByID[item.ID]
+		if !ok {
+			item.Options = make([]models.CartItemOption, 0)
+			if tags.Valid {
+				item.Tags = strings.Split(tags.String, ",")
 			}
+			items = append(items, item)
+			idx = len(items) - 1
+			indexByID[item.ID] = idx
 		}
-
 		if optionName.Valid {
-			cartItemsMap[itemID].Options = append(cartItemsMap[itemID].Options, models.CartItemOption{
-				Name:          optionName.String,
-				PriceModifier: optionPrice.Float64,
+			items[idx].Options = append(items[idx].Options, models.CartItemOption{
+				ID:                    optionID.Int64,
+				Name:                  optionName.String,
+				PriceModifier:         models.Money(optionPrice.Int64),
+				SnapshotPriceModifier: models.Money(optionSnapshotPrice.Int64),
 			})
 		}
 	}
-
-	var items []models.CartItem
-	for _, item := range cartItemsMap {
-		items = append(items, *item)
-	}
-
-	return items, nil
+	return items, rows.Err()
 }
 
-func UpdateCartItemQuantity(ctx context.Context, userID, itemID int64, quantity int) (int64, error) {
+func RemoveCartItem(ctx context.Context, userID, itemID int64) (int64, error) {
 	query := `
-		UPDATE cart_items SET quantity = ?
+		DELETE FROM cart_items
 		WHERE id = ? AND cart_id = (SELECT id FROM carts WHERE user_id = ?)
 	`
-	res, err := db.DB.ExecContext(ctx, query, quantity, itemID, userID)
+	res, err := db.DB.ExecContext(ctx, query, itemID, userID)
 	if err != nil {
 		return 0, err
 	}
 	return res.RowsAffected()
 }
 
-func RemoveCartItem(ctx context.Context, userID, itemID int64) (int64, error) {
-	query := `
-		DELETE FROM cart_items
-		WHERE id = ? AND cart_id = (SELECT id FROM carts WHERE user_id = ?)
-	`
-	res, err := db.DB.ExecContext(ctx, query, itemID, userID)
+func GetCartIDForUpdate(ctx context.Context, tx *sql.Tx, userID int64) (int64, error) {
+	var cartID int64
+	query := `SELECT id FROM carts WHERE user_id = ? FOR UPDATE`
+	err := tx.QueryRowContext(ctx, query, userID).Scan(&cartID)
+	return cartID, err
+}
+
+func RemoveCartItemByCartID(ctx context.Context, cartID, itemID int64) (int64, error) {
+	query := `DELETE FROM cart_items WHERE id = ? AND cart_id = ?`
+	res, err := db.DB.ExecContext(ctx, query, itemID, cartID)
 	if err != nil {
 		return 0, err
 	}
 	return res.RowsAffected()
 }
+
+func CreateGuestCart(ctx context.Context, tx *sql.Tx, tenantID, tokenHash string, expiresAt time.Time) (int64, error) {
+	query := `INSERT INTO carts (tenant_id, guest_token_hash, expires_at) VALUES (?, ?, ?)`
+	res, err := tx.ExecContext(ctx, query, tenantID, tokenHash, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// GetGuestCartID finds the unexpired guest cart of a tenant by the hash of its token. Inside a transaction the cart row
+// is locked.
+func GetGuestCartID(ctx context.Context, tx *sql.Tx, tenantID, tokenHash string) (int64, error) {
+	var cartID int64
+	query := `SELECT id FROM carts WHERE guest_token_hash = ? AND tenant_id = ? AND user_id IS NULL AND expires_at > ?`
+	if tx != nil {
+		query += ` FOR UPDATE`
+	}
+	err := executor(tx).QueryRowContext(ctx, query, tokenHash, tenantID, time.Now().UTC()).Scan(&cartID)
+	return cartID, err
+}
+
+// ExtendGuestCart pushes the expiry of a guest cart back; it is called whenever the guest changes the cart.
+func ExtendGuestCart(ctx context.Context, tx *sql.Tx, cartID int64, expiresAt time.Time) error {
+	query := `UPDATE carts SET expires_at = ? WHERE id = ?`
+	_, err := executor(tx).ExecContext(ctx, query, expiresAt, cartID)
+	return err
+}
+
+func DeleteCart(ctx context.Context, tx *sql.Tx, cartID int64) error {
+	query := `DELETE FROM carts WHERE id = ?`
+	_, err := tx.ExecContext(ctx, query, cartID)
+	return err
+}
+
+func DeleteExpiredGuestCarts(ctx context.Context, now time.Time) (int64, error) {
+	query := `DELETE FROM carts WHERE user_id IS NULL AND expires_at <= ?`
+	res, err := db.DB.ExecContext(ctx, query, now)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// GetCheckoutItems returns the lines of a cart that belong to products of the tenant. It takes no locks: the caller
+// holds the cart row, and stock is locked separately in a fixed order when it is reserved.
+func GetCheckoutItems(ctx context.Context, tx *sql.Tx, cartID int64, tenantID string) ([]models.CartItem, error) {
+	query := `
+		SELECT ` + cartItemColumns + `
+		FROM cart_items ci
+		JOIN products p ON ci.product_id = p.id
+		LEFT JOIN cart_item_options cio ON ci.id = cio.cart_item_id
+		LEFT JOIN options o ON cio.option_id = o.id
+		WHERE ci.cart_id = ? AND p.tenant_id = ?
+		ORDER BY ci.id, o.id
+	`
+	rows, err := tx.QueryContext(ctx, query, cartID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCartItems(rows)
+}
+
+func ClearCart(ctx context.Context, tx *sql.Tx, cartID int64) error {
+	query := `DELETE FROM cart_items WHERE cart_id = ?`
+	_, err := tx.ExecContext(ctx, query, cartID)
+	return err
+}
+
+func GetCartPromotionID(ctx context.Context, tx *sql.Tx, userID int64) (sql.NullInt64, error) {
+	var promotionID sql.NullInt64
+	query := `SELECT promotion_id FROM carts WHERE user_id = ?`
+	err := executor(tx).QueryRowContext(ctx, query, userID).Scan(&promotionID)
+	if err == sql.ErrNoRows {
+		return promotionID, nil
+	}
+	return promotionID, err
+}
+
+func SetCartPromotion(ctx context.Context, tx *sql.Tx, cartID int64, promotionID *int64) error {
+	query := `UPDATE carts SET promotion_id = ? WHERE id = ?`
+	_, err := tx.ExecContext(ctx, query, promotionID, cartID)
+	return err
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+const categoryColumns = `id, parent_id, name, image_url, display_order, is_visible,
+	TIME_FORMAT(available_from, '%H:%i'), TIME_FORMAT(available_until, '%H:%i')`
+
+func scanCategory(row rowScanner) (*models.Category, error) {
+	var c models.Category
+	var parentID sql.NullInt64
+	var availableFrom, availableUntil sql.NullString
+	err := row.Scan(&c.ID, &parentID, &c.Name, &c.ImageURL, &c.DisplayOrder, &c.IsVisible, &availableFrom, &availableUntil)
+	if err != nil {
+		return nil, err
+	}
+	if parentID.Valid {
+		c.ParentID = &parentID.Int64
+	}
+	if availableFrom.Valid {
+		c.AvailableFrom = &availableFrom.String
+	}
+	if availableUntil.Valid {
+		c.AvailableUntil = &availableUntil.String
+	}
+	return &c, nil
+}
+
+// GetCategories lists all categories of a tenant in display order.
+func GetCategories(ctx context.Context, tx *sql.Tx, tenantID string) ([]models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE tenant_id = ? ORDER BY display_order, name, id`
+	rows, err := executor(tx).QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]models.Category, 0)
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *c)
+	}
+	return categories, rows.Err()
+}
+
+func GetCategoryByID(ctx context.Context, tx *sql.Tx, tenantID string, categoryID int64) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = ? AND tenant_id = ?`
+	return scanCategory(executor(tx).QueryRowContext(ctx, query, categoryID, tenantID))
+}
+
+// GetCategoryByName finds a category the way the unique key on categories compares names, so case does not matter.
+func GetCategoryByName(ctx context.Context, tx *sql.Tx, tenantID string, name string) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE tenant_id = ? AND name = ?`
+	return scanCategory(executor(tx).QueryRowContext(ctx, query, tenantID, name))
+}
+
+func CreateCategory(ctx context.Context, tx *sql.Tx, tenantID string, payload *models.CategoryPayload) (int64, error) {
+	query := `
+		INSERT INTO categories (tenant_id, parent_id, name, image_url, display_order, is_visible, available_from, available_until)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := executor(tx).ExecContext(ctx, query, tenantID, payload.ParentID, payload.Name, payload.ImageURL, payload.DisplayOrder,
+		payload.IsVisible, payload.AvailableFrom, payload.AvailableUntil)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func UpdateCategory(ctx context.Context, tx *sql.Tx, tenantID string, categoryID int64, payload *models.CategoryPayload) error {
+	query := `
+		UPDATE categories SET parent_id = ?, name = ?, image_url = ?, display_order = ?, is_visible = ?, available_from = ?, available_until = ?
+		WHERE id = ? AND tenant_id = ?
+	`
+	_, err := executor(tx).ExecContext(ctx, query, payload.ParentID, payload.Name, payload.ImageURL, payload.DisplayOrder,
+		payload.IsVisible, payload.AvailableFrom, payload.AvailableUntil, categoryID, tenantID)
+	return err
+}
+
+func DeleteCategory(ctx context.Context, tx *sql.Tx, tenantID string, categoryID int64) error {
+	query := `DELETE FROM categories WHERE id = ? AND tenant_id = ?`
+	_, err := executor(tx).ExecContext(ctx, query, categoryID, tenantID)
+	return err
+}
+
+// CountCategoryUsage counts the products, tags and subcategories that belong to a category.
+func CountCategoryUsage(ctx context.Context, tx *sql.Tx, categoryID int64) (products, tags, children int, err error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM products WHERE category_id = ?),
+			(SELECT COUNT(*) FROM tags WHERE category_id = ?),
+			(SELECT COUNT(*) FROM categories WHERE parent_id = ?)
+	`
+	err = executor(tx).QueryRowContext(ctx, query, categoryID, categoryID, categoryID).Scan(&products, &tags, &children)
+	return products, tags, children, err
+}
+
+// RenameCategory copies a category's new name onto its products and tags, which keep it in main_category.
+func RenameCategory(ctx context.Context, tx *sql.Tx, categoryID int64, name string) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE products SET main_category = ? WHERE category_id = ?`, name, categoryID); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `UPDATE tags SET main_category = ? WHERE category_id = ?`, name, categoryID)
+	return err
+}
+
+// PromotionsUsingCategory lists the codes of the promotions restricted to a category.
+func PromotionsUsingCategory(ctx context.Context, tx *sql.Tx, tenantID string, name string) ([]string, error) {
+	return promotionsRestrictedTo(ctx, tx, tenantID, "categories", name)
+}
+
+// RenamePromotionCategory rewrites a category name in the restrictions of a tenant's promotions.
+func RenamePromotionCategory(ctx context.Context, tx *sql.Tx, tenantID string, from, to string) error {
+	return renamePromotionRestriction(ctx, tx, tenantID, "categories", from, to)
+}
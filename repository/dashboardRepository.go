@@ -10,8 +10,10 @@ import (
 func GetTenantDashboardStats(ctx context.Context, tenantID string) (*models.DashboardStats, error) {
 	var stats models.DashboardStats
 
-	revenueQuery := "SELECT COALESCE(SUM(total_price), 0) FROM orders WHERE tenant_id = ? AND status = 'Completed'"
-	err := db.DB.QueryRowContext(ctx, revenueQuery, tenantID).Scan(&stats.TotalRevenue)
+	revenueQuery := `
+		SELECT COALESCE(SUM(total_price - refunded_total), 0), COALESCE(SUM(refunded_total), 0)
+		FROM orders WHERE tenant_id = ? AND status IN ('Completed', 'Refunded')`
+	err := db.DB.QueryRowContext(ctx, revenueQuery, tenantID).Scan(&stats.TotalRevenue, &stats.TotalRefunded)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	db "github.com/AryaTabani/Dorivo/DB"
 	"github.com/AryaTabani/Dorivo/models"
@@ -19,26 +20,45 @@ func RemoveFromFavorites(ctx context.Context, userID, productID int64) error {
 	return err
 }
 
-func GetFavorites(ctx context.Context, userID int64) ([]models.Product, error) {
+// favoriteSortColumns are the sort fields of the favorites list, where newest means most recently favorited.
+var favoriteSortColumns = sortColumns{
+	"price":      productSortColumns["price"],
+	"rating":     productSortColumns["rating"],
+	"newest":     "uf.created_at",
+	"name":       productSortColumns["name"],
+	"popularity": productSortColumns["popularity"],
+}
+
+func GetFavorites(ctx context.Context, userID int64, page *models.PageQuery) (*models.Page[models.Product], error) {
+	ks := newKeyset(page, favoriteSortColumns, "p.id")
 	query := `
-		SELECT p.id, p.name, p.description, p.price, p.rating, p.image_url, p.main_category, p.discount_price, p.is_featured, p.is_recommended
+		SELECT p.id, p.name, p.description, p.price, p.rating, p.image_url, p.main_category, p.discount_price, p.is_featured, p.is_recommended, p.is_available, p.stock_quantity` + ks.columns + `
 		FROM products p
 		JOIN user_favorites uf ON p.id = uf.product_id
-		WHERE uf.user_id = ?
-	`
-	rows, err := db.DB.QueryContext(ctx, query, userID)
+		WHERE uf.user_id = ? AND ` + ks.condition + ks.orderBy + ks.limit
+	rows, err := db.DB.QueryContext(ctx, query, append([]interface{}{userID}, ks.args...)...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var products []models.Product
-	for rows.Next() {
+	result, err := scanPage(rows, page, func(row rowScanner) (*models.Product, error) {
 		var p models.Product
-		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.DiscountPrice, &p.IsFeatured, &p.IsRecommended); err != nil {
+		var stock sql.NullInt64
+		if err := row.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.DiscountPrice, &p.IsFeatured, &p.IsRecommended, &p.IsAvailable, &stock); err != nil {
+			return nil, err
+		}
+		p.StockQuantity = stockQuantity(stock)
+		return &p, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if page.WithTotal {
+		result.Pagination.Total, err = countTotal(ctx, `SELECT COUNT(*) FROM user_favorites WHERE user_id = ?`, userID)
+		if err != nil {
 			return nil, err
 		}
-		products = append(products, p)
 	}
-	return products, nil
+	return result, nil
 }
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	db "github.com/AryaTabani/Dorivo/DB"
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+func scanStockLevel(row rowScanner) (*models.StockLevel, error) {
+	var level models.StockLevel
+	var stock sql.NullInt64
+	if err := row.Scan(&level.Name, &stock, &level.IsAvailable, &level.LowStockThreshold); err != nil {
+		return nil, err
+	}
+	level.StockQuantity = stockQuantity(stock)
+	return &level, nil
+}
+
+// stockQuantity converts a nullable stock column; NULL means stock is not tracked.
+func stockQuantity(stock sql.NullInt64) *int {
+	if !stock.Valid {
+		return nil
+	}
+	quantity := int(stock.Int64)
+	return &quantity
+}
+
+// GetProductStock returns the inventory state of a product. Inside a transaction the product row is locked.
+func GetProductStock(ctx context.Context, tx *sql.Tx, tenantID string, productID int64) (*models.StockLevel, error) {
+	query := `SELECT name, stock_quantity, is_available, low_stock_threshold FROM products WHERE id = ? AND tenant_id = ?`
+	if tx != nil {
+		query += ` FOR UPDATE`
+	}
+	return scanStockLevel(executor(tx).QueryRowContext(ctx, query, productID, tenantID))
+}
+
+// GetOptionStock returns the inventory state of an option of the given product. The low stock threshold is the
+// product's. Inside a transaction only the option row is locked, not the product and group it is joined to.
+func GetOptionStock(ctx context.Context, tx *sql.Tx, productID, optionID int64) (*models.StockLevel, error) {
+	query := `
+		SELECT CONCAT(p.name, ' - ', o.name), o.stock_quantity, o.is_available, p.low_stock_threshold
+		FROM options o
+		JOIN option_groups og ON o.option_group_id = og.id
+		JOIN products p ON og.product_id = p.id
+		WHERE o.id = ? AND p.id = ?
+	`
+	if tx != nil {
+		query += ` FOR UPDATE OF o`
+	}
+	return scanStockLevel(executor(tx).QueryRowContext(ctx, query, optionID, productID))
+}
+
+// AdjustProductStock adds delta to the stock of a product that tracks stock.
+func AdjustProductStock(ctx context.Context, tx *sql.Tx, productID int64, delta int) error {
+	query := `UPDATE products SET stock_quantity = stock_quantity + ? WHERE id = ? AND stock_quantity IS NOT NULL`
+	_, err := tx.ExecContext(ctx, query, delta, productID)
+	return err
+}
+
+// AdjustOptionStock adds delta to the stock of an option that tracks stock.
+func AdjustOptionStock(ctx context.Context, tx *sql.Tx, optionID int64, delta int) error {
+	query := `UPDATE options SET stock_quantity = stock_quantity + ? WHERE id = ? AND stock_quantity IS NOT NULL`
+	_, err := tx.ExecContext(ctx, query, delta, optionID)
+	return err
+}
+
+func UpdateProductStock(ctx context.Context, tenantID string, productID int64, payload *models.StockPayload, lowStockThreshold int) error {
+	query := `UPDATE products SET stock_quantity = ?, is_available = ?, low_stock_threshold = ? WHERE id = ? AND tenant_id = ?`
+	_, err := db.DB.ExecContext(ctx, query, payload.StockQuantity, *payload.IsAvailable, lowStockThreshold, productID, tenantID)
+	return err
+}
+
+func OptionBelongsToTenant(ctx context.Context, tenantID string, optionID int64) (bool, error) {
+	var exists bool
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM options o
+			JOIN option_groups og ON o.option_group_id = og.id
+			JOIN products p ON og.product_id = p.id
+			WHERE o.id = ? AND p.tenant_id = ?
+		)
+	`
+	err := db.DB.QueryRowContext(ctx, query, optionID, tenantID).Scan(&exists)
+	return exists, err
+}
+
+func UpdateOptionStock(ctx context.Context, tenantID string, optionID int64, payload *models.StockPayload) error {
+	query := `
+		UPDATE options o
+		JOIN option_groups og ON o.option_group_id = og.id
+		JOIN products p ON og.product_id = p.id
+		SET o.stock_quantity = ?, o.is_available = ?
+		WHERE o.id = ? AND p.tenant_id = ?
+	`
+	_, err := db.DB.ExecContext(ctx, query, payload.StockQuantity, *payload.IsAvailable, optionID, tenantID)
+	return err
+}
+
+func CreateStockReservation(ctx context.Context, tx *sql.Tx, orderID int64, reservation *models.StockReservation) error {
+	query := `INSERT INTO stock_reservations (order_id, product_id, option_id, quantity) VALUES (?, ?, ?, ?)`
+	_, err := tx.ExecContext(ctx, query, orderID, reservation.ProductID, reservation.OptionID, reservation.Quantity)
+	return err
+}
+
+// GetOpenStockReservations returns the reservations of an order that have not been released yet and locks them.
+func GetOpenStockReservations(ctx context.Context, tx *sql.Tx, orderID int64) ([]models.StockReservation, error) {
+	query := `SELECT product_id, option_id, quantity FROM stock_reservations WHERE order_id = ? AND released = 0 FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []models.StockReservation
+	for rows.Next() {
+		var r models.StockReservation
+		var productID, optionID sql.NullInt64
+		if err := rows.Scan(&productID, &optionID, &r.Quantity); err != nil {
+			return nil, err
+		}
+		if productID.Valid {
+			r.ProductID = &productID.Int64
+		}
+		if optionID.Valid {
+			r.OptionID = &optionID.Int64
+		}
+		reservations = append(reservations, r)
+	}
+	return reservations, rows.Err()
+}
+
+func ReleaseStockReservations(ctx context.Context, tx *sql.Tx, orderID int64) error {
+	query := `UPDATE stock_reservations SET released = 1 WHERE order_id = ? AND released = 0`
+	_, err := tx.ExecContext(ctx, query, orderID)
+	return err
+}
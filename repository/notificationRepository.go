@@ -14,22 +14,35 @@ func CreateNotification(ctx context.Context, n *models.Notification) error {
 	return err
 }
 
-func GetNotificationsByUserID(ctx context.Context, userID int64) ([]*models.Notification, error) {
-	query := `SELECT id, user_id, title, type, content, is_read, metadata, created_at FROM notifications WHERE user_id = ? ORDER BY created_at DESC`
-	rows, err := db.DB.QueryContext(ctx, query, userID)
+var notificationSortColumns = sortColumns{
+	"newest": "created_at",
+}
+
+func GetNotificationsByUserID(ctx context.Context, userID int64, page *models.PageQuery) (*models.Page[models.Notification], error) {
+	ks := newKeyset(page, notificationSortColumns, "id")
+	query := `SELECT id, user_id, title, type, COALESCE(content, ''), is_read, metadata, created_at` + ks.columns +
+		` FROM notifications WHERE user_id = ? AND ` + ks.condition + ks.orderBy + ks.limit
+	rows, err := db.DB.QueryContext(ctx, query, append([]interface{}{userID}, ks.args...)...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var notifications []*models.Notification
-	for rows.Next() {
+
+	result, err := scanPage(rows, page, func(row rowScanner) (*models.Notification, error) {
 		var n models.Notification
-		if err := rows.Scan(&n.ID, &n.UserID, &n.Title, &n.Type, &n.Content, &n.IsRead, &n.Metadata, &n.CreatedAt); err != nil {
+		err := row.Scan(&n.ID, &n.UserID, &n.Title, &n.Type, &n.Content, &n.IsRead, &n.Metadata, &n.CreatedAt)
+		return &n, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if page.WithTotal {
+		result.Pagination.Total, err = countTotal(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = ?`, userID)
+		if err != nil {
 			return nil, err
 		}
-		notifications = append(notifications, &n)
 	}
-	return notifications, nil
+	return result, nil
 }
 
 func MarkNotificationsAsRead(ctx context.Context, userID int64, notificationIDs []int64) error {
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	db "github.com/AryaTabani/Dorivo/DB"
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+const optionGroupColumns = `og.id, og.product_id, og.name, og.selection_type, og.min_selections, og.max_selections, og.is_required, og.sort_order`
+
+const optionColumns = `o.id, o.option_group_id, o.name, o.price_modifier, o.is_default, o.sort_order, o.is_available, o.stock_quantity`
+
+func scanOptionGroup(row rowScanner) (*models.OptionGroup, error) {
+	var group models.OptionGroup
+	var maxSelections sql.NullInt64
+	err := row.Scan(&group.ID, &group.ProductID, &group.Name, &group.SelectionType, &group.MinSelections, &maxSelections, &group.IsRequired, &group.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+	if maxSelections.Valid {
+		max := int(maxSelections.Int64)
+		group.MaxSelections = &max
+	}
+	group.Options = make([]models.Option, 0)
+	return &group, nil
+}
+
+func scanOption(row rowScanner) (*models.Option, error) {
+	var opt models.Option
+	var stock sql.NullInt64
+	err := row.Scan(&opt.ID, &opt.OptionGroupID, &opt.Name, &opt.PriceModifier, &opt.IsDefault, &opt.SortOrder, &opt.IsAvailable, &stock)
+	if err != nil {
+		return nil, err
+	}
+	opt.StockQuantity = stockQuantity(stock)
+	return &opt, nil
+}
+
+// GetOptionGroupsByProductID returns the option groups of a product with their options, both in display order.
+func GetOptionGroupsByProductID(ctx context.Context, productID int64) ([]models.OptionGroup, error) {
+	query := `SELECT ` + optionGroupColumns + ` FROM option_groups og WHERE og.product_id = ? ORDER BY og.sort_order, og.id`
+	rows, err := db.DB.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make([]models.OptionGroup, 0)
+	for rows.Next() {
+		group, err := scanOptionGroup(rows)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, *group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return groups, nil
+	}
+
+	groupIndex := make(map[int64]int, len(groups))
+	groupIDs := make([]interface{}, len(groups))
+	for i, group := range groups {
+		groupIndex[group.ID] = i
+		groupIDs[i] = group.ID
+	}
+
+	optionsQuery := fmt.Sprintf(`SELECT %s FROM options o WHERE o.option_group_id IN (?%s) ORDER BY o.sort_order, o.id`, optionColumns, strings.Repeat(",?", len(groupIDs)-1))
+	optionRows, err := db.DB.QueryContext(ctx, optionsQuery, groupIDs...)
+	if err != nil {
+		return nil, err
+	}
+	defer optionRows.Close()
+
+	for optionRows.Next() {
+		opt, err := scanOption(optionRows)
+		if err != nil {
+			return nil, err
+		}
+		i := groupIndex[opt.OptionGroupID]
+		groups[i].Options = append(groups[i].Options, *opt)
+	}
+	return groups, optionRows.Err()
+}
+
+// GetOptionGroup returns an option group of a tenant's product with its options. Inside a transaction the group row is
+// locked so concurrent edits of its options are serialised.
+func GetOptionGroup(ctx context.Context, tx *sql.Tx, tenantID string, productID, groupID int64) (*models.OptionGroup, error) {
+	query := `
+		SELECT ` + optionGroupColumns + `
+		FROM option_groups og
+		JOIN products p ON og.product_id = p.id
+		WHERE og.id = ? AND og.product_id = ? AND p.tenant_id = ?
+	`
+	if tx != nil {
+		query += ` FOR UPDATE`
+	}
+	group, err := scanOptionGroup(executor(tx).QueryRowContext(ctx, query, groupID, productID, tenantID))
+	if err != nil {
+		return nil, err
+	}
+
+	optionsQuery := `SELECT ` + optionColumns + ` FROM options o WHERE o.option_group_id = ? ORDER BY o.sort_order, o.id`
+	rows, err := executor(tx).QueryContext(ctx, optionsQuery, groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		opt, err := scanOption(rows)
+		if err != nil {
+			return nil, err
+		}
+		group.Options = append(group.Options, *opt)
+	}
+	return group, rows.Err()
+}
+
+func CreateOptionGroup(ctx context.Context, tx *sql.Tx, productID int64, payload *models.OptionGroupPayload) (int64, error) {
+	query := `INSERT INTO option_groups (product_id, name, selection_type, min_selections, max_selections, is_required, sort_order) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := executor(tx).ExecContext(ctx, query, productID, payload.Name, payload.SelectionType, payload.MinSelections, payload.MaxSelections, payload.IsRequired, payload.SortOrder)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func UpdateOptionGroup(ctx context.Context, tx *sql.Tx, groupID int64, payload *models.OptionGroupPayload) error {
+	query := `UPDATE option_groups SET name = ?, selection_type = ?, min_selections = ?, max_selections = ?, is_required = ?, sort_order = ? WHERE id = ?`
+	_, err := executor(tx).ExecContext(ctx, query, payload.Name, payload.SelectionType, payload.MinSelections, payload.MaxSelections, payload.IsRequired, payload.SortOrder, groupID)
+	return err
+}
+
+func DeleteOptionGroup(ctx context.Context, groupID int64) error {
+	query := `DELETE FROM option_groups WHERE id = ?`
+	_, err := db.DB.ExecContext(ctx, query, groupID)
+	return err
+}
+
+func CreateOption(ctx context.Context, tx *sql.Tx, groupID int64, payload *models.OptionPayload) (int64, error) {
+	isAvailable := payload.IsAvailable == nil || *payload.IsAvailable
+	query := `INSERT INTO options (option_group_id, name, price_modifier, is_default, sort_order, is_available, stock_quantity) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := executor(tx).ExecContext(ctx, query, groupID, payload.Name, payload.PriceModifier, payload.IsDefault, payload.SortOrder, isAvailable, payload.StockQuantity)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func UpdateOption(ctx context.Context, tx *sql.Tx, optionID int64, payload *models.OptionPayload) error {
+	isAvailable := payload.IsAvailable == nil || *payload.IsAvailable
+	query := `UPDATE options SET name = ?, price_modifier = ?, is_default = ?, sort_order = ?, is_available = ?, stock_quantity = ? WHERE id = ?`
+	_, err := executor(tx).ExecContext(ctx, query, payload.Name, payload.PriceModifier, payload.IsDefault, payload.SortOrder, isAvailable, payload.StockQuantity, optionID)
+	return err
+}
+
+func DeleteOption(ctx context.Context, tx *sql.Tx, optionID int64) error {
+	query := `DELETE FROM options WHERE id = ?`
+	_, err := executor(tx).ExecContext(ctx, query, optionID)
+	return err
+}
+
+// ClearDefaultOptions unmarks every default option of a group except keepID.
+func ClearDefaultOptions(ctx context.Context, tx *sql.Tx, groupID, keepID int64) error {
+	query := `UPDATE options SET is_default = 0 WHERE option_group_id = ? AND id <> ? AND is_default = 1`
+	_, err := executor(tx).ExecContext(ctx, query, groupID, keepID)
+	return err
+}
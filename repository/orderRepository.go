@@ -3,6 +3,10 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	db "github.com/AryaTabani/Dorivo/DB"
 	"github.com/AryaTabani/Dorivo/models"
@@ -12,46 +16,213 @@ func BeginTx(ctx context.Context) (*sql.Tx, error) {
 	return db.DB.BeginTx(ctx, nil)
 }
 
-func GetOrdersByUserID(ctx context.Context, userID int64, status string) ([]models.OrderSummaryView, error) {
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows so one scan helper can serve single and list queries.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// executor lets a repository function run either inside the caller's transaction or, when tx is nil, directly on the pool.
+func executor(tx *sql.Tx) dbExecutor {
+	if tx != nil {
+		return tx
+	}
+	return db.DB
+}
+
+// orderStatusFilter renders an "IN (...)" condition on the order status column together with its arguments.
+func orderStatusFilter(column string, statuses []models.OrderStatus) (string, []interface{}) {
+	args := make([]interface{}, len(statuses))
+	for i, status := range statuses {
+		args[i] = status
+	}
+	return column + ` IN (?` + strings.Repeat(",?", len(statuses)-1) + `)`, args
+}
+
+// orderSortColumns are the sort fields of order lists; price is the order total.
+var orderSortColumns = sortColumns{
+	"newest": "o.created_at",
+	"price":  "o.total_price",
+}
+
+func GetOrdersByUserID(ctx context.Context, userID int64, statuses []models.OrderStatus, page *models.PageQuery) (*models.Page[models.OrderSummaryView], error) {
+	statusCondition, statusArgs := orderStatusFilter("o.status", statuses)
+	where := `WHERE o.user_id = ? AND ` + statusCondition
+	args := append([]interface{}{userID}, statusArgs...)
+
+	ks := newKeyset(page, orderSortColumns, "o.id")
 	query := `
-		SELECT o.id, o.total_price, o.status, o.created_at,
+		SELECT o.id, o.currency, o.total_price, o.status, o.created_at,
 			(SELECT item_name FROM order_items WHERE order_id = o.id LIMIT 1) as primary_item_name,
 			(SELECT image_url FROM order_items WHERE order_id = o.id LIMIT 1) as primary_item_img,
-			(SELECT COUNT(*) FROM order_items WHERE order_id = o.id) as item_count
+			(SELECT COUNT(*) FROM order_items WHERE order_id = o.id) as item_count` + ks.columns + `
 		FROM orders o
-		WHERE o.user_id = ? AND o.status = ?
-		ORDER BY o.created_at DESC;
-	`
-	rows, err := db.DB.QueryContext(ctx, query, userID, status)
+		` + where + ` AND ` + ks.condition + ks.orderBy + ks.limit
+	rows, err := db.DB.QueryContext(ctx, query, append(append([]interface{}{}, args...), ks.args...)...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var orders []models.OrderSummaryView
-	for rows.Next() {
+	result, err := scanPage(rows, page, func(row rowScanner) (*models.OrderSummaryView, error) {
 		var order models.OrderSummaryView
-		err := rows.Scan(&order.ID, &order.TotalPrice, &order.Status, &order.CreatedAt, &order.PrimaryItemName, &order.PrimaryItemImg, &order.ItemCount)
+		err := row.Scan(&order.ID, &order.Currency, &order.TotalPrice, &order.Status, &order.CreatedAt, &order.PrimaryItemName, &order.PrimaryItemImg, &order.ItemCount)
+		return &order, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if page.WithTotal {
+		result.Pagination.Total, err = countTotal(ctx, `SELECT COUNT(*) FROM orders o `+where, args...)
 		if err != nil {
 			return nil, err
 		}
-		orders = append(orders, order)
 	}
-	return orders, nil
+	return result, nil
 }
 
-func GetOrderByIdAndUserID(ctx context.Context, orderID, userID int64) (*models.Order, error) {
+const orderColumns = `id, COALESCE(user_id, 0), tenant_id, COALESCE(guest_email, ''), COALESCE(guest_phone, ''), status, currency, total_price, discount_total, tax_total, fee_total, refunded_total, COALESCE(promo_code, ''), address_id, delivery_address, payment_method_id,
+	fulfilment_type, COALESCE(table_number, ''), COALESCE(delivery_zone, ''), eta_minutes, scheduled_for, created_at`
+
+func scanOrder(row rowScanner) (*models.Order, error) {
 	var order models.Order
-	query := `SELECT id, user_id, tenant_id, status, total_price, created_at FROM orders WHERE id = ? AND user_id = ?`
-	err := db.DB.QueryRowContext(ctx, query, orderID, userID).Scan(
+	var addressID, paymentMethodID, etaMinutes sql.NullInt64
+	var deliveryAddress sql.NullString
+	var scheduledFor sql.NullTime
+	err := row.Scan(
 		&order.ID,
 		&order.UserID,
 		&order.TenantID,
+		&order.GuestEmail,
+		&order.GuestPhone,
 		&order.Status,
+		&order.Currency,
 		&order.TotalPrice,
+		&order.DiscountTotal,
+		&order.TaxTotal,
+		&order.FeeTotal,
+		&order.RefundedTotal,
+		&order.PromoCode,
+		&addressID,
+		&deliveryAddress,
+		&paymentMethodID,
+		&order.FulfilmentType,
+		&order.TableNumber,
+		&order.DeliveryZone,
+		&etaMinutes,
+		&scheduledFor,
 		&order.CreatedAt,
 	)
-	return &order, err
+	if err != nil {
+		return nil, err
+	}
+	if addressID.Valid {
+		order.AddressID = &addressID.Int64
+	}
+	if deliveryAddress.Valid {
+		if err := json.Unmarshal([]byte(deliveryAddress.String), &order.DeliveryAddress); err != nil {
+			return nil, err
+		}
+	}
+	if paymentMethodID.Valid {
+		order.PaymentMethodID = &paymentMethodID.Int64
+	}
+	if etaMinutes.Valid {
+		eta := int(etaMinutes.Int64)
+		order.EtaMinutes = &eta
+	}
+	if scheduledFor.Valid {
+		order.ScheduledFor = &scheduledFor.Time
+	}
+	return &order, nil
+}
+
+func GetOrderByIdAndUserID(ctx context.Context, orderID, userID int64) (*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = ? AND user_id = ?`
+	return scanOrder(db.DB.QueryRowContext(ctx, query, orderID, userID))
+}
+
+func GetOrderByIDAndTenantID(ctx context.Context, orderID int64, tenantID string) (*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = ? AND tenant_id = ?`
+	return scanOrder(db.DB.QueryRowContext(ctx, query, orderID, tenantID))
+}
+
+// GetOrderForUpdate locks the order row for the rest of the transaction so concurrent status changes are serialised.
+func GetOrderForUpdate(ctx context.Context, tx *sql.Tx, orderID int64) (*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = ? FOR UPDATE`
+	return scanOrder(tx.QueryRowContext(ctx, query, orderID))
+}
+
+func GetOrderByID(ctx context.Context, orderID int64) (*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = ?`
+	return scanOrder(db.DB.QueryRowContext(ctx, query, orderID))
+}
+
+// orderLockTimeout is how many seconds LockOrder waits for another status change of the same order to finish.
+const orderLockTimeout = 30
+
+// LockOrder takes a named lock on an order until the returned release function is called. Status changes that call
+// the payment gateway hold it instead of the order row, so the row is not locked while the gateway is waited on.
+func LockOrder(ctx context.Context, orderID int64) (func(), error) {
+	conn, err := db.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("dorivo_order_%d", orderID)
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, ?)`, name, orderLockTimeout).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if acquired.Int64 != 1 {
+		conn.Close()
+		return nil, fmt.Errorf("timed out waiting for the lock on order %d", orderID)
+	}
+	return func() {
+		conn.ExecContext(context.Background(), `DO RELEASE_LOCK(?)`, name)
+		conn.Close()
+	}, nil
+}
+
+// CountSlotOrders counts the orders per slot, scheduled or made as soon as possible, that take up a slot between from
+// and to and are not cancelled or refunded. Inside a transaction the counted orders are locked so a slot cannot be
+// overbooked concurrently.
+func CountSlotOrders(ctx context.Context, tx *sql.Tx, tenantID string, from, to time.Time) (map[time.Time]int, error) {
+	query := `
+		SELECT slot_start, COUNT(*) FROM orders
+		WHERE tenant_id = ? AND slot_start >= ? AND slot_start < ? AND status NOT IN (?, ?)
+		GROUP BY slot_start
+	`
+	if tx != nil {
+		query += ` FOR UPDATE`
+	}
+	rows, err := executor(tx).QueryContext(ctx, query, tenantID, from, to, models.OrderStatusCancelled, models.OrderStatusRefunded)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[time.Time]int)
+	for rows.Next() {
+		var slot time.Time
+		var count int
+		if err := rows.Scan(&slot, &count); err != nil {
+			return nil, err
+		}
+		counts[slot.UTC()] = count
+	}
+	return counts, rows.Err()
+}
+
+func AddOrderRefundedTotal(ctx context.Context, tx *sql.Tx, orderID int64, amount models.Money) error {
+	query := `UPDATE orders SET refunded_total = refunded_total + ? WHERE id = ?`
+	_, err := tx.ExecContext(ctx, query, amount, orderID)
+	return err
 }
 
 func CreateCancellation(ctx context.Context, tx *sql.Tx, userID, orderID int64, reason string) error {
@@ -60,7 +231,7 @@ func CreateCancellation(ctx context.Context, tx *sql.Tx, userID, orderID int64,
 	return err
 }
 
-func UpdateOrderStatus(ctx context.Context, tx *sql.Tx, orderID int64, newStatus string) error {
+func UpdateOrderStatus(ctx context.Context, tx *sql.Tx, orderID int64, newStatus models.OrderStatus) error {
 	query := `UPDATE orders SET status = ? WHERE id = ?`
 	_, err := tx.ExecContext(ctx, query, newStatus, orderID)
 	return err
@@ -79,31 +250,230 @@ func CreateReview(ctx context.Context, userID, orderID int64, rating int, commen
 	return err
 }
 
-func GetOrdersByTenantID(ctx context.Context, tenantId string, status string) ([]models.Order, error) {
-	query := `SELECT id, user_id, tenant_id, status, total_price, created_at FROM orders WHERE tenant_id = ? AND status = ? ORDER BY created_at DESC`
-	rows, err := db.DB.QueryContext(ctx, query, tenantId, status)
+func GetOrdersByTenantID(ctx context.Context, tenantId string, statuses []models.OrderStatus, page *models.PageQuery) (*models.Page[models.Order], error) {
+	statusCondition, statusArgs := orderStatusFilter("o.status", statuses)
+	where := `WHERE o.tenant_id = ? AND ` + statusCondition
+	args := append([]interface{}{tenantId}, statusArgs...)
+
+	ks := newKeyset(page, orderSortColumns, "o.id")
+	query := `SELECT ` + orderColumns + ks.columns + ` FROM orders o ` + where + ` AND ` + ks.condition + ks.orderBy + ks.limit
+	rows, err := db.DB.QueryContext(ctx, query, append(append([]interface{}{}, args...), ks.args...)...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var orders []models.Order
-	for rows.Next() {
-		var o models.Order
-		err := rows.Scan(&o.ID, &o.UserID, &o.TenantID, &o.Status, &o.TotalPrice, &o.CreatedAt)
+
+	result, err := scanPage(rows, page, scanOrder)
+	if err != nil {
+		return nil, err
+	}
+	if page.WithTotal {
+		result.Pagination.Total, err = countTotal(ctx, `SELECT COUNT(*) FROM orders o `+where, args...)
 		if err != nil {
 			return nil, err
 		}
-		orders = append(orders, o)
+	}
+	return result, nil
+}
 
+func CreateOrder(ctx context.Context, tx *sql.Tx, order *models.Order) (int64, error) {
+	var promoCode sql.NullString
+	if order.PromoCode != "" {
+		promoCode = sql.NullString{String: order.PromoCode, Valid: true}
+	}
+	var userID sql.NullInt64
+	if order.UserID != 0 {
+		userID = sql.NullInt64{Int64: order.UserID, Valid: true}
+	}
+	var guestEmail, guestPhone sql.NullString
+	if order.GuestEmail != "" {
+		guestEmail = sql.NullString{String: order.GuestEmail, Valid: true}
 	}
-	return orders, nil
+	if order.GuestPhone != "" {
+		guestPhone = sql.NullString{String: order.GuestPhone, Valid: true}
+	}
+	var deliveryAddress, tableNumber, deliveryZone sql.NullString
+	if order.DeliveryAddress != nil {
+		addressJSON, err := json.Marshal(order.DeliveryAddress)
+		if err != nil {
+			return 0, err
+		}
+		deliveryAddress = sql.NullString{String: string(addressJSON), Valid: true}
+	}
+	if order.TableNumber != "" {
+		tableNumber = sql.NullString{String: order.TableNumber, Valid: true}
+	}
+	if order.DeliveryZone != "" {
+		deliveryZone = sql.NullString{String: order.DeliveryZone, Valid: true}
+	}
+	query := `
+		INSERT INTO orders (user_id, tenant_id, guest_email, guest_phone, status, currency, total_price, discount_total, tax_total, fee_total, promo_code, address_id, delivery_address,
+			payment_method_id, fulfilment_type, table_number, delivery_zone, eta_minutes, scheduled_for, slot_start, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := tx.ExecContext(ctx, query, userID, order.TenantID, guestEmail, guestPhone, order.Status, order.Currency, order.TotalPrice, order.DiscountTotal,
+		order.TaxTotal, order.FeeTotal, promoCode, order.AddressID, deliveryAddress, order.PaymentMethodID, order.FulfilmentType, tableNumber, deliveryZone, order.EtaMinutes, order.ScheduledFor, order.SlotStart, order.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
 }
 
-func AdminUpdateOrderStatus(ctx context.Context, tenantID string, orderID int64, newStatus string) (int64, error) {
-	query := `UPDATE orders SET status = ? WHERE id = ? AND tenant_id = ?`
-	res, err := db.DB.ExecContext(ctx, query, newStatus, tenantID, orderID)
+func CreateOrderItem(ctx context.Context, tx *sql.Tx, orderID int64, item *models.OrderItem) (int64, error) {
+	query := `INSERT INTO order_items (order_id, product_id, item_name, quantity, price, image_url) VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := tx.ExecContext(ctx, query, orderID, item.ProductID, item.Name, item.Quantity, item.Price, item.ImageURL)
 	if err != nil {
 		return 0, err
 	}
-	return res.RowsAffected()
+	return res.LastInsertId()
+}
+
+func CreateOrderItemOption(ctx context.Context, tx *sql.Tx, orderItemID int64, option *models.OrderItemOption) (int64, error) {
+	query := `INSERT INTO order_item_options (order_item_id, option_id, option_name, price_modifier) VALUES (?, ?, ?, ?)`
+	res, err := tx.ExecContext(ctx, query, orderItemID, option.OptionID, option.Name, option.PriceModifier)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func CreateOrderCharge(ctx context.Context, tx *sql.Tx, orderID int64, charge *models.Charge) error {
+	query := `INSERT INTO order_charges (order_id, kind, name, rate, inclusive, amount) VALUES (?, ?, ?, ?, ?, ?)`
+	_, err := tx.ExecContext(ctx, query, orderID, charge.Kind, charge.Name, charge.Rate, charge.Inclusive, charge.Amount)
+	return err
+}
+
+func GetOrderCharges(ctx context.Context, orderID int64) ([]models.Charge, error) {
+	query := `SELECT kind, name, rate, inclusive, amount FROM order_charges WHERE order_id = ? ORDER BY id`
+	rows, err := db.DB.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	charges := make([]models.Charge, 0)
+	for rows.Next() {
+		var charge models.Charge
+		var rate sql.NullFloat64
+		if err := rows.Scan(&charge.Kind, &charge.Name, &rate, &charge.Inclusive, &charge.Amount); err != nil {
+			return nil, err
+		}
+		if rate.Valid {
+			charge.Rate = &rate.Float64
+		}
+		charges = append(charges, charge)
+	}
+	return charges, rows.Err()
+}
+
+func GetOrderItems(ctx context.Context, orderID int64) ([]models.OrderItem, error) {
+	query := `
+		SELECT oi.id, oi.product_id, oi.item_name, oi.quantity, oi.price, COALESCE(oi.image_url, ''), oio.id, oio.option_id, oio.option_name, oio.price_modifier
+		FROM order_items oi
+		LEFT JOIN order_item_options oio ON oi.id = oio.order_item_id
+		WHERE oi.order_id = ?
+		ORDER BY oi.id, oio.id
+	`
+	rows, err := db.DB.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.OrderItem, 0)
+	indexByID := make(map[int64]int)
+	for rows.Next() {
+		var item models.OrderItem
+		var productID, optionID, optionRef sql.NullInt64
+		var optionName sql.NullString
+		var optionPrice sql.NullInt64
+		if err := rows.Scan(&item.ID, &productID, &item.Name, &item.Quantity, &item.Price, &item.ImageURL, &optionID, &optionRef, &optionName, &optionPrice); err != nil {
+			return nil, err
+		}
+		if productID.Valid {
+			item.ProductID = &productID.Int64
+		}
+		idx, ok := indexByID[item.ID]
+		if !ok {
+			item.Options = make([]models.OrderItemOption, 0)
+			items = append(items, item)
+			idx = len(items) - 1
+			indexByID[item.ID] = idx
+		}
+		if optionID.Valid {
+			option := models.OrderItemOption{
+				ID:            optionID.Int64,
+				Name:          optionName.String,
+				PriceModifier: models.Money(optionPrice.Int64),
+			}
+			if optionRef.Valid {
+				option.OptionID = &optionRef.Int64
+			}
+			items[idx].Options = append(items[idx].Options, option)
+		}
+	}
+	return items, rows.Err()
+}
+
+func CreateOrderStatusChange(ctx context.Context, tx *sql.Tx, orderID int64, change *models.OrderStatusChange) error {
+	var fromStatus, note sql.NullString
+	if change.FromStatus != "" {
+		fromStatus = sql.NullString{String: string(change.FromStatus), Valid: true}
+	}
+	if change.Note != "" {
+		note = sql.NullString{String: change.Note, Valid: true}
+	}
+	query := `INSERT INTO order_status_history (order_id, from_status, to_status, changed_by, note) VALUES (?, ?, ?, ?, ?)`
+	_, err := tx.ExecContext(ctx, query, orderID, fromStatus, change.ToStatus, change.ChangedBy, note)
+	return err
+}
+
+func GetOrderStatusHistory(ctx context.Context, orderID int64) ([]models.OrderStatusChange, error) {
+	query := `
+		SELECT COALESCE(from_status, ''), to_status, changed_by, COALESCE(note, ''), created_at
+		FROM order_status_history
+		WHERE order_id = ?
+		ORDER BY created_at, id
+	`
+	rows, err := db.DB.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]models.OrderStatusChange, 0)
+	for rows.Next() {
+		var change models.OrderStatusChange
+		var changedBy sql.NullInt64
+		if err := rows.Scan(&change.FromStatus, &change.ToStatus, &changedBy, &change.Note, &change.CreatedAt); err != nil {
+			return nil, err
+		}
+		if changedBy.Valid {
+			change.ChangedBy = &changedBy.Int64
+		}
+		history = append(history, change)
+	}
+	return history, rows.Err()
+}
+
+func GetCancellationReason(ctx context.Context, orderID int64) (string, error) {
+	var reason sql.NullString
+	query := `SELECT reason FROM cancellations WHERE order_id = ? ORDER BY created_at DESC LIMIT 1`
+	err := db.DB.QueryRowContext(ctx, query, orderID).Scan(&reason)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return reason.String, err
+}
+
+func GetReviewByOrderID(ctx context.Context, orderID int64) (*models.Review, error) {
+	var review models.Review
+	var comment sql.NullString
+	query := `SELECT id, order_id, user_id, rating, comment, created_at FROM reviews WHERE order_id = ?`
+	err := db.DB.QueryRowContext(ctx, query, orderID).Scan(&review.ID, &review.OrderID, &review.UserID, &review.Rating, &comment, &review.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	review.Comment = comment.String
+	return &review, nil
 }
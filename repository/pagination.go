@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/AryaTabani/Dorivo/DB"
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+// sortColumns maps the sort fields a list accepts to the SQL expressions they order by. The expressions must not be
+// NULL, or rows would fall out of the keyset comparison.
+type sortColumns map[string]string
+
+// keyset is the SQL that narrows a list query down to one page: columns goes at the end of the SELECT list, condition
+// with its args into the WHERE clause, then orderBy and limit. Rows after the previous page are found by comparing their sort keys
+// with the ones in the cursor rather than by skipping rows, so pages stay stable while new rows are added.
+type keyset struct {
+	columns   string
+	condition string
+	args      []interface{}
+	orderBy   string
+	limit     string
+}
+
+func newKeyset(page *models.PageQuery, columns sortColumns, idColumn string) keyset {
+	type key struct {
+		expr string
+		desc bool
+	}
+	keys := make([]key, 0, len(page.Sort)+1)
+	for _, field := range page.Sort {
+		keys = append(keys, key{expr: columns[field.Field], desc: field.Desc})
+	}
+	keys = append(keys, key{expr: idColumn, desc: true})
+
+	var ks keyset
+	var order []string
+	for _, k := range keys {
+		ks.columns += ", " + k.expr
+		if k.desc {
+			order = append(order, k.expr+" DESC")
+		} else {
+			order = append(order, k.expr+" ASC")
+		}
+	}
+	ks.orderBy = " ORDER BY " + strings.Join(order, ", ")
+
+	ks.condition = "1 = 1"
+	if len(page.After) == len(keys) {
+		// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., with < for descending keys.
+		var alternatives []string
+		for i, k := range keys {
+			var parts []string
+			for j := 0; j < i; j++ {
+				parts = append(parts, keys[j].expr+" = ?")
+				ks.args = append(ks.args, page.After[j])
+			}
+			op := " > ?"
+			if k.desc {
+				op = " < ?"
+			}
+			parts = append(parts, k.expr+op)
+			ks.args = append(ks.args, page.After[i])
+			alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+		}
+		ks.condition = "(" + strings.Join(alternatives, " OR ") + ")"
+	}
+
+	if page.Limit > 0 {
+		ks.limit = fmt.Sprintf(" LIMIT %d", page.Limit+1)
+	}
+	return ks
+}
+
+// keyScanner scans the keyset columns that follow the columns of the row itself.
+type keyScanner struct {
+	row  rowScanner
+	keys []interface{}
+}
+
+func (s keyScanner) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.keys...)...)
+}
+
+// scanPage reads a page of rows queried with a keyset. One row more than the page size is asked for, so its presence
+// tells that there is a next page.
+func scanPage[T any](rows *sql.Rows, page *models.PageQuery, scan func(rowScanner) (*T, error)) (*models.Page[T], error) {
+	result := &models.Page[T]{Items: make([]T, 0), Pagination: models.Pagination{Limit: page.Limit}}
+	var keys [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(page.Sort)+1)
+		pointers := make([]interface{}, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		item, err := scan(keyScanner{row: rows, keys: pointers})
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, *item)
+		keys = append(keys, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if page.Limit > 0 && len(result.Items) > page.Limit {
+		result.Items = result.Items[:page.Limit]
+		result.Pagination.HasMore = true
+		result.NextKey = cursorValues(keys[page.Limit-1])
+	}
+	return result, nil
+}
+
+// cursorValues turns scanned sort keys into values that survive a round trip through a cursor and compare correctly
+// when sent back to MySQL.
+func cursorValues(values []interface{}) []interface{} {
+	for i, v := range values {
+		switch v := v.(type) {
+		case []byte:
+			values[i] = string(v)
+		case time.Time:
+			values[i] = v.UTC().Format("2006-01-02 15:04:05.999999")
+		}
+	}
+	return values
+}
+
+// countTotal runs the COUNT query that gives a list's total on its first page.
+func countTotal(ctx context.Context, query string, args ...interface{}) (*int, error) {
+	var total int
+	if err := db.DB.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+	return &total, nil
+}
@@ -2,19 +2,20 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	db "github.com/AryaTabani/Dorivo/DB"
 	"github.com/AryaTabani/Dorivo/models"
 )
 
-func CreatePaymentMethod(ctx context.Context, method *models.PaymentMethod) error {
-	query := `INSERT INTO payment_methods (user_id, processor_token, card_brand, last_four, expiry_month, expiry_year) VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := db.DB.ExecContext(ctx, query, method.UserID, method.ProcessorToken, method.CardBrand, method.LastFour, method.ExpiryMonth, method.ExpiryYear)
+func CreatePaymentMethod(ctx context.Context, tx *sql.Tx, method *models.PaymentMethod) error {
+	query := `INSERT INTO payment_methods (user_id, processor_token, card_brand, last_four, expiry_month, expiry_year, is_default) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	_, err := executor(tx).ExecContext(ctx, query, method.UserID, method.ProcessorToken, method.CardBrand, method.LastFour, method.ExpiryMonth, method.ExpiryYear, method.IsDefault)
 	return err
 }
 
 func GetPaymentMethodsByUserID(ctx context.Context, userID int64) ([]models.PaymentMethod, error) {
-	query := `SELECT id, card_brand, last_four, expiry_month, expiry_year FROM payment_methods WHERE user_id = ?`
+	query := `SELECT id, card_brand, last_four, expiry_month, expiry_year, is_default FROM payment_methods WHERE user_id = ? ORDER BY is_default DESC, id DESC`
 	rows, err := db.DB.QueryContext(ctx, query, userID)
 	if err != nil {
 		return nil, err
@@ -24,7 +25,7 @@ func GetPaymentMethodsByUserID(ctx context.Context, userID int64) ([]models.Paym
 	var methods []models.PaymentMethod
 	for rows.Next() {
 		var pm models.PaymentMethod
-		if err := rows.Scan(&pm.ID, &pm.CardBrand, &pm.LastFour, &pm.ExpiryMonth, &pm.ExpiryYear); err != nil {
+		if err := rows.Scan(&pm.ID, &pm.CardBrand, &pm.LastFour, &pm.ExpiryMonth, &pm.ExpiryYear, &pm.IsDefault); err != nil {
 			return nil, err
 		}
 		methods = append(methods, pm)
@@ -32,11 +33,147 @@ func GetPaymentMethodsByUserID(ctx context.Context, userID int64) ([]models.Paym
 	return methods, nil
 }
 
-func DeletePaymentMethod(ctx context.Context, userID, methodID int64) (int64, error) {
+// LockPaymentMethods serializes changes to a user's payment methods for the rest of the transaction. It locks the
+// user's row, since a user without payment methods has no payment method rows to lock.
+func LockPaymentMethods(ctx context.Context, tx *sql.Tx, userID int64) error {
+	var id int64
+	return tx.QueryRowContext(ctx, `SELECT id FROM users WHERE id = ? FOR UPDATE`, userID).Scan(&id)
+}
+
+func HasDefaultPaymentMethod(ctx context.Context, tx *sql.Tx, userID int64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM payment_methods WHERE user_id = ? AND is_default = 1)`
+	err := executor(tx).QueryRowContext(ctx, query, userID).Scan(&exists)
+	return exists, err
+}
+
+func DeletePaymentMethod(ctx context.Context, tx *sql.Tx, userID, methodID int64) (int64, error) {
 	query := `DELETE FROM payment_methods WHERE id = ? AND user_id = ?`
-	result, err := db.DB.ExecContext(ctx, query, methodID, userID)
+	result, err := executor(tx).ExecContext(ctx, query, methodID, userID)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
+
+func GetPaymentMethodByIDAndUserID(ctx context.Context, methodID, userID int64) (*models.PaymentMethod, error) {
+	var pm models.PaymentMethod
+	query := `SELECT id, user_id, processor_token, card_brand, last_four, expiry_month, expiry_year, is_default FROM payment_methods WHERE id = ? AND user_id = ?`
+	err := db.DB.QueryRowContext(ctx, query, methodID, userID).Scan(&pm.ID, &pm.UserID, &pm.ProcessorToken, &pm.CardBrand, &pm.LastFour, &pm.ExpiryMonth, &pm.ExpiryYear, &pm.IsDefault)
+	if err != nil {
+		return nil, err
+	}
+	return &pm, nil
+}
+
+func GetPaymentMethodForUpdate(ctx context.Context, tx *sql.Tx, methodID, userID int64) (*models.PaymentMethod, error) {
+	var pm models.PaymentMethod
+	query := `SELECT id, user_id, card_brand, last_four, expiry_month, expiry_year, is_default FROM payment_methods WHERE id = ? AND user_id = ? FOR UPDATE`
+	err := tx.QueryRowContext(ctx, query, methodID, userID).Scan(&pm.ID, &pm.UserID, &pm.CardBrand, &pm.LastFour, &pm.ExpiryMonth, &pm.ExpiryYear, &pm.IsDefault)
+	if err != nil {
+		return nil, err
+	}
+	return &pm, nil
+}
+
+// SetDefaultPaymentMethod flips the default of all the user's cards in one statement so exactly one stays default.
+func SetDefaultPaymentMethod(ctx context.Context, tx *sql.Tx, userID, methodID int64) error {
+	query := `UPDATE payment_methods SET is_default = (id = ?) WHERE user_id = ?`
+	_, err := tx.ExecContext(ctx, query, methodID, userID)
+	return err
+}
+
+// PromoteDefaultPaymentMethod makes the newest card of the user the default, preferring cards that have not expired
+// before the given month.
+func PromoteDefaultPaymentMethod(ctx context.Context, tx *sql.Tx, userID int64, year, month int) error {
+	query := `
+		UPDATE payment_methods SET is_default = 1
+		WHERE user_id = ?
+		ORDER BY (expiry_year * 12 + expiry_month) < ?, id DESC
+		LIMIT 1
+	`
+	_, err := tx.ExecContext(ctx, query, userID, year*12+month)
+	return err
+}
+
+// GetPaymentMethodsExpiringIn returns the cards that expire in the given month and whose owner has not been reminded yet.
+func GetPaymentMethodsExpiringIn(ctx context.Context, year, month int) ([]models.PaymentMethod, error) {
+	query := `
+		SELECT id, user_id, card_brand, last_four, expiry_month, expiry_year, is_default
+		FROM payment_methods
+		WHERE expiry_year = ? AND expiry_month = ? AND expiry_reminder_sent = 0
+	`
+	rows, err := db.DB.QueryContext(ctx, query, year, month)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var methods []models.PaymentMethod
+	for rows.Next() {
+		var pm models.PaymentMethod
+		if err := rows.Scan(&pm.ID, &pm.UserID, &pm.CardBrand, &pm.LastFour, &pm.ExpiryMonth, &pm.ExpiryYear, &pm.IsDefault); err != nil {
+			return nil, err
+		}
+		methods = append(methods, pm)
+	}
+	return methods, rows.Err()
+}
+
+func MarkExpiryReminderSent(ctx context.Context, methodID int64) error {
+	query := `UPDATE payment_methods SET expiry_reminder_sent = 1 WHERE id = ?`
+	_, err := db.DB.ExecContext(ctx, query, methodID)
+	return err
+}
+
+// CreatePaymentTransaction appends an entry to the payments ledger, inside tx or, when tx is nil, straight on the pool.
+func CreatePaymentTransaction(ctx context.Context, tx *sql.Tx, payment *models.PaymentTransaction) (int64, error) {
+	var reference, failureReason sql.NullString
+	if payment.GatewayReference != "" {
+		reference = sql.NullString{String: payment.GatewayReference, Valid: true}
+	}
+	if payment.FailureReason != "" {
+		failureReason = sql.NullString{String: payment.FailureReason, Valid: true}
+	}
+	query := `
+		INSERT INTO payments (order_id, user_id, payment_method_id, operation, status, amount, gateway_reference, failure_reason)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := executor(tx).ExecContext(ctx, query, payment.OrderID, payment.UserID, payment.PaymentMethodID, payment.Operation,
+		payment.Status, payment.Amount, reference, failureReason)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func GetPaymentsByOrderID(ctx context.Context, tx *sql.Tx, orderID int64) ([]models.PaymentTransaction, error) {
+	query := `
+		SELECT id, order_id, user_id, payment_method_id, operation, status, amount, COALESCE(gateway_reference, ''), COALESCE(failure_reason, ''), created_at
+		FROM payments
+		WHERE order_id = ?
+		ORDER BY id
+	`
+	rows, err := executor(tx).QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := make([]models.PaymentTransaction, 0)
+	for rows.Next() {
+		var p models.PaymentTransaction
+		var orderIDValue, methodID sql.NullInt64
+		if err := rows.Scan(&p.ID, &orderIDValue, &p.UserID, &methodID, &p.Operation, &p.Status, &p.Amount, &p.GatewayReference, &p.FailureReason, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		if orderIDValue.Valid {
+			p.OrderID = &orderIDValue.Int64
+		}
+		if methodID.Valid {
+			p.PaymentMethodID = &methodID.Int64
+		}
+		payments = append(payments, p)
+	}
+	return payments, rows.Err()
+}
@@ -10,81 +10,139 @@ import (
 	"github.com/AryaTabani/Dorivo/models"
 )
 
-func SearchProducts(ctx context.Context, tenantID string, filters map[string][]string) ([]models.Product, error) {
-	var args []interface{}
-	var whereClauses []string
+// productSortColumns are the sort fields of product lists. Products have no creation time, so newest goes by ID.
+var productSortColumns = sortColumns{
+	"price":      effectivePrice,
+	"rating":     "COALESCE(p.rating, 0)",
+	"newest":     "p.id",
+	"name":       "p.name",
+	"popularity": "(SELECT COALESCE(SUM(oi.quantity), 0) FROM order_items oi WHERE oi.product_id = p.id)",
+}
 
-	baseQuery := `
-		SELECT p.id, p.name, p.description, p.price, p.rating, p.image_url, p.main_category, GROUP_CONCAT(t.name) as tags
-		FROM products p
-		LEFT JOIN product_tags pt ON p.id = pt.product_id
-		LEFT JOIN tags t ON pt.tag_id = t.id
-	`
-	whereClauses = append(whereClauses, "p.tenant_id = ?")
-	args = append(args, tenantID)
+const inStockCondition = "p.is_available = 1 AND (p.stock_quantity IS NULL OR p.stock_quantity > 0)"
+
+// visibleCategoryIDs selects the categories of a tenant, given as its one argument, that are visible themselves and
+// through all their parents. Products of other categories are left out of the public product lists.
+const visibleCategoryIDs = `WITH RECURSIVE visible AS (
+		SELECT id FROM categories WHERE tenant_id = ? AND parent_id IS NULL AND is_visible = 1
+		UNION ALL
+		SELECT c.id FROM categories c JOIN visible v ON c.parent_id = v.id WHERE c.is_visible = 1
+	) SELECT id FROM visible`
 
+// categorySubtreeIDs selects a category, given by tenant and name, together with every category below it.
+const categorySubtreeIDs = `WITH RECURSIVE subtree AS (
+		SELECT id FROM categories WHERE tenant_id = ? AND name = ?
+		UNION ALL
+		SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+	) SELECT id FROM subtree`
+
+// productFilterCondition renders the filters of a product search as one WHERE condition on products p. A non-nil ids
+// limits the products to those, which is how keyword matches worked out in memory are applied; filters named in skip
+// are left out, so a facet can be counted as if its own choice had not been made yet.
+func productFilterCondition(tenantID string, filters map[string][]string, ids []int64, skip ...string) (string, []interface{}) {
+	conditions := []string{"p.tenant_id = ?", "p.category_id IN (" + visibleCategoryIDs + ")"}
+	args := []interface{}{tenantID, tenantID}
+
+	skipped := make(map[string]bool, len(skip))
+	for _, key := range skip {
+		skipped[key] = true
+	}
 	for key, values := range filters {
-		if len(values) == 0 {
+		if len(values) == 0 || skipped[key] {
 			continue
 		}
 		switch key {
-		case "category":
-			whereClauses = append(whereClauses, "p.main_category = ?")
+		case "q":
+			conditions = append(conditions, "MATCH(p.search_text) AGAINST (? IN NATURAL LANGUAGE MODE)")
 			args = append(args, values[0])
+		case "category":
+			// A category includes its subcategories.
+			conditions = append(conditions, "p.category_id IN ("+categorySubtreeIDs+")")
+			args = append(args, tenantID, values[0])
 		case "min_price":
-			whereClauses = append(whereClauses, "p.price >= ?")
+			conditions = append(conditions, "p.price >= ?")
 			args = append(args, values[0])
 		case "max_price":
-			whereClauses = append(whereClauses, "p.price <= ?")
+			conditions = append(conditions, "p.price <= ?")
+			args = append(args, values[0])
+		case "min_rating":
+			conditions = append(conditions, "COALESCE(p.rating, 0) >= ?")
 			args = append(args, values[0])
+		case "available":
+			switch values[0] {
+			case "true":
+				conditions = append(conditions, inStockCondition)
+			case "false":
+				conditions = append(conditions, "NOT ("+inStockCondition+")")
+			}
+		case "tags":
+			// Products must carry every one of the tags.
+			conditions = append(conditions, fmt.Sprintf(`(SELECT COUNT(DISTINCT t.name) FROM product_tags pt JOIN tags t ON pt.tag_id = t.id
+				WHERE pt.product_id = p.id AND t.name IN (?%s)) = ?`, strings.Repeat(",?", len(values)-1)))
+			for _, tag := range values {
+				args = append(args, tag)
+			}
+			args = append(args, len(values))
 		}
 	}
 
-	query := baseQuery
-	if len(whereClauses) > 0 {
-		query += " WHERE " + strings.Join(whereClauses, " AND ")
-	}
-
-	query += " GROUP BY p.id"
-
-	if tagValues, ok := filters["tags"]; ok && len(tagValues) > 0 {
-		query += fmt.Sprintf(" HAVING SUM(CASE WHEN t.name IN (?%s) THEN 1 ELSE 0 END) = ?", strings.Repeat(",?", len(tagValues)-1))
-		for _, tag := range tagValues {
-			args = append(args, tag)
+	if ids != nil {
+		if len(ids) == 0 {
+			conditions = append(conditions, "1 = 0")
+		} else {
+			conditions = append(conditions, "p.id IN (?"+strings.Repeat(",?", len(ids)-1)+")")
+			for _, id := range ids {
+				args = append(args, id)
+			}
 		}
-		args = append(args, len(tagValues))
 	}
+	return strings.Join(conditions, " AND "), args
+}
 
-	if sortBy, ok := filters["sort_by"]; ok && len(sortBy) > 0 {
-		if sortBy[0] == "rating_desc" {
-			query += " ORDER BY p.rating DESC"
-		}
-	}
+func SearchProducts(ctx context.Context, tenantID string, filters map[string][]string, page *models.PageQuery) (*models.Page[models.Product], error) {
+	condition, args := productFilterCondition(tenantID, filters, nil)
 
-	rows, err := db.DB.QueryContext(ctx, query, args...)
+	ks := newKeyset(page, productSortColumns, "p.id")
+	query := `
+		SELECT p.id, p.name, p.description, p.price, p.rating, p.image_url, p.main_category, p.is_available, p.stock_quantity,
+			(SELECT GROUP_CONCAT(t.name) FROM product_tags pt JOIN tags t ON pt.tag_id = t.id WHERE pt.product_id = p.id) AS tags` + ks.columns + `
+		FROM products p
+		WHERE ` + condition + ` AND ` + ks.condition + ks.orderBy + ks.limit
+
+	rows, err := db.DB.QueryContext(ctx, query, append(append([]interface{}{}, args...), ks.args...)...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var products []models.Product
-	for rows.Next() {
+	result, err := scanPage(rows, page, func(row rowScanner) (*models.Product, error) {
 		var p models.Product
 		var tags sql.NullString
-		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &tags); err != nil {
+		var stock sql.NullInt64
+		if err := row.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.IsAvailable, &stock, &tags); err != nil {
 			return nil, err
 		}
+		p.StockQuantity = stockQuantity(stock)
 		if tags.Valid {
 			p.Tags = strings.Split(tags.String, ",")
 		}
-		products = append(products, p)
+		return &p, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return products, nil
+	if page.WithTotal {
+		result.Pagination.Total, err = countTotal(ctx, `SELECT COUNT(*) FROM products p WHERE `+condition, args...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
 }
 
 func GetTags(ctx context.Context, tenantID string) ([]models.Tag, error) {
-	query := "SELECT id, name, main_category FROM tags WHERE tenant_id = ?"
+	query := "SELECT " + tagColumns + " FROM tags WHERE tenant_id = ?"
 	rows, err := db.DB.QueryContext(ctx, query, tenantID)
 	if err != nil {
 		return nil, err
@@ -93,74 +151,43 @@ func GetTags(ctx context.Context, tenantID string) ([]models.Tag, error) {
 
 	var tags []models.Tag
 	for rows.Next() {
-		var t models.Tag
-		if err := rows.Scan(&t.ID, &t.Name, &t.MainCategory); err != nil {
+		t, err := scanTag(rows)
+		if err != nil {
 			return nil, err
 		}
-		tags = append(tags, t)
+		tags = append(tags, *t)
 	}
 	return tags, nil
 }
 
 func GetProductDetails(ctx context.Context, tenantID string, productID int64) (*models.Product, error) {
 	var p models.Product
-	productQuery := `SELECT id, name, description, price, rating, image_url, main_category FROM products WHERE id = ? AND tenant_id = ?`
-	err := db.DB.QueryRowContext(ctx, productQuery, productID, tenantID).Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory)
+	var stock sql.NullInt64
+	productQuery := `SELECT id, name, description, price, rating, image_url, main_category, is_available, stock_quantity FROM products WHERE id = ? AND tenant_id = ?`
+	err := db.DB.QueryRowContext(ctx, productQuery, productID, tenantID).Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.IsAvailable, &stock)
 	if err != nil {
 		return nil, err
 	}
+	p.StockQuantity = stockQuantity(stock)
 
-	optionsQuery := `
-		SELECT og.id, og.name, og.selection_type, o.id, o.name, o.price_modifier
-		FROM option_groups og
-		JOIN options o ON og.id = o.option_group_id
-		WHERE og.product_id = ?
-		ORDER BY og.id, o.id
-	`
-	rows, err := db.DB.QueryContext(ctx, optionsQuery, productID)
+	p.OptionGroups, err = GetOptionGroupsByProductID(ctx, productID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	optionGroupsMap := make(map[int64]*models.OptionGroup)
-	for rows.Next() {
-		var ogID int64
-		var ogName, ogSelectionType string
-		var opt models.Option
-		if err := rows.Scan(&ogID, &ogName, &ogSelectionType, &opt.ID, &opt.Name, &opt.PriceModifier); err != nil {
-			return nil, err
-		}
-
-		if _, ok := optionGroupsMap[ogID]; !ok {
-			optionGroupsMap[ogID] = &models.OptionGroup{
-				ID:            ogID,
-				Name:          ogName,
-				SelectionType: ogSelectionType,
-				Options:       make([]models.Option, 0),
-			}
-		}
-		optionGroupsMap[ogID].Options = append(optionGroupsMap[ogID].Options, opt)
-	}
-
-	p.OptionGroups = make([]models.OptionGroup, 0, len(optionGroupsMap))
-	for _, group := range optionGroupsMap {
-		p.OptionGroups = append(p.OptionGroups, *group)
-	}
 
 	return &p, nil
 }
 func GetBestSellers(ctx context.Context, tenantID string, limit int) ([]models.Product, error) {
 	query := `
-		SELECT p.id, p.name, p.description, p.price, p.rating, p.image_url, p.main_category, p.discount_price, p.is_featured, p.is_recommended
+		SELECT p.id, p.name, p.description, p.price, p.rating, p.image_url, p.main_category, p.discount_price, p.is_featured, p.is_recommended, p.is_available, p.stock_quantity
 		FROM products p
 		JOIN order_items oi ON p.id = oi.product_id
-		WHERE p.tenant_id = ?
+		WHERE p.tenant_id = ? AND p.category_id IN (` + visibleCategoryIDs + `)
 		GROUP BY p.id
 		ORDER BY SUM(oi.quantity) DESC
 		LIMIT ?
 	`
-	rows, err := db.DB.QueryContext(ctx, query, tenantID, limit)
+	rows, err := db.DB.QueryContext(ctx, query, tenantID, tenantID, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -169,9 +196,11 @@ func GetBestSellers(ctx context.Context, tenantID string, limit int) ([]models.P
 	var products []models.Product
 	for rows.Next() {
 		var p models.Product
-		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.DiscountPrice, &p.IsFeatured, &p.IsRecommended); err != nil {
+		var stock sql.NullInt64
+		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.DiscountPrice, &p.IsFeatured, &p.IsRecommended, &p.IsAvailable, &stock); err != nil {
 			return nil, err
 		}
+		p.StockQuantity = stockQuantity(stock)
 		products = append(products, p)
 	}
 	return products, nil
@@ -179,17 +208,19 @@ func GetBestSellers(ctx context.Context, tenantID string, limit int) ([]models.P
 
 func GetFeaturedProduct(ctx context.Context, tenantID string) (*models.Product, error) {
 	var p models.Product
-	query := `SELECT id, name, description, price, rating, image_url, main_category, discount_price, is_featured, is_recommended FROM products WHERE tenant_id = ? AND is_featured = TRUE LIMIT 1`
-	err := db.DB.QueryRowContext(ctx, query, tenantID).Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.DiscountPrice, &p.IsFeatured, &p.IsRecommended)
+	query := `SELECT id, name, description, price, rating, image_url, main_category, discount_price, is_featured, is_recommended, is_available, stock_quantity FROM products WHERE tenant_id = ? AND is_featured = TRUE AND category_id IN (` + visibleCategoryIDs + `) LIMIT 1`
+	var stock sql.NullInt64
+	err := db.DB.QueryRowContext(ctx, query, tenantID, tenantID).Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.DiscountPrice, &p.IsFeatured, &p.IsRecommended, &p.IsAvailable, &stock)
 	if err != nil {
 		return nil, err
 	}
+	p.StockQuantity = stockQuantity(stock)
 	return &p, nil
 }
 
 func GetRecommendedProducts(ctx context.Context, tenantID string) ([]models.Product, error) {
-	query := `SELECT id, name, description, price, rating, image_url, main_category, discount_price, is_featured, is_recommended FROM products WHERE tenant_id = ? AND is_recommended = TRUE`
-	rows, err := db.DB.QueryContext(ctx, query, tenantID)
+	query := `SELECT id, name, description, price, rating, image_url, main_category, discount_price, is_featured, is_recommended, is_available, stock_quantity FROM products WHERE tenant_id = ? AND is_recommended = TRUE AND category_id IN (` + visibleCategoryIDs + `)`
+	rows, err := db.DB.QueryContext(ctx, query, tenantID, tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -198,30 +229,170 @@ func GetRecommendedProducts(ctx context.Context, tenantID string) ([]models.Prod
 	var products []models.Product
 	for rows.Next() {
 		var p models.Product
-		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.DiscountPrice, &p.IsFeatured, &p.IsRecommended); err != nil {
+		var stock sql.NullInt64
+		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.Price, &p.Rating, &p.ImageURL, &p.MainCategory, &p.DiscountPrice, &p.IsFeatured, &p.IsRecommended, &p.IsAvailable, &stock); err != nil {
 			return nil, err
 		}
+		p.StockQuantity = stockQuantity(stock)
 		products = append(products, p)
 	}
 	return products, nil
 }
-func CreateProduct(ctx context.Context, tenantID string, payload *models.ProductPayload) (int64, error) {
-	query := `INSERT INTO products (tenant_id, name, description, price, image_url, main_category, discount_price, is_featured, is_recommended) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	res, err := db.DB.ExecContext(ctx, query, tenantID, payload.Name, payload.Description, payload.Price, payload.ImageURL, payload.MainCategory, payload.DiscountPrice, payload.IsFeatured, payload.IsRecommended)
+func CreateProduct(ctx context.Context, tx *sql.Tx, tenantID string, payload *models.ProductPayload) (int64, error) {
+	query := `INSERT INTO products (tenant_id, name, description, price, image_url, category_id, main_category, discount_price, is_featured, is_recommended) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := executor(tx).ExecContext(ctx, query, tenantID, payload.Name, payload.Description, payload.Price, payload.ImageURL, payload.CategoryID, payload.MainCategory, payload.DiscountPrice, payload.IsFeatured, payload.IsRecommended)
 	if err != nil {
 		return 0, err
 	}
 	return res.LastInsertId()
 }
 
-func UpdateProduct(ctx context.Context, tenantID string, productID int64, payload *models.ProductPayload) error {
-	query := `UPDATE products SET name=?, description=?, price=?, image_url=?, main_category=?, discount_price=?, is_featured=?, is_recommended=? WHERE id=? AND tenant_id=?`
-	_, err := db.DB.ExecContext(ctx, query, payload.Name, payload.Description, payload.Price, payload.ImageURL, payload.MainCategory, payload.DiscountPrice, payload.IsFeatured, payload.IsRecommended, productID, tenantID)
+func UpdateProduct(ctx context.Context, tx *sql.Tx, tenantID string, productID int64, payload *models.ProductPayload) error {
+	query := `UPDATE products SET name=?, description=?, price=?, image_url=?, category_id=?, main_category=?, discount_price=?, is_featured=?, is_recommended=? WHERE id=? AND tenant_id=?`
+	_, err := executor(tx).ExecContext(ctx, query, payload.Name, payload.Description, payload.Price, payload.ImageURL, payload.CategoryID, payload.MainCategory, payload.DiscountPrice, payload.IsFeatured, payload.IsRecommended, productID, tenantID)
 	return err
 }
 
+// RefreshProductSearchText rebuilds the text keyword search runs on from the products' names, descriptions and tags.
+// It is folded the same way as search queries: lower-cased, with the Arabic yeh and kaf replaced by their Persian
+// forms.
+func RefreshProductSearchText(ctx context.Context, tx *sql.Tx, productIDs ...int64) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(productIDs)), ",")
+	query := `
+		UPDATE products p
+		SET p.search_text = REPLACE(REPLACE(REPLACE(LOWER(CONCAT_WS(' ', p.name, p.description,
+			(SELECT GROUP_CONCAT(t.name SEPARATOR ' ') FROM product_tags pt JOIN tags t ON pt.tag_id = t.id WHERE pt.product_id = p.id))),
+			'ي', 'ی'), 'ى', 'ی'), 'ك', 'ک')
+		WHERE p.id IN (` + placeholders + `)
+	`
+	args := make([]interface{}, len(productIDs))
+	for i, id := range productIDs {
+		args[i] = id
+	}
+	_, err := executor(tx).ExecContext(ctx, query, args...)
+	return err
+}
+
+func ProductExists(ctx context.Context, tenantID string, productID int64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM products WHERE id = ? AND tenant_id = ?)`
+	err := db.DB.QueryRowContext(ctx, query, productID, tenantID).Scan(&exists)
+	return exists, err
+}
+
 func DeleteProduct(ctx context.Context, tenantID string, productID int64) error {
 	query := `DELETE FROM products WHERE id=? AND tenant_id=?`
 	_, err := db.DB.ExecContext(ctx, query, productID, tenantID)
 	return err
 }
+
+// priceRangeBuckets is how many price ranges the price facet aims for.
+const priceRangeBuckets = 5
+
+// GetProductFacets counts the products matching a search per category, tag, price range, rating and availability.
+// ids limits the products like it does for productFilterCondition.
+func GetProductFacets(ctx context.Context, tenantID string, filters map[string][]string, ids []int64) (*models.ProductFacets, error) {
+	facets := &models.ProductFacets{
+		Categories:  make([]models.FacetCount, 0),
+		Tags:        make([]models.FacetCount, 0),
+		PriceRanges: make([]models.PriceRangeCount, 0),
+		Ratings:     make([]models.RatingCount, 0),
+	}
+
+	condition, args := productFilterCondition(tenantID, filters, ids, "category")
+	query := `SELECT p.main_category, COUNT(*) FROM products p WHERE ` + condition + ` GROUP BY p.main_category ORDER BY COUNT(*) DESC, p.main_category`
+	if err := scanFacetCounts(ctx, &facets.Categories, query, args); err != nil {
+		return nil, err
+	}
+
+	condition, args = productFilterCondition(tenantID, filters, ids)
+	query = `
+		SELECT t.name, COUNT(DISTINCT p.id)
+		FROM products p
+		JOIN product_tags pt ON pt.product_id = p.id
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE ` + condition + ` GROUP BY t.name ORDER BY COUNT(DISTINCT p.id) DESC, t.name`
+	if err := scanFacetCounts(ctx, &facets.Tags, query, args); err != nil {
+		return nil, err
+	}
+
+	condition, args = productFilterCondition(tenantID, filters, ids, "min_rating")
+	query = `SELECT COALESCE(SUM(COALESCE(p.rating, 0) >= 4), 0), COALESCE(SUM(COALESCE(p.rating, 0) >= 3), 0),
+		COALESCE(SUM(COALESCE(p.rating, 0) >= 2), 0), COALESCE(SUM(COALESCE(p.rating, 0) >= 1), 0)
+		FROM products p WHERE ` + condition
+	var ratings [4]int
+	if err := db.DB.QueryRowContext(ctx, query, args...).Scan(&ratings[0], &ratings[1], &ratings[2], &ratings[3]); err != nil {
+		return nil, err
+	}
+	for i, count := range ratings {
+		if count > 0 {
+			facets.Ratings = append(facets.Ratings, models.RatingCount{MinRating: 4 - i, Count: count})
+		}
+	}
+
+	condition, args = productFilterCondition(tenantID, filters, ids, "available")
+	query = `SELECT COALESCE(SUM(` + inStockCondition + `), 0), COALESCE(SUM(NOT (` + inStockCondition + `)), 0) FROM products p WHERE ` + condition
+	if err := db.DB.QueryRowContext(ctx, query, args...).Scan(&facets.Availability.Available, &facets.Availability.Unavailable); err != nil {
+		return nil, err
+	}
+
+	condition, args = productFilterCondition(tenantID, filters, ids, "min_price", "max_price")
+	var lowest, highest sql.NullInt64
+	query = `SELECT MIN(p.price), MAX(p.price) FROM products p WHERE ` + condition
+	if err := db.DB.QueryRowContext(ctx, query, args...).Scan(&lowest, &highest); err != nil {
+		return nil, err
+	}
+	if !lowest.Valid {
+		return facets, nil
+	}
+	step := priceStep(highest.Int64 - lowest.Int64)
+	start := lowest.Int64 - lowest.Int64%step
+	query = `SELECT (p.price - ?) DIV ?, COUNT(*) FROM products p WHERE ` + condition + ` GROUP BY 1 ORDER BY 1`
+	rows, err := db.DB.QueryContext(ctx, query, append([]interface{}{start, step}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bucket int64
+		var count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, err
+		}
+		from := models.Money(start + bucket*step)
+		facets.PriceRanges = append(facets.PriceRanges, models.PriceRangeCount{Min: from, Max: from + models.Money(step) - 1, Count: count})
+	}
+	return facets, rows.Err()
+}
+
+// priceStep picks a round width, 1, 2 or 5 times a power of ten minor units, that splits a price spread into about
+// priceRangeBuckets ranges.
+func priceStep(spread int64) int64 {
+	target := spread/priceRangeBuckets + 1
+	for magnitude := int64(1); ; magnitude *= 10 {
+		for _, factor := range []int64{1, 2, 5} {
+			if step := factor * magnitude; step >= target {
+				return step
+			}
+		}
+	}
+}
+
+func scanFacetCounts(ctx context.Context, counts *[]models.FacetCount, query string, args []interface{}) error {
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var facet models.FacetCount
+		if err := rows.Scan(&facet.Value, &facet.Count); err != nil {
+			return err
+		}
+		*counts = append(*counts, facet)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	db "github.com/AryaTabani/Dorivo/DB"
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+const promotionColumns = `p.id, p.tenant_id, p.code, COALESCE(p.description, ''), p.type, p.value, p.amount, p.free_product_id, p.min_basket,
+	p.max_uses, p.max_uses_per_user, p.starts_at, p.ends_at, p.categories, p.tags, p.is_active,
+	(SELECT COUNT(*) FROM promotion_redemptions pr WHERE pr.promotion_id = p.id)`
+
+func scanPromotion(row rowScanner) (*models.Promotion, error) {
+	var p models.Promotion
+	var freeProductID sql.NullInt64
+	var minBasket sql.NullInt64
+	var maxUses, maxUsesPerUser sql.NullInt64
+	var startsAt, endsAt sql.NullTime
+	var categoriesJSON, tagsJSON sql.NullString
+
+	err := row.Scan(&p.ID, &p.TenantID, &p.Code, &p.Description, &p.Type, &p.Value, &p.Amount, &freeProductID, &minBasket,
+		&maxUses, &maxUsesPerUser, &startsAt, &endsAt, &categoriesJSON, &tagsJSON, &p.IsActive, &p.UsageCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if freeProductID.Valid {
+		p.FreeProductID = &freeProductID.Int64
+	}
+	if minBasket.Valid {
+		basket := models.Money(minBasket.Int64)
+		p.MinBasket = &basket
+	}
+	if maxUses.Valid {
+		v := int(maxUses.Int64)
+		p.MaxUses = &v
+	}
+	if maxUsesPerUser.Valid {
+		v := int(maxUsesPerUser.Int64)
+		p.MaxUsesPerUser = &v
+	}
+	if startsAt.Valid {
+		p.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		p.EndsAt = &endsAt.Time
+	}
+	p.Categories = make([]string, 0)
+	if categoriesJSON.Valid {
+		if err := json.Unmarshal([]byte(categoriesJSON.String), &p.Categories); err != nil {
+			return nil, err
+		}
+	}
+	p.Tags = make([]string, 0)
+	if tagsJSON.Valid {
+		if err := json.Unmarshal([]byte(tagsJSON.String), &p.Tags); err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
+func promotionRestrictionsJSON(payload *models.PromotionPayload) (string, string, error) {
+	categories := payload.Categories
+	if categories == nil {
+		categories = []string{}
+	}
+	tags := payload.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	categoriesJSON, err := json.Marshal(categories)
+	if err != nil {
+		return "", "", err
+	}
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return "", "", err
+	}
+	return string(categoriesJSON), string(tagsJSON), nil
+}
+
+func CreatePromotion(ctx context.Context, tenantID string, payload *models.PromotionPayload) (int64, error) {
+	categoriesJSON, tagsJSON, err := promotionRestrictionsJSON(payload)
+	if err != nil {
+		return 0, err
+	}
+	query := `
+		INSERT INTO promotions (tenant_id, code, description, type, value, amount, free_product_id, min_basket, max_uses, max_uses_per_user, starts_at, ends_at, categories, tags, is_active)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := db.DB.ExecContext(ctx, query, tenantID, payload.Code, payload.Description, payload.Type, payload.Value, payload.Amount, payload.FreeProductID,
+		payload.MinBasket, payload.MaxUses, payload.MaxUsesPerUser, payload.StartsAt, payload.EndsAt, categoriesJSON, tagsJSON, payload.IsActive)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func UpdatePromotion(ctx context.Context, tenantID string, promotionID int64, payload *models.PromotionPayload) (int64, error) {
+	categoriesJSON, tagsJSON, err := promotionRestrictionsJSON(payload)
+	if err != nil {
+		return 0, err
+	}
+	query := `
+		UPDATE promotions SET code = ?, description = ?, type = ?, value = ?, amount = ?, free_product_id = ?, min_basket = ?, max_uses = ?,
+			max_uses_per_user = ?, starts_at = ?, ends_at = ?, categories = ?, tags = ?, is_active = ?
+		WHERE id = ? AND tenant_id = ?
+	`
+	res, err := db.DB.ExecContext(ctx, query, payload.Code, payload.Description, payload.Type, payload.Value, payload.Amount, payload.FreeProductID, payload.MinBasket,
+		payload.MaxUses, payload.MaxUsesPerUser, payload.StartsAt, payload.EndsAt, categoriesJSON, tagsJSON, payload.IsActive, promotionID, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func DeletePromotion(ctx context.Context, tenantID string, promotionID int64) (int64, error) {
+	query := `DELETE FROM promotions WHERE id = ? AND tenant_id = ?`
+	res, err := db.DB.ExecContext(ctx, query, promotionID, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func GetPromotionsByTenant(ctx context.Context, tenantID string) ([]models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions p WHERE p.tenant_id = ? ORDER BY p.id DESC`
+	rows, err := db.DB.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promotions []models.Promotion
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, *p)
+	}
+	return promotions, rows.Err()
+}
+
+func GetPromotionByID(ctx context.Context, tx *sql.Tx, promotionID int64) (*models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions p WHERE p.id = ?`
+	return scanPromotion(executor(tx).QueryRowContext(ctx, query, promotionID))
+}
+
+func GetPromotionByCode(ctx context.Context, tenantID string, code string) (*models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions p WHERE p.tenant_id = ? AND p.code = ?`
+	return scanPromotion(db.DB.QueryRowContext(ctx, query, tenantID, code))
+}
+
+// GetPromotionForUpdate locks the promotion row so concurrent checkouts cannot both take the last redemption.
+func GetPromotionForUpdate(ctx context.Context, tx *sql.Tx, promotionID int64) (*models.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions p WHERE p.id = ? FOR UPDATE`
+	return scanPromotion(tx.QueryRowContext(ctx, query, promotionID))
+}
+
+func CountUserRedemptions(ctx context.Context, tx *sql.Tx, promotionID, userID int64) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = ? AND user_id = ?`
+	err := executor(tx).QueryRowContext(ctx, query, promotionID, userID).Scan(&count)
+	return count, err
+}
+
+func CreatePromotionRedemption(ctx context.Context, tx *sql.Tx, redemption *models.PromotionRedemption) error {
+	query := `INSERT INTO promotion_redemptions (promotion_id, user_id, order_id, code, discount_amount) VALUES (?, ?, ?, ?, ?)`
+	_, err := tx.ExecContext(ctx, query, redemption.PromotionID, redemption.UserID, redemption.OrderID, redemption.Code, redemption.DiscountAmount)
+	return err
+}
+
+func GetPromotionRedemptions(ctx context.Context, tenantID string, promotionID int64) ([]models.PromotionRedemption, error) {
+	query := `
+		SELECT pr.id, pr.promotion_id, pr.user_id, pr.order_id, pr.code, pr.discount_amount, pr.created_at
+		FROM promotion_redemptions pr
+		JOIN promotions p ON pr.promotion_id = p.id
+		WHERE pr.promotion_id = ? AND p.tenant_id = ?
+		ORDER BY pr.created_at DESC
+	`
+	rows, err := db.DB.QueryContext(ctx, query, promotionID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var redemptions []models.PromotionRedemption
+	for rows.Next() {
+		var r models.PromotionRedemption
+		if err := rows.Scan(&r.ID, &r.PromotionID, &r.UserID, &r.OrderID, &r.Code, &r.DiscountAmount, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		redemptions = append(redemptions, r)
+	}
+	return redemptions, rows.Err()
+}
+
+// promotionsRestrictedTo lists the codes of the promotions whose categories or tags, as given by column, include name.
+func promotionsRestrictedTo(ctx context.Context, tx *sql.Tx, tenantID, column, name string) ([]string, error) {
+	var codes []string
+	err := eachPromotionRestriction(ctx, tx, tenantID, column, false, func(_ int64, code string, names []string) error {
+		for _, n := range names {
+			if strings.EqualFold(n, name) {
+				codes = append(codes, code)
+				break
+			}
+		}
+		return nil
+	})
+	return codes, err
+}
+
+// renamePromotionRestriction replaces a name in the categories or tags, as given by column, of a tenant's promotions.
+// A promotion that already listed the new name keeps it once.
+func renamePromotionRestriction(ctx context.Context, tx *sql.Tx, tenantID, column, from, to string) error {
+	type change struct {
+		id    int64
+		names string
+	}
+	var changes []change
+	err := eachPromotionRestriction(ctx, tx, tenantID, column, true, func(id int64, _ string, names []string) error {
+		renamed := make([]string, 0, len(names))
+		changed := false
+		for _, name := range names {
+			if strings.EqualFold(name, from) {
+				name, changed = to, true
+			}
+			duplicate := false
+			for _, kept := range renamed {
+				if strings.EqualFold(kept, name) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				renamed = append(renamed, name)
+			}
+		}
+		if !changed {
+			return nil
+		}
+		namesJSON, err := json.Marshal(renamed)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, change{id: id, names: string(namesJSON)})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, c := range changes {
+		if _, err := tx.ExecContext(ctx, `UPDATE promotions SET `+column+` = ? WHERE id = ?`, c.names, c.id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eachPromotionRestriction calls fn with the categories or tags, as given by column, of every promotion of a tenant
+// that is restricted to any, locking the rows when forUpdate is set.
+func eachPromotionRestriction(ctx context.Context, tx *sql.Tx, tenantID, column string, forUpdate bool, fn func(id int64, code string, names []string) error) error {
+	query := `SELECT id, code, ` + column + ` FROM promotions WHERE tenant_id = ? AND ` + column + ` IS NOT NULL AND JSON_LENGTH(` + column + `) > 0`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	rows, err := executor(tx).QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var code, namesJSON string
+		if err := rows.Scan(&id, &code, &namesJSON); err != nil {
+			return err
+		}
+		var names []string
+		if err := json.Unmarshal([]byte(namesJSON), &names); err != nil {
+			return err
+		}
+		if err := fn(id, code, names); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+func CreateRefund(ctx context.Context, tx *sql.Tx, refund *models.Refund) (int64, error) {
+	var quantity sql.NullInt64
+	if refund.OrderItemID != nil {
+		quantity = sql.NullInt64{Int64: int64(refund.Quantity), Valid: true}
+	}
+	query := `
+		INSERT INTO refunds (order_id, order_item_id, quantity, amount, reason, refunded_by, payment_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	res, err := tx.ExecContext(ctx, query, refund.OrderID, refund.OrderItemID, quantity, refund.Amount, refund.Reason, refund.RefundedBy, refund.PaymentID)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func GetRefundsByOrderID(ctx context.Context, tx *sql.Tx, orderID int64) ([]models.Refund, error) {
+	query := `
+		SELECT id, order_id, order_item_id, COALESCE(quantity, 0), amount, reason, refunded_by, payment_id, created_at
+		FROM refunds
+		WHERE order_id = ?
+		ORDER BY id
+	`
+	rows, err := executor(tx).QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := make([]models.Refund, 0)
+	for rows.Next() {
+		var r models.Refund
+		var itemID, refundedBy, paymentID sql.NullInt64
+		if err := rows.Scan(&r.ID, &r.OrderID, &itemID, &r.Quantity, &r.Amount, &r.Reason, &refundedBy, &paymentID, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		if itemID.Valid {
+			r.OrderItemID = &itemID.Int64
+		}
+		if refundedBy.Valid {
+			r.RefundedBy = &refundedBy.Int64
+		}
+		if paymentID.Valid {
+			r.PaymentID = &paymentID.Int64
+		}
+		refunds = append(refunds, r)
+	}
+	return refunds, rows.Err()
+}
+
+// GetRefundedQuantities returns how many units of each order line have already been refunded.
+func GetRefundedQuantities(ctx context.Context, tx *sql.Tx, orderID int64) (map[int64]int, error) {
+	query := `SELECT order_item_id, SUM(quantity) FROM refunds WHERE order_id = ? AND order_item_id IS NOT NULL GROUP BY order_item_id`
+	rows, err := executor(tx).QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	quantities := make(map[int64]int)
+	for rows.Next() {
+		var itemID int64
+		var quantity int
+		if err := rows.Scan(&itemID, &quantity); err != nil {
+			return nil, err
+		}
+		quantities[itemID] = quantity
+	}
+	return quantities, rows.Err()
+}
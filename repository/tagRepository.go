@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+const tagColumns = `id, name, category_id, main_category`
+
+func scanTag(row rowScanner) (*models.Tag, error) {
+	var t models.Tag
+	var categoryID sql.NullInt64
+	if err := row.Scan(&t.ID, &t.Name, &categoryID, &t.MainCategory); err != nil {
+		return nil, err
+	}
+	if categoryID.Valid {
+		t.CategoryID = &categoryID.Int64
+	}
+	return &t, nil
+}
+
+func CreateTag(ctx context.Context, tx *sql.Tx, tenantID string, payload *models.TagPayload) (int64, error) {
+	query := `INSERT INTO tags (tenant_id, name, category_id, main_category) VALUES (?, ?, ?, ?)`
+	res, err := executor(tx).ExecContext(ctx, query, tenantID, payload.Name, payload.CategoryID, payload.MainCategory)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func GetTagByID(ctx context.Context, tx *sql.Tx, tenantID string, tagID int64) (*models.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE id = ? AND tenant_id = ?`
+	return scanTag(executor(tx).QueryRowContext(ctx, query, tagID, tenantID))
+}
+
+// GetTagByName finds a tag the way the unique key on tags compares names, so case does not matter.
+func GetTagByName(ctx context.Context, tx *sql.Tx, tenantID string, name string) (*models.Tag, error) {
+	query := `SELECT ` + tagColumns + ` FROM tags WHERE tenant_id = ? AND name = ?`
+	return scanTag(executor(tx).QueryRowContext(ctx, query, tenantID, name))
+}
+
+func UpdateTag(ctx context.Context, tx *sql.Tx, tenantID string, tagID int64, payload *models.TagPayload) error {
+	query := `UPDATE tags SET name = ?, category_id = ?, main_category = ? WHERE id = ? AND tenant_id = ?`
+	_, err := executor(tx).ExecContext(ctx, query, payload.Name, payload.CategoryID, payload.MainCategory, tagID, tenantID)
+	return err
+}
+
+// DeleteTag deletes a tag; its product associations go with it.
+func DeleteTag(ctx context.Context, tx *sql.Tx, tenantID string, tagID int64) error {
+	query := `DELETE FROM tags WHERE id = ? AND tenant_id = ?`
+	_, err := executor(tx).ExecContext(ctx, query, tagID, tenantID)
+	return err
+}
+
+func GetTagProductIDs(ctx context.Context, tx *sql.Tx, tagID int64) ([]int64, error) {
+	rows, err := executor(tx).QueryContext(ctx, `SELECT product_id FROM product_tags WHERE tag_id = ?`, tagID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// SetProductTags replaces the tags of a product.
+func SetProductTags(ctx context.Context, tx *sql.Tx, productID int64, tagIDs []int64) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_tags WHERE product_id = ?`, productID); err != nil {
+		return err
+	}
+	for _, tagID := range tagIDs {
+		if _, err := tx.ExecContext(ctx, `INSERT IGNORE INTO product_tags (product_id, tag_id) VALUES (?, ?)`, productID, tagID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MergeTag moves the products of one tag over to another and deletes the first. Products that already had both tags
+// keep just one association.
+func MergeTag(ctx context.Context, tx *sql.Tx, tenantID string, fromTagID, intoTagID int64) error {
+	query := `INSERT IGNORE INTO product_tags (product_id, tag_id) SELECT product_id, ? FROM product_tags WHERE tag_id = ?`
+	if _, err := tx.ExecContext(ctx, query, intoTagID, fromTagID); err != nil {
+		return err
+	}
+	return DeleteTag(ctx, tx, tenantID, fromTagID)
+}
+
+// PromotionsUsingTag lists the codes of the promotions restricted to a tag.
+func PromotionsUsingTag(ctx context.Context, tx *sql.Tx, tenantID string, name string) ([]string, error) {
+	return promotionsRestrictedTo(ctx, tx, tenantID, "tags", name)
+}
+
+// RenamePromotionTag rewrites a tag name in the restrictions of a tenant's promotions. Promotions store tag names
+// rather than IDs, so renaming or merging a tag has to follow them there.
+func RenamePromotionTag(ctx context.Context, tx *sql.Tx, tenantID string, from, to string) error {
+	return renamePromotionRestriction(ctx, tx, tenantID, "tags", from, to)
+}
@@ -28,17 +28,44 @@ func GetTenantByID(ctx context.Context, id string) (*models.Tenant, error) {
 
 	return &tenant, nil
 }
-func UpdateTenantConfig(ctx context.Context, tenantID string, config *models.TenantConfig) error {
+func UpdateTenantConfig(ctx context.Context, tx *sql.Tx, tenantID string, config *models.TenantConfig) error {
 	configJSON, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
 	query := `UPDATE tenants SET config = ? WHERE name = ?`
-	_, err = db.DB.ExecContext(ctx, query, string(configJSON), tenantID)
+	_, err = executor(tx).ExecContext(ctx, query, string(configJSON), tenantID)
 	return err
 }
 
+// GetTenantConfigForUpdate reads a tenant's configuration and locks it until the transaction ends.
+func GetTenantConfigForUpdate(ctx context.Context, tx *sql.Tx, tenantID string) (*models.TenantConfig, error) {
+	var configJSON string
+	query := `SELECT config FROM tenants WHERE name = ? FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, query, tenantID).Scan(&configJSON); err != nil {
+		return nil, err
+	}
+	var config models.TenantConfig
+	if err := json.Unmarshal([]byte(configJSON), &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// TenantHasPrices reports whether a tenant has products, promotions or orders, whose amounts are kept in minor units
+// of its currency.
+func TenantHasPrices(ctx context.Context, tx *sql.Tx, tenantID string) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM products WHERE tenant_id = ?)
+			OR EXISTS (SELECT 1 FROM promotions WHERE tenant_id = ?)
+			OR EXISTS (SELECT 1 FROM orders WHERE tenant_id = ?)
+	`
+	var hasPrices bool
+	err := executor(tx).QueryRowContext(ctx, query, tenantID, tenantID, tenantID).Scan(&hasPrices)
+	return hasPrices, err
+}
+
 func CreateTenant(ctx context.Context, name string, config *models.TenantConfig) error {
 	configJSON, err := json.Marshal(config)
 	if err != nil {
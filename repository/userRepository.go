@@ -11,8 +11,12 @@ import (
 )
 
 func CreateUser(ctx context.Context, user *models.User) error {
-	query := `INSERT INTO users (tenant_id, role, full_name, email, mobile_number, password_hash, date_of_birth) VALUES (?, ?, ?, ?, ?, ?)`
-	_, err := db.DB.ExecContext(ctx, query, user.TenantID, user.Role, user.Full_name, user.Email, user.Mobile_number, user.Password_hash, user.Date_of_birth)
+	query := `INSERT INTO users (tenant_id, role, full_name, email, mobile_number, password_hash, date_of_birth) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := db.DB.ExecContext(ctx, query, user.TenantID, user.Role, user.Full_name, user.Email, user.Mobile_number, user.Password_hash, user.Date_of_birth)
+	if err != nil {
+		return err
+	}
+	user.ID, err = res.LastInsertId()
 	return err
 }
 
@@ -38,9 +42,37 @@ func CreatePasswordResetToken(ctx context.Context, userID int64, tokenHash strin
 	return err
 }
 
-func UpdateUserPassword(ctx context.Context, userID int64, newPasswordHash string) error {
+func GetPasswordResetToken(ctx context.Context, tokenHash string, tenantID string) (int64, time.Time, error) {
+	var userID int64
+	var expiresAt time.Time
+	query := `
+		SELECT prt.user_id, prt.expires_at
+		FROM password_reset_tokens prt
+		JOIN users u ON prt.user_id = u.id
+		WHERE prt.token_hash = ? AND u.tenant_id = ?
+	`
+	err := db.DB.QueryRowContext(ctx, query, tokenHash, tenantID).Scan(&userID, &expiresAt)
+	return userID, expiresAt, err
+}
+
+func DeletePasswordResetToken(ctx context.Context, tx *sql.Tx, tokenHash string) (int64, error) {
+	query := `DELETE FROM password_reset_tokens WHERE token_hash = ?`
+	res, err := executor(tx).ExecContext(ctx, query, tokenHash)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func DeletePasswordResetTokensByUserID(ctx context.Context, tx *sql.Tx, userID int64) error {
+	query := `DELETE FROM password_reset_tokens WHERE user_id = ?`
+	_, err := executor(tx).ExecContext(ctx, query, userID)
+	return err
+}
+
+func UpdateUserPassword(ctx context.Context, tx *sql.Tx, userID int64, newPasswordHash string) error {
 	query := `UPDATE users SET password_hash = ? WHERE id = ?`
-	_, err := db.DB.ExecContext(ctx, query, newPasswordHash, userID)
+	_, err := executor(tx).ExecContext(ctx, query, newPasswordHash, userID)
 	return err
 }
 func UpdateUser(ctx context.Context, userID int64, payload *models.UpdateProfilePayload) error {
@@ -91,23 +123,54 @@ func DeleteUserByID(ctx context.Context, userID int64) error {
 	return err
 }
 
-func GetUsersByTenantID(ctx context.Context, tenantID string) ([]models.User, error) {
-	query := `SELECT id, role, full_name, email, mobile_number, date_of_birth, avatar_url, tenant_id FROM users WHERE tenant_id = ? AND role = 'CUSTOMER'`
-	rows, err := db.DB.QueryContext(ctx, query, tenantID)
+// customerSortColumns are the sort fields of customer lists. Users have no creation time, so newest goes by ID.
+var customerSortColumns = sortColumns{
+	"newest": "id",
+	"name":   "full_name",
+}
+
+func GetUsersByTenantID(ctx context.Context, tenantID string, page *models.PageQuery) (*models.Page[models.User], error) {
+	where := `WHERE tenant_id = ? AND role = 'CUSTOMER'`
+	ks := newKeyset(page, customerSortColumns, "id")
+	query := `SELECT id, role, full_name, email, COALESCE(mobile_number, ''), COALESCE(date_of_birth, ''), COALESCE(avatar_url, ''), tenant_id` + ks.columns +
+		` FROM users ` + where + ` AND ` + ks.condition + ks.orderBy + ks.limit
+	rows, err := db.DB.QueryContext(ctx, query, append([]interface{}{tenantID}, ks.args...)...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var users []models.User
-	for rows.Next() {
+
+	result, err := scanPage(rows, page, func(row rowScanner) (*models.User, error) {
 		var u models.User
-		err := rows.Scan(&u.ID, &u.Full_name, &u.Email, &u.Mobile_number, &u.Date_of_birth, &u.Avatar_url, &u.TenantID)
+		err := row.Scan(&u.ID, &u.Role, &u.Full_name, &u.Email, &u.Mobile_number, &u.Date_of_birth, &u.Avatar_url, &u.TenantID)
+		return &u, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if page.WithTotal {
+		result.Pagination.Total, err = countTotal(ctx, `SELECT COUNT(*) FROM users `+where, tenantID)
 		if err != nil {
 			return nil, err
 		}
-		users = append(users, u)
-
 	}
-	return users, nil
+	return result, nil
+}
 
+func GetTenantAdminIDs(ctx context.Context, tenantID string) ([]int64, error) {
+	query := `SELECT id FROM users WHERE tenant_id = ? AND role = 'ADMIN'`
+	rows, err := db.DB.QueryContext(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
 }
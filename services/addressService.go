@@ -8,23 +8,118 @@ import (
 	"github.com/AryaTabani/Dorivo/repository"
 )
 
-var ErrAddressNotFound = errors.New("address not found or you do not have permission to delete it")
+var ErrAddressNotFound = errors.New("address not found or it does not belong to you")
 
-func AddAddress(ctx context.Context, userID int64, payload *models.AddAddressPayload) error {
-	return repository.CreateAddress(ctx, userID, payload)
+// AddAddress saves a new address. A user's first address becomes their default.
+func AddAddress(ctx context.Context, userID int64, payload *models.AddAddressPayload) (*models.Address, error) {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	addresses, err := repository.GetAddressesByUserID(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	isDefault := (payload.IsDefault != nil && *payload.IsDefault) || len(addresses) == 0
+
+	id, err := repository.CreateAddress(ctx, tx, userID, payload, isDefault)
+	if err != nil {
+		return nil, err
+	}
+	if isDefault {
+		if err := repository.SetDefaultAddress(ctx, tx, userID, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return repository.GetAddressByIDAndUserID(ctx, id, userID)
+}
+
+// UpdateAddress replaces the fields of one of the user's addresses. The default mark only changes when is_default is
+// given. Orders already placed keep their own copy.
+func UpdateAddress(ctx context.Context, userID, addressID int64, payload *models.AddAddressPayload) (*models.Address, error) {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	addresses, err := repository.GetAddressesByUserID(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	current := findAddress(addresses, addressID)
+	if current == nil {
+		return nil, ErrAddressNotFound
+	}
+
+	if err := repository.UpdateAddress(ctx, tx, addressID, payload); err != nil {
+		return nil, err
+	}
+	if payload.IsDefault != nil && *payload.IsDefault != current.IsDefault {
+		defaultID := addressID
+		if !*payload.IsDefault {
+			defaultID = 0
+		}
+		if err := repository.SetDefaultAddress(ctx, tx, userID, defaultID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return repository.GetAddressByIDAndUserID(ctx, addressID, userID)
 }
 
 func GetMyAddresses(ctx context.Context, userID int64) ([]models.Address, error) {
-	return repository.GetAddressesByUserID(ctx, userID)
+	return repository.GetAddressesByUserID(ctx, nil, userID)
 }
 
+// DeleteAddress removes one of the user's addresses. When it was the default, the newest remaining address takes over.
 func DeleteAddress(ctx context.Context, userID int64, addressID int64) error {
-	rowsAffected, err := repository.DeleteAddress(ctx, userID, addressID)
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	addresses, err := repository.GetAddressesByUserID(ctx, tx, userID)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
+	deleted := findAddress(addresses, addressID)
+	if deleted == nil {
 		return ErrAddressNotFound
 	}
+
+	if _, err := repository.DeleteAddress(ctx, tx, userID, addressID); err != nil {
+		return err
+	}
+	if deleted.IsDefault {
+		var newest int64
+		for _, addr := range addresses {
+			if addr.ID != addressID && addr.ID > newest {
+				newest = addr.ID
+			}
+		}
+		if newest != 0 {
+			if err := repository.SetDefaultAddress(ctx, tx, userID, newest); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+func findAddress(addresses []models.Address, addressID int64) *models.Address {
+	for i := range addresses {
+		if addresses[i].ID == addressID {
+			return &addresses[i]
+		}
+	}
 	return nil
 }
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	db "github.com/AryaTabani/Dorivo/DB"
 	"github.com/AryaTabani/Dorivo/models"
@@ -11,20 +13,120 @@ import (
 )
 
 func CreateProduct(ctx context.Context, tenantID string, payload *models.ProductPayload) (int64, error) {
-	return repository.CreateProduct(ctx, tenantID, payload)
+	tags, err := normalizeTagNames(payload.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	category, err := resolveCategory(ctx, tx, tenantID, payload.CategoryID, payload.MainCategory)
+	if err != nil {
+		return 0, err
+	}
+	payload.CategoryID, payload.MainCategory = &category.ID, category.Name
+	productID, err := repository.CreateProduct(ctx, tx, tenantID, payload)
+	if err != nil {
+		return 0, err
+	}
+	if err := setProductTags(ctx, tx, tenantID, productID, tags, category); err != nil {
+		return 0, err
+	}
+	if err := repository.RefreshProductSearchText(ctx, tx, productID); err != nil {
+		return 0, err
+	}
+	return productID, tx.Commit()
 }
 
+// UpdateProduct saves a product's details. Its tags are only replaced when the payload lists them.
 func UpdateProduct(ctx context.Context, tenantID string, productID int64, payload *models.ProductPayload) error {
-	return repository.UpdateProduct(ctx, tenantID, productID, payload)
+	var tags []string
+	if payload.Tags != nil {
+		var err error
+		if tags, err = normalizeTagNames(payload.Tags); err != nil {
+			return err
+		}
+	}
+	exists, err := repository.ProductExists(ctx, tenantID, productID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProductNotFound
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	category, err := resolveCategory(ctx, tx, tenantID, payload.CategoryID, payload.MainCategory)
+	if err != nil {
+		return err
+	}
+	payload.CategoryID, payload.MainCategory = &category.ID, category.Name
+	if err := repository.UpdateProduct(ctx, tx, tenantID, productID, payload); err != nil {
+		return err
+	}
+	if payload.Tags != nil {
+		if err := setProductTags(ctx, tx, tenantID, productID, tags, category); err != nil {
+			return err
+		}
+	}
+	if err := repository.RefreshProductSearchText(ctx, tx, productID); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func DeleteProduct(ctx context.Context, tenantID string, productID int64) error {
 	return repository.DeleteProduct(ctx, tenantID, productID)
 }
 
+// UpdateTenantConfig replaces a tenant's configuration. Leaving the currency out keeps the current one. Amounts are
+// stored in minor units of the currency, so it can only be changed while the store has no prices yet.
 func UpdateTenantConfig(ctx context.Context, tenantID string, payload *models.TenantConfig) error {
-	err := repository.UpdateTenantConfig(ctx, tenantID, payload)
+	if err := validateDeliveryZones(payload); err != nil {
+		return err
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := repository.GetTenantConfigForUpdate(ctx, tx, tenantID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTenantNotFound
+		}
+		return err
+	}
+	applyMoneyDefaults(current)
+	if payload.Currency == "" {
+		payload.Currency = current.Currency
+	}
+	payload.Currency = strings.ToUpper(payload.Currency)
+	if payload.Currency != current.Currency {
+		hasPrices, err := repository.TenantHasPrices(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+		if hasPrices {
+			return ErrCurrencyLocked
+		}
+	}
+
+	if err := repository.UpdateTenantConfig(ctx, tx, tenantID, payload); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
 		return err
 	}
 	cacheKey := fmt.Sprintf("tenant_config:%s", tenantID)
@@ -32,25 +134,64 @@ func UpdateTenantConfig(ctx context.Context, tenantID string, payload *models.Te
 
 	return nil
 }
-func GetTenantOrders(ctx context.Context, tenantID string, status string) ([]models.Order, error) {
-	if status == "" {
-		status = "Active"
+func GetTenantOrders(ctx context.Context, tenantID string, statusFilter []string, req *models.PageRequest) (*models.Page[models.Order], error) {
+	statuses, err := ParseOrderStatusFilter(statusFilter)
+	if err != nil {
+		return nil, err
 	}
-	return repository.GetOrdersByTenantID(ctx, tenantID, status)
-}
-func UpdateOrderStatus(ctx context.Context, tenantID string, orderID int64, newStatus string) error {
-	RowsAffected, err := repository.AdminUpdateOrderStatus(ctx, tenantID, orderID, newStatus)
+	page, err := resolvePage(req, orderSortFields, "newest")
 	if err != nil {
+		return nil, err
+	}
+	orders, err := repository.GetOrdersByTenantID(ctx, tenantID, statuses, page)
+	if err != nil {
+		return nil, err
+	}
+	return finishPage(orders, page), nil
+}
+func UpdateOrderStatus(ctx context.Context, tenantID string, adminID, orderID int64, payload *models.UpdateOrderStatusPayload) error {
+	if payload.Status == models.OrderStatusRefunded {
+		// Refunding an order pays back what is left of it, which RefundOrder does before moving it to Refunded.
+		reason := payload.Note
+		if reason == "" {
+			reason = "Order refunded"
+		}
+		_, err := RefundOrder(ctx, tenantID, adminID, orderID, &models.RefundPayload{Reason: reason})
 		return err
 	}
-	if RowsAffected == 0 {
-		return errors.New("order not found or you do not have permission to update it")
+	check := func(order *models.Order) error {
+		if order.TenantID != tenantID {
+			return ErrOrderNotFound
+		}
+		return nil
 	}
+	order, err := moveOrder(ctx, orderID, payload.Status, &adminID, payload.Note, check, nil)
+	if err != nil {
+		return err
+	}
+	notifyOrderStatusChange(ctx, order)
 	return nil
 }
-func GetTenantCustomers(ctx context.Context, tenantID string) ([]models.User, error) {
-	return repository.GetUsersByTenantID(ctx, tenantID)
+func GetTenantCustomers(ctx context.Context, tenantID string, req *models.PageRequest) (*models.Page[models.User], error) {
+	page, err := resolvePage(req, customerSortFields, "newest")
+	if err != nil {
+		return nil, err
+	}
+	customers, err := repository.GetUsersByTenantID(ctx, tenantID, page)
+	if err != nil {
+		return nil, err
+	}
+	return finishPage(customers, page), nil
 }
 func GetDashboardStats(ctx context.Context, tenantID string) (*models.DashboardStats, error) {
-	return repository.GetTenantDashboardStats(ctx, tenantID)
+	currency, _, err := tenantMoney(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := repository.GetTenantDashboardStats(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	stats.Currency = currency
+	return stats, nil
 }
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	_ "time/tzdata" // tenant time zones must resolve even on hosts without a zone database
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var (
+	ErrStoreClosed     = errors.New("the store cannot take orders right now; schedule the order for a later slot")
+	ErrSlotUnavailable = errors.New("the chosen time slot is not available")
+	ErrSlotFull        = errors.New("the time slot is fully booked")
+)
+
+const (
+	defaultSlotMinutes  = 15
+	defaultScheduleDays = 7
+	maxAvailableSlots   = 200
+)
+
+// openWindow is a stretch of time in which the store is open.
+type openWindow struct {
+	start, end time.Time
+}
+
+func tenantLocation(config *models.TenantConfig) *time.Location {
+	if config.Timezone != "" {
+		if loc, err := time.LoadLocation(config.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+func slotLength(config *models.TenantConfig) time.Duration {
+	if config.SlotMinutes > 0 {
+		return time.Duration(config.SlotMinutes) * time.Minute
+	}
+	return defaultSlotMinutes * time.Minute
+}
+
+func scheduleHorizon(config *models.TenantConfig) time.Duration {
+	days := config.ScheduleDays
+	if days == 0 {
+		days = defaultScheduleDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// isClosedOn reports whether a closure covers the given date, which is in the tenant's time zone.
+func isClosedOn(config *models.TenantConfig, day time.Time) bool {
+	date := day.Format(time.DateOnly)
+	for _, closure := range config.Closures {
+		to := closure.To
+		if to == "" {
+			to = closure.From
+		}
+		if date >= closure.From && date <= to {
+			return true
+		}
+	}
+	return false
+}
+
+// openWindows returns the opening windows overlapping from..to in start order. A window belongs to the day it opens
+// on, so a closure on that day also cancels the hours it runs past midnight.
+func openWindows(config *models.TenantConfig, from, to time.Time) []openWindow {
+	loc := tenantLocation(config)
+	local := from.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, loc)
+
+	var windows []openWindow
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if isClosedOn(config, day) {
+			continue
+		}
+		if len(config.OpeningHours) == 0 {
+			windows = append(windows, openWindow{start: day, end: day.AddDate(0, 0, 1)})
+			continue
+		}
+		for _, hours := range config.OpeningHours {
+			if hours.Day != int(day.Weekday()) {
+				continue
+			}
+			opens, errOpen := time.Parse("15:04", hours.Open)
+			closes, errClose := time.Parse("15:04", hours.Close)
+			if errOpen != nil || errClose != nil {
+				continue
+			}
+			window := openWindow{
+				start: time.Date(day.Year(), day.Month(), day.Day(), opens.Hour(), opens.Minute(), 0, 0, loc),
+				end:   time.Date(day.Year(), day.Month(), day.Day(), closes.Hour(), closes.Minute(), 0, 0, loc),
+			}
+			if !window.end.After(window.start) {
+				window.end = window.end.AddDate(0, 0, 1)
+			}
+			windows = append(windows, window)
+		}
+	}
+
+	overlapping := windows[:0]
+	for _, window := range windows {
+		if window.end.After(from) && window.start.Before(to) {
+			overlapping = append(overlapping, window)
+		}
+	}
+	sort.Slice(overlapping, func(i, j int) bool { return overlapping[i].start.Before(overlapping[j].start) })
+	return overlapping
+}
+
+func isOpenAt(config *models.TenantConfig, t time.Time) bool {
+	for _, window := range openWindows(config, t, t.Add(time.Nanosecond)) {
+		if !t.Before(window.start) && t.Before(window.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// openUntil returns when the store closes after t, following on into windows that touch or overlap the one t falls
+// in, such as a day that closes at midnight and a next day that opens then. It is t itself when the store is closed.
+func openUntil(config *models.TenantConfig, t time.Time) time.Time {
+	until := t
+	for _, window := range openWindows(config, t, t.Add(scheduleHorizon(config))) {
+		if window.start.After(until) {
+			break
+		}
+		if window.end.After(until) {
+			until = window.end
+		}
+	}
+	return until
+}
+
+// nextOpening returns when the store opens next after t, or nil when it stays closed for the whole schedule horizon.
+func nextOpening(config *models.TenantConfig, t time.Time) *time.Time {
+	for _, window := range openWindows(config, t, t.Add(scheduleHorizon(config))) {
+		if window.start.After(t) {
+			start := window.start.UTC()
+			return &start
+		}
+	}
+	return nil
+}
+
+// slotStarts lists the starts of the slots that begin no earlier than earliest and before latest. Slots are laid out
+// from the start of each opening window and must end within it.
+func slotStarts(config *models.TenantConfig, earliest, latest time.Time) []time.Time {
+	length := slotLength(config)
+	var starts []time.Time
+	for _, window := range openWindows(config, earliest, latest) {
+		for start := window.start; !start.Add(length).After(window.end) && start.Before(latest); start = start.Add(length) {
+			if !start.Before(earliest) {
+				starts = append(starts, start.UTC())
+			}
+		}
+	}
+	return starts
+}
+
+// slotAt returns the start of the slot that t falls in, or nil when no slot covers it. Time after a window's last whole
+// slot, up to and including its closing time, belongs to that slot; where two windows meet, the later one is used.
+func slotAt(config *models.TenantConfig, t time.Time) *time.Time {
+	length := slotLength(config)
+	var slot *time.Time
+	for _, window := range openWindows(config, t.Add(-time.Nanosecond), t.Add(time.Nanosecond)) {
+		slots := window.end.Sub(window.start) / length
+		if t.Before(window.start) || t.After(window.end) || slots == 0 {
+			continue
+		}
+		start := window.start.Add(min(t.Sub(window.start)/length, slots-1) * length).UTC()
+		slot = &start
+	}
+	return slot
+}
+
+// GetAvailability tells whether a tenant takes orders now and lists the next slots that can still be booked.
+func GetAvailability(ctx context.Context, tenantID string, limit int) (*models.Availability, error) {
+	config, err := GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxAvailableSlots {
+		limit = maxAvailableSlots
+	}
+
+	now := time.Now().UTC()
+	availability := &models.Availability{
+		Open:        isOpenAt(config, now),
+		Timezone:    tenantLocation(config).String(),
+		PrepMinutes: config.PrepMinutes,
+		Slots:       make([]models.TimeSlot, 0),
+	}
+	if !availability.Open {
+		availability.NextOpenAt = nextOpening(config, now)
+	}
+
+	earliest := now.Add(time.Duration(config.PrepMinutes) * time.Minute)
+	latest := now.Add(scheduleHorizon(config))
+	counts, err := repository.CountSlotOrders(ctx, nil, tenantID, earliest, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	length := slotLength(config)
+	for _, start := range slotStarts(config, earliest, latest) {
+		slot := models.TimeSlot{Start: start, End: start.Add(length)}
+		if config.SlotCapacity > 0 {
+			remaining := config.SlotCapacity - counts[start]
+			if remaining <= 0 {
+				continue
+			}
+			slot.Remaining = &remaining
+		}
+		availability.Slots = append(availability.Slots, slot)
+		if len(availability.Slots) == limit {
+			break
+		}
+	}
+	return availability, nil
+}
+
+// checkSchedule validates when an order is wanted. Orders without a slot are made as soon as possible: the store must be
+// open and stay open until the preparation lead time has passed, and they take up the slot they are ready in. Scheduled
+// orders need a slot that is far enough ahead for the preparation lead time. Either way the slot must not be full.
+func checkSchedule(ctx context.Context, tx *sql.Tx, config *models.TenantConfig, order *models.Order, now time.Time) error {
+	if order.ScheduledFor == nil {
+		if !isOpenAt(config, now) {
+			return ErrStoreClosed
+		}
+		ready := now.Add(time.Duration(config.PrepMinutes) * time.Minute)
+		if closes := openUntil(config, now); ready.After(closes) {
+			return fmt.Errorf("%w: an order placed now would not be ready before the store closes at %s", ErrStoreClosed, closes.In(tenantLocation(config)).Format("15:04"))
+		}
+		slot := slotAt(config, ready)
+		if slot == nil {
+			return nil
+		}
+		if err := checkSlotCapacity(ctx, tx, config, order.TenantID, *slot); err != nil {
+			return err
+		}
+		order.SlotStart = slot
+		return nil
+	}
+
+	slot := order.ScheduledFor.UTC().Truncate(time.Second)
+	earliest := now.Add(time.Duration(config.PrepMinutes) * time.Minute)
+	if slot.Before(earliest) {
+		return fmt.Errorf("%w: the earliest possible time is %s", ErrSlotUnavailable, earliest.In(tenantLocation(config)).Format("2006-01-02 15:04 MST"))
+	}
+	if slot.After(now.Add(scheduleHorizon(config))) {
+		return fmt.Errorf("%w: orders can be scheduled at most %d days ahead", ErrSlotUnavailable, int(scheduleHorizon(config).Hours()/24))
+	}
+	starts := slotStarts(config, slot, slot.Add(time.Nanosecond))
+	if len(starts) == 0 || !starts[0].Equal(slot) {
+		return fmt.Errorf("%w: pick one of the slots from the store's availability", ErrSlotUnavailable)
+	}
+
+	if err := checkSlotCapacity(ctx, tx, config, order.TenantID, slot); err != nil {
+		return err
+	}
+	order.ScheduledFor = &slot
+	order.SlotStart = &slot
+	return nil
+}
+
+// checkSlotCapacity makes sure the slot starting at slot can take one more order.
+func checkSlotCapacity(ctx context.Context, tx *sql.Tx, config *models.TenantConfig, tenantID string, slot time.Time) error {
+	if config.SlotCapacity <= 0 {
+		return nil
+	}
+	counts, err := repository.CountSlotOrders(ctx, tx, tenantID, slot, slot.Add(time.Second))
+	if err != nil {
+		return err
+	}
+	if counts[slot] >= config.SlotCapacity {
+		return ErrSlotFull
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"slices"
 
 	db "github.com/AryaTabani/Dorivo/DB"
 	"github.com/AryaTabani/Dorivo/models"
@@ -11,59 +13,236 @@ import (
 
 var ErrCartItemNotFound = errors.New("cart item not found or you do not have permission to modify it")
 
-func AddToCart(ctx context.Context, userID int64, payload *models.AddToCartPayload) error {
+func AddToCart(ctx context.Context, userID int64, tenantID string, payload *models.AddToCartPayload) error {
+	if err := validateOptionSelection(ctx, tenantID, payload.ProductID, payload.OptionIDs); err != nil {
+		return err
+	}
+
 	tx, err := db.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	cartID, err := repository.FindOrCreateCartByUserID(ctx, tx, userID)
+	if _, err := repository.FindOrCreateCartByUserID(ctx, tx, userID); err != nil {
+		return err
+	}
+	cartID, err := repository.GetCartIDForUpdate(ctx, tx, userID)
 	if err != nil {
 		return err
 	}
+	if err := addCartItem(ctx, tx, cartID, tenantID, payload); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
 
-	if err := repository.AddItem(ctx, tx, cartID, payload); err != nil {
+// addCartItem puts an already validated item into a locked cart, merging it into a line with the same options. The line
+// is priced at the current prices.
+func addCartItem(ctx context.Context, tx *sql.Tx, cartID int64, tenantID string, payload *models.AddToCartPayload) error {
+	lines, err := repository.GetCartLines(ctx, tx, cartID, payload.ProductID)
+	if err != nil {
 		return err
 	}
+	if line := findCartLine(lines, payload.OptionIDs, 0); line != nil {
+		quantity := line.Quantity + payload.Quantity
+		if err := checkItemAvailability(ctx, tenantID, payload.ProductID, payload.OptionIDs, quantity); err != nil {
+			return err
+		}
+		// The customer is adding the item at today's price, so the whole line moves to it.
+		if err := repository.RefreshCartItemPrices(ctx, tx, line.ID); err != nil {
+			return err
+		}
+		return repository.SetCartItemQuantity(ctx, tx, line.ID, quantity)
+	}
 
-	return tx.Commit()
+	if err := checkItemAvailability(ctx, tenantID, payload.ProductID, payload.OptionIDs, payload.Quantity); err != nil {
+		return err
+	}
+	return repository.AddItem(ctx, tx, cartID, payload)
+}
+
+// findCartLine returns the line, other than skipID, that holds exactly the given option set.
+func findCartLine(lines map[int64]*models.CartLine, optionIDs []int64, skipID int64) *models.CartLine {
+	wanted := sortedOptionIDs(optionIDs)
+	for id, line := range lines {
+		if id != skipID && slices.Equal(line.OptionIDs, wanted) {
+			return line
+		}
+	}
+	return nil
 }
 
-func GetCart(ctx context.Context, userID int64) (*models.Cart, error) {
+func sortedOptionIDs(optionIDs []int64) []int64 {
+	sorted := append(make([]int64, 0, len(optionIDs)), optionIDs...)
+	slices.Sort(sorted)
+	return sorted
+}
+
+func GetCart(ctx context.Context, userID int64, tenantID string) (*models.Cart, error) {
 	items, err := repository.GetCartContentsByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	config, err := GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	cart := newCart(config, items)
+
+	promotionID, err := repository.GetCartPromotionID(ctx, nil, userID)
+	if err != nil {
+		return nil, err
+	}
+	if promotionID.Valid && len(items) > 0 {
+		promotion, err := repository.GetPromotionByID(ctx, nil, promotionID.Int64)
+		if err != nil {
+			return nil, err
+		}
+		cart.PromoCode = promotion.Code
+		discount, err := calculatePromotionDiscount(ctx, nil, promotion, userID, items, cart.Subtotal)
+		if err != nil {
+			if !errors.Is(err, ErrPromoNotApplicable) {
+				return nil, err
+			}
+			cart.PromoError = err.Error()
+		} else {
+			cart.Discounts = append(cart.Discounts, models.CartDiscount{
+				Code:        promotion.Code,
+				Description: promotion.Description,
+				Amount:      discount,
+			})
+			cart.DiscountTotal += discount
+		}
+	}
+
+	chargeCart(config, cart)
+	return cart, nil
+}
+
+// newCart prices the items and wraps them in a cart in the tenant's currency, without discounts or charges.
+func newCart(config *models.TenantConfig, items []models.CartItem) *models.Cart {
+	if items == nil {
+		items = make([]models.CartItem, 0)
+	}
+	subtotal := priceCartItems(items)
+	snapshotSubtotal := snapshotSubtotal(items)
+	return &models.Cart{
+		Items:            items,
+		Currency:         config.Currency,
+		Subtotal:         subtotal,
+		SnapshotSubtotal: snapshotSubtotal,
+		PriceChanged:     subtotal != snapshotSubtotal,
+		Discounts:        make([]models.CartDiscount, 0),
+		Charges:          make([]models.Charge, 0),
+		GrandTotal:       subtotal,
+	}
+}
 
-	var grandTotal float64
-	for i, item := range items {
-		var itemOptionsTotal float64
+// priceCartItems fills in the current and snapshot unit prices and the line total of every item and returns the cart
+// subtotal at current prices.
+func priceCartItems(items []models.CartItem) models.Money {
+	var subtotal models.Money
+	for i := range items {
+		item := &items[i]
+		item.UnitPrice, item.SnapshotUnitPrice = item.BasePrice, item.SnapshotBasePrice
 		for _, opt := range item.Options {
-			itemOptionsTotal += opt.PriceModifier
+			item.UnitPrice += opt.PriceModifier
+			item.SnapshotUnitPrice += opt.SnapshotPriceModifier
 		}
-		singleItemPrice := item.BasePrice + itemOptionsTotal
-		items[i].TotalPrice = singleItemPrice * float64(item.Quantity)
-		grandTotal += items[i].TotalPrice
+		item.PriceChanged = item.UnitPrice != item.SnapshotUnitPrice
+		item.TotalPrice = item.UnitPrice.Times(item.Quantity)
+		subtotal += item.TotalPrice
 	}
+	return subtotal
+}
 
-	cart := &models.Cart{
-		Items:      items,
-		GrandTotal: grandTotal,
+// snapshotSubtotal returns what priced items cost at the prices they were added at.
+func snapshotSubtotal(items []models.CartItem) models.Money {
+	var subtotal models.Money
+	for _, item := range items {
+		subtotal += item.SnapshotUnitPrice.Times(item.Quantity)
 	}
+	return subtotal
+}
 
-	return cart, nil
+// cartPriceChanges lists the priced items whose unit price differs from their snapshot.
+func cartPriceChanges(items []models.CartItem) []models.CartPriceChange {
+	changes := make([]models.CartPriceChange, 0)
+	for _, item := range items {
+		if item.PriceChanged {
+			changes = append(changes, models.CartPriceChange{
+				CartItemID:        item.ID,
+				Name:              item.Name,
+				SnapshotUnitPrice: item.SnapshotUnitPrice,
+				UnitPrice:         item.UnitPrice,
+			})
+		}
+	}
+	return changes
 }
 
-func UpdateCartItemQuantity(ctx context.Context, userID, itemID int64, quantity int) error {
-	rowsAffected, err := repository.UpdateCartItemQuantity(ctx, userID, itemID, quantity)
+// UpdateCartItem changes the quantity and, when option IDs are given, the option selection of a cart line. If the new
+// selection matches another line of the same product, the two lines are merged into that one.
+func UpdateCartItem(ctx context.Context, userID int64, tenantID string, itemID int64, payload *models.UpdateCartItemPayload) error {
+	tx, err := repository.BeginTx(ctx)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return ErrCartItemNotFound
+	defer tx.Rollback()
+
+	cartID, err := repository.GetCartIDForUpdate(ctx, tx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCartItemNotFound
+		}
+		return err
 	}
-	return nil
+	if err := updateCartItem(ctx, tx, cartID, tenantID, itemID, payload); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func updateCartItem(ctx context.Context, tx *sql.Tx, cartID int64, tenantID string, itemID int64, payload *models.UpdateCartItemPayload) error {
+	productID, err := repository.GetCartItemProductID(ctx, tx, cartID, itemID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCartItemNotFound
+		}
+		return err
+	}
+	lines, err := repository.GetCartLines(ctx, tx, cartID, productID)
+	if err != nil {
+		return err
+	}
+
+	optionIDs := lines[itemID].OptionIDs
+	if payload.OptionIDs != nil {
+		optionIDs = *payload.OptionIDs
+		if err := validateOptionSelection(ctx, tenantID, productID, optionIDs); err != nil {
+			return err
+		}
+	}
+
+	target, quantity := itemID, payload.Quantity
+	if line := findCartLine(lines, optionIDs, itemID); line != nil {
+		target, quantity = line.ID, line.Quantity+payload.Quantity
+	}
+	if err := checkItemAvailability(ctx, tenantID, productID, optionIDs, quantity); err != nil {
+		return err
+	}
+
+	if target != itemID {
+		if err := repository.DeleteCartItem(ctx, tx, itemID); err != nil {
+			return err
+		}
+	} else if payload.OptionIDs != nil {
+		if err := repository.ReplaceCartItemOptions(ctx, tx, itemID, optionIDs); err != nil {
+			return err
+		}
+	}
+	return repository.SetCartItemQuantity(ctx, tx, target, quantity)
 }
 
 func RemoveCartItem(ctx context.Context, userID, itemID int64) error {
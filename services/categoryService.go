@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/AryaTabani/Dorivo/DB"
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryExists   = errors.New("a category with this name already exists")
+	ErrInvalidCategory  = errors.New("invalid category")
+	ErrCategoryInUse    = errors.New("category is still in use")
+)
+
+// GetCategories lists all categories of a store, hidden ones included, for the admin panel.
+func GetCategories(ctx context.Context, tenantID string) ([]models.Category, error) {
+	return repository.GetCategories(ctx, nil, tenantID)
+}
+
+// GetCategoryTree returns the visible categories of a store nested under their parents, each telling whether it is
+// served right now. A hidden category hides everything below it.
+func GetCategoryTree(ctx context.Context, tenantID string) ([]models.Category, error) {
+	config, err := GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := repository.GetCategories(ctx, nil, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[int64][]models.Category)
+	var roots []models.Category
+	for _, category := range categories {
+		if !category.IsVisible {
+			continue
+		}
+		if category.ParentID == nil {
+			roots = append(roots, category)
+		} else {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	now := time.Now().In(tenantLocation(config))
+	var build func(nodes []models.Category, parentServed bool) []models.Category
+	build = func(nodes []models.Category, parentServed bool) []models.Category {
+		tree := make([]models.Category, 0, len(nodes))
+		for _, node := range nodes {
+			node.AvailableNow = parentServed && servedAt(&node, now)
+			node.Children = build(children[node.ID], node.AvailableNow)
+			tree = append(tree, node)
+		}
+		return tree
+	}
+	return build(roots, true), nil
+}
+
+// servedAt reports whether t, in the tenant's time zone, falls into a category's serving hours. Like opening hours, a
+// window that ends at or before its start runs past midnight.
+func servedAt(category *models.Category, t time.Time) bool {
+	if category.AvailableFrom == nil || category.AvailableUntil == nil {
+		return true
+	}
+	from, errFrom := time.Parse("15:04", *category.AvailableFrom)
+	until, errUntil := time.Parse("15:04", *category.AvailableUntil)
+	if errFrom != nil || errUntil != nil {
+		return true
+	}
+	minute := t.Hour()*60 + t.Minute()
+	start := from.Hour()*60 + from.Minute()
+	end := until.Hour()*60 + until.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+func CreateCategory(ctx context.Context, tenantID string, payload *models.CategoryPayload) (*models.Category, error) {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := validateCategory(ctx, tx, tenantID, 0, payload); err != nil {
+		return nil, err
+	}
+	categoryID, err := repository.CreateCategory(ctx, tx, tenantID, payload)
+	if err != nil {
+		return nil, err
+	}
+	category, err := repository.GetCategoryByID(ctx, tx, tenantID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return category, tx.Commit()
+}
+
+// UpdateCategory saves a category. A new name is copied onto the category's products and tags and replaces the old
+// one in the promotions and taxes limited to it, which refer to categories by name.
+func UpdateCategory(ctx context.Context, tenantID string, categoryID int64, payload *models.CategoryPayload) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	category, err := getCategory(ctx, tx, tenantID, categoryID)
+	if err != nil {
+		return err
+	}
+	if err := validateCategory(ctx, tx, tenantID, categoryID, payload); err != nil {
+		return err
+	}
+	if err := repository.UpdateCategory(ctx, tx, tenantID, categoryID, payload); err != nil {
+		return err
+	}
+
+	renamed := category.Name != payload.Name
+	if renamed {
+		if err := repository.RenameCategory(ctx, tx, categoryID, payload.Name); err != nil {
+			return err
+		}
+		if err := repository.RenamePromotionCategory(ctx, tx, tenantID, category.Name, payload.Name); err != nil {
+			return err
+		}
+		config, err := repository.GetTenantConfigForUpdate(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+		if renameTaxCategory(config, category.Name, payload.Name) {
+			if err := repository.UpdateTenantConfig(ctx, tx, tenantID, config); err != nil {
+				return err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if renamed {
+		db.Rdb.Del(db.Ctx, fmt.Sprintf("tenant_config:%s", tenantID))
+	}
+	return nil
+}
+
+// DeleteCategory deletes an unused category. Categories that still have products, tags or subcategories, or that
+// promotions or taxes are limited to, have to be emptied first.
+func DeleteCategory(ctx context.Context, tenantID string, categoryID int64) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	category, err := getCategory(ctx, tx, tenantID, categoryID)
+	if err != nil {
+		return err
+	}
+	products, tags, children, err := repository.CountCategoryUsage(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+	if products > 0 || tags > 0 || children > 0 {
+		return fmt.Errorf("%w: it has %d products, %d tags and %d subcategories", ErrCategoryInUse, products, tags, children)
+	}
+	codes, err := repository.PromotionsUsingCategory(ctx, tx, tenantID, category.Name)
+	if err != nil {
+		return err
+	}
+	if len(codes) > 0 {
+		return fmt.Errorf("%w: promotions %s are limited to it", ErrCategoryInUse, strings.Join(codes, ", "))
+	}
+	config, err := GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	for _, tax := range config.Taxes {
+		for _, name := range tax.Categories {
+			if strings.EqualFold(name, category.Name) {
+				return fmt.Errorf("%w: tax %s is limited to it", ErrCategoryInUse, tax.Name)
+			}
+		}
+	}
+
+	if err := repository.DeleteCategory(ctx, tx, tenantID, categoryID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func getCategory(ctx context.Context, tx *sql.Tx, tenantID string, categoryID int64) (*models.Category, error) {
+	category, err := repository.GetCategoryByID(ctx, tx, tenantID, categoryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCategoryNotFound
+	}
+	return category, err
+}
+
+// validateCategory checks a category payload, with categoryID 0 for a new category, and fills in its defaults. The
+// name must be unique in the store, serving hours need both ends, and the parent must be another category of the
+// store that is not below this one.
+func validateCategory(ctx context.Context, tx *sql.Tx, tenantID string, categoryID int64, payload *models.CategoryPayload) error {
+	payload.Name = strings.Join(strings.Fields(payload.Name), " ")
+	if payload.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+	if payload.IsVisible == nil {
+		visible := true
+		payload.IsVisible = &visible
+	}
+	if (payload.AvailableFrom == nil) != (payload.AvailableUntil == nil) {
+		return fmt.Errorf("%w: available_from and available_until must be given together", ErrInvalidCategory)
+	}
+
+	existing, err := repository.GetCategoryByName(ctx, tx, tenantID, payload.Name)
+	if err == nil && existing.ID != categoryID {
+		return ErrCategoryExists
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	if payload.ParentID == nil {
+		return nil
+	}
+	categories, err := repository.GetCategories(ctx, tx, tenantID)
+	if err != nil {
+		return err
+	}
+	parents := make(map[int64]*int64, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+	if _, ok := parents[*payload.ParentID]; !ok {
+		return fmt.Errorf("%w: parent category %d does not exist", ErrInvalidCategory, *payload.ParentID)
+	}
+	for id := payload.ParentID; id != nil; id = parents[*id] {
+		if *id == categoryID {
+			return fmt.Errorf("%w: a category cannot be placed under itself or one of its subcategories", ErrInvalidCategory)
+		}
+	}
+	return nil
+}
+
+// resolveCategory finds the category a product or tag is put in, by ID or else by name. Unknown categories are
+// refused rather than created, so a typo cannot start a new category.
+func resolveCategory(ctx context.Context, tx *sql.Tx, tenantID string, categoryID *int64, name string) (*models.Category, error) {
+	var category *models.Category
+	var err error
+	switch {
+	case categoryID != nil:
+		category, err = repository.GetCategoryByID(ctx, tx, tenantID, *categoryID)
+	case strings.TrimSpace(name) != "":
+		category, err = repository.GetCategoryByName(ctx, tx, tenantID, strings.TrimSpace(name))
+	default:
+		return nil, fmt.Errorf("%w: category_id or main_category is required", ErrInvalidCategory)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		if categoryID != nil {
+			return nil, fmt.Errorf("%w: category %d does not exist", ErrInvalidCategory, *categoryID)
+		}
+		return nil, fmt.Errorf("%w: category %q does not exist", ErrInvalidCategory, name)
+	}
+	return category, err
+}
+
+// renameTaxCategory replaces a category name in the taxes of a tenant and reports whether any tax used it.
+func renameTaxCategory(config *models.TenantConfig, from, to string) bool {
+	changed := false
+	for i := range config.Taxes {
+		for j, name := range config.Taxes[i].Categories {
+			if strings.EqualFold(name, from) {
+				config.Taxes[i].Categories[j] = to
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// checkCategoryHours makes sure every ordered product is on the menu at the time the order is for: its category and
+// the ones above it must be visible and serving then.
+func checkCategoryHours(ctx context.Context, tx *sql.Tx, config *models.TenantConfig, order *models.Order, items []models.CartItem, now time.Time) error {
+	categories, err := repository.GetCategories(ctx, tx, order.TenantID)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int64]*models.Category, len(categories))
+	for i := range categories {
+		byID[categories[i].ID] = &categories[i]
+	}
+
+	at := now
+	if order.ScheduledFor != nil {
+		at = *order.ScheduledFor
+	}
+	at = at.In(tenantLocation(config))
+	for _, item := range items {
+		var category *models.Category
+		if item.CategoryID != nil {
+			category = byID[*item.CategoryID]
+		}
+		for ; category != nil; category = parentCategory(byID, category) {
+			if !category.IsVisible {
+				return unavailable("%s is not on the menu", item.Name)
+			}
+			if !servedAt(category, at) {
+				return unavailable("%s is only served between %s and %s", item.Name, *category.AvailableFrom, *category.AvailableUntil)
+			}
+		}
+	}
+	return nil
+}
+
+func parentCategory(byID map[int64]*models.Category, category *models.Category) *models.Category {
+	if category.ParentID == nil {
+		return nil
+	}
+	return byID[*category.ParentID]
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+// priceBreakdown is what a basket costs once the tenant's taxes, service charges and fees are applied to it.
+type priceBreakdown struct {
+	Charges  []models.Charge
+	TaxTotal models.Money
+	FeeTotal models.Money
+	Total    models.Money
+}
+
+// calculateCharges applies the taxes, service charges and fees of a tenant to priced items. The discount is spread
+// over the lines in proportion to their totals so every tax is charged on what the customer actually pays for the
+// items it covers. Service charges are a share of the discounted subtotal; neither they nor fees are taxed.
+func calculateCharges(config *models.TenantConfig, items []models.CartItem, subtotal, discount models.Money) priceBreakdown {
+	discounted := subtotal - discount
+	breakdown := priceBreakdown{Charges: make([]models.Charge, 0), Total: discounted}
+	if len(items) == 0 {
+		return breakdown
+	}
+
+	for _, tax := range config.Taxes {
+		var base models.Money
+		for i := range items {
+			if taxAppliesTo(tax, &items[i]) {
+				line := items[i].TotalPrice
+				base += line - discount.Share(line, subtotal, config.Rounding)
+			}
+		}
+		if base <= 0 {
+			continue
+		}
+
+		var amount models.Money
+		if tax.Inclusive {
+			amount = base.IncludedPercent(tax.Rate, config.Rounding)
+		} else {
+			amount = base.Percent(tax.Rate, config.Rounding)
+			breakdown.Total += amount
+		}
+		breakdown.TaxTotal += amount
+		breakdown.Charges = append(breakdown.Charges, models.Charge{
+			Kind:      models.ChargeTax,
+			Name:      tax.Name,
+			Rate:      &tax.Rate,
+			Inclusive: tax.Inclusive,
+			Amount:    amount,
+		})
+	}
+
+	for _, charge := range config.ServiceCharges {
+		amount := discounted.Percent(charge.Rate, config.Rounding)
+		if amount <= 0 {
+			continue
+		}
+		breakdown.FeeTotal += amount
+		breakdown.Total += amount
+		breakdown.Charges = append(breakdown.Charges, models.Charge{
+			Kind:   models.ChargeServiceCharge,
+			Name:   charge.Name,
+			Rate:   &charge.Rate,
+			Amount: amount,
+		})
+	}
+
+	for _, fee := range config.Fees {
+		breakdown.FeeTotal += fee.Amount
+		breakdown.Total += fee.Amount
+		breakdown.Charges = append(breakdown.Charges, models.Charge{
+			Kind:   models.ChargeFee,
+			Name:   fee.Name,
+			Amount: fee.Amount,
+		})
+	}
+	return breakdown
+}
+
+func taxAppliesTo(tax models.TaxRate, item *models.CartItem) bool {
+	if len(tax.Categories) == 0 {
+		return true
+	}
+	for _, category := range tax.Categories {
+		if strings.EqualFold(category, item.MainCategory) {
+			return true
+		}
+	}
+	return false
+}
+
+// chargeCart adds the tenant's charges to a cart whose discounts are already applied.
+func chargeCart(config *models.TenantConfig, cart *models.Cart) {
+	breakdown := calculateCharges(config, cart.Items, cart.Subtotal, cart.DiscountTotal)
+	cart.Charges = breakdown.Charges
+	cart.TaxTotal = breakdown.TaxTotal
+	cart.FeeTotal = breakdown.FeeTotal
+	cart.GrandTotal = breakdown.Total
+}
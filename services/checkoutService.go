@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var (
+	ErrCartEmpty                     = errors.New("your cart is empty")
+	ErrCheckoutAddressNotFound       = errors.New("delivery address not found")
+	ErrCheckoutPaymentMethodNotFound = errors.New("payment method not found")
+	ErrPriceChanged                  = errors.New("prices in your cart have changed since the items were added")
+	ErrOrderTotalChanged             = errors.New("your order total changed while the payment was being authorized; please check out again")
+)
+
+// PriceChangeError lists the cart lines whose price moved, so the customer can review them before accepting.
+type PriceChangeError struct {
+	Changes []models.CartPriceChange
+}
+
+func (e *PriceChangeError) Error() string {
+	return fmt.Sprintf("%s; check out again with accept_price_changes to pay the current prices", ErrPriceChanged)
+}
+
+func (e *PriceChangeError) Unwrap() error {
+	return ErrPriceChanged
+}
+
+func Checkout(ctx context.Context, userID int64, tenantID string, payload *models.CheckoutPayload) (*models.Order, error) {
+	var address *models.Address
+	if payload.AddressID != nil {
+		var err error
+		address, err = repository.GetAddressByIDAndUserID(ctx, *payload.AddressID, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrCheckoutAddressNotFound
+			}
+			return nil, err
+		}
+	}
+	var paymentMethod *models.PaymentMethod
+	if payload.PaymentMethodID != nil {
+		var err error
+		paymentMethod, err = repository.GetPaymentMethodByIDAndUserID(ctx, *payload.PaymentMethodID, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrCheckoutPaymentMethodNotFound
+			}
+			return nil, err
+		}
+		if paymentMethodExpired(paymentMethod, time.Now()) {
+			return nil, ErrPaymentMethodExpired
+		}
+	}
+
+	order := &models.Order{
+		UserID:          userID,
+		TenantID:        tenantID,
+		AddressID:       payload.AddressID,
+		PaymentMethodID: payload.PaymentMethodID,
+		FulfilmentType:  payload.FulfilmentType,
+		TableNumber:     payload.TableNumber,
+		ScheduledFor:    payload.ScheduledFor,
+	}
+	if order.FulfilmentType == "" {
+		order.FulfilmentType = models.FulfilmentPickup
+		if address != nil {
+			order.FulfilmentType = models.FulfilmentDelivery
+		}
+	}
+
+	// The total is authorized before the order is placed, so the cart is not locked while the gateway is waited on.
+	var authorization *models.PaymentTransaction
+	if paymentMethod != nil {
+		quote, err := quoteOrder(ctx, order, address, payload.AcceptPriceChanges)
+		if err != nil {
+			return nil, err
+		}
+		if quote.TotalPrice > 0 {
+			authorization, err = authorizeOrderPayment(ctx, userID, paymentMethod, quote.TotalPrice, quote.Currency)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	lowStockAlerts, err := checkoutCart(ctx, order, address, payload.AcceptPriceChanges, authorization)
+	if err != nil {
+		if authorization != nil {
+			// The order was not placed, so the money reserved for it must be released again.
+			releaseAuthorization(ctx, authorization)
+		}
+		return nil, err
+	}
+	sendLowStockAlerts(ctx, tenantID, lowStockAlerts)
+	return order, nil
+}
+
+// quoteOrder prices a checkout by placing a copy of the order in a transaction that is rolled back again.
+func quoteOrder(ctx context.Context, order *models.Order, address *models.Address, acceptPriceChanges bool) (*models.Order, error) {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	cartID, err := lockCheckoutCart(ctx, tx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+	quote := *order
+	if _, err := placeOrder(ctx, tx, cartID, &quote, address, acceptPriceChanges); err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// checkoutCart places the order and stores its authorization, which must be for exactly the order total. A cart that
+// changed after it was quoted fails with ErrOrderTotalChanged.
+func checkoutCart(ctx context.Context, order *models.Order, address *models.Address, acceptPriceChanges bool, authorization *models.PaymentTransaction) ([]lowStockAlert, error) {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	cartID, err := lockCheckoutCart(ctx, tx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+	lowStockAlerts, err := placeOrder(ctx, tx, cartID, order, address, acceptPriceChanges)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.PaymentMethodID != nil {
+		var authorized models.Money
+		if authorization != nil {
+			authorized = authorization.Amount
+		}
+		if order.TotalPrice != authorized {
+			return nil, ErrOrderTotalChanged
+		}
+	}
+	if authorization != nil {
+		authorization.OrderID = &order.ID
+		authorization.ID, err = repository.CreatePaymentTransaction(ctx, tx, authorization)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lowStockAlerts, tx.Commit()
+}
+
+func lockCheckoutCart(ctx context.Context, tx *sql.Tx, userID int64) (int64, error) {
+	cartID, err := repository.GetCartIDForUpdate(ctx, tx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrCartEmpty
+	}
+	return cartID, err
+}
+
+// placeOrder turns a locked cart into the given order: it re-prices the items, checks the fulfilment type against the
+// delivery address and the requested time against the opening hours, applies the cart's promo code when the order
+// belongs to a user and the tenant's taxes and fees, stores the order with its items, reserves stock and empties the
+// cart. The order only needs its owner, tenant, fulfilment and scheduling details filled in; address is nil unless one
+// was chosen. If the cart total moved since the items were added, the order is only placed when the customer accepted
+// the price changes.
+func placeOrder(ctx context.Context, tx *sql.Tx, cartID int64, order *models.Order, address *models.Address, acceptPriceChanges bool) ([]lowStockAlert, error) {
+	cartItems, err := repository.GetCheckoutItems(ctx, tx, cartID, order.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cartItems) == 0 {
+		return nil, ErrCartEmpty
+	}
+	if err := validateCartSelections(ctx, cartItems); err != nil {
+		return nil, err
+	}
+
+	config, err := GetTenantConfig(ctx, order.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	subtotal := priceCartItems(cartItems)
+	if !acceptPriceChanges && subtotal != snapshotSubtotal(cartItems) {
+		return nil, &PriceChangeError{Changes: cartPriceChanges(cartItems)}
+	}
+	zone, err := checkFulfilment(config, order, address, subtotal)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if err := checkSchedule(ctx, tx, config, order, now); err != nil {
+		return nil, err
+	}
+	if err := checkCategoryHours(ctx, tx, config, order, cartItems, now); err != nil {
+		return nil, err
+	}
+	if address != nil {
+		snapshot := *address
+		snapshot.IsDefault = false
+		order.DeliveryAddress = &snapshot
+	}
+
+	order.Status = models.OrderStatusPending
+	order.Currency = config.Currency
+	order.CreatedAt = time.Now().UTC()
+	order.Items = make([]models.OrderItem, 0, len(cartItems))
+	for _, cartItem := range cartItems {
+		productID := cartItem.ProductID
+		item := models.OrderItem{
+			ProductID: &productID,
+			Name:      cartItem.Name,
+			ImageURL:  cartItem.ImageURL,
+			Quantity:  cartItem.Quantity,
+			BasePrice: cartItem.BasePrice,
+			Price:     cartItem.UnitPrice,
+			LineTotal: cartItem.TotalPrice,
+			Options:   make([]models.OrderItemOption, 0, len(cartItem.Options)),
+		}
+		for _, opt := range cartItem.Options {
+			optionID := opt.ID
+			item.Options = append(item.Options, models.OrderItemOption{OptionID: &optionID, Name: opt.Name, PriceModifier: opt.PriceModifier})
+		}
+		order.Items = append(order.Items, item)
+	}
+
+	var promotion *models.Promotion
+	if order.UserID != 0 {
+		promotionID, err := repository.GetCartPromotionID(ctx, tx, order.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if promotionID.Valid {
+			promotion, err = repository.GetPromotionForUpdate(ctx, tx, promotionID.Int64)
+			if err != nil {
+				return nil, err
+			}
+			order.DiscountTotal, err = calculatePromotionDiscount(ctx, tx, promotion, order.UserID, cartItems, subtotal)
+			if err != nil {
+				return nil, err
+			}
+			order.PromoCode = promotion.Code
+		}
+	}
+	breakdown := calculateCharges(config, cartItems, subtotal, order.DiscountTotal)
+	addDeliveryFee(&breakdown, zone)
+	order.Charges = breakdown.Charges
+	order.TaxTotal = breakdown.TaxTotal
+	order.FeeTotal = breakdown.FeeTotal
+	order.TotalPrice = breakdown.Total
+
+	order.ID, err = repository.CreateOrder(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	for i := range order.Charges {
+		if err := repository.CreateOrderCharge(ctx, tx, order.ID, &order.Charges[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range order.Items {
+		item := &order.Items[i]
+		item.ID, err = repository.CreateOrderItem(ctx, tx, order.ID, item)
+		if err != nil {
+			return nil, err
+		}
+		for j := range item.Options {
+			item.Options[j].ID, err = repository.CreateOrderItemOption(ctx, tx, item.ID, &item.Options[j])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if promotion != nil {
+		redemption := &models.PromotionRedemption{
+			PromotionID:    promotion.ID,
+			UserID:         order.UserID,
+			OrderID:        order.ID,
+			Code:           promotion.Code,
+			DiscountAmount: order.DiscountTotal,
+		}
+		if err := repository.CreatePromotionRedemption(ctx, tx, redemption); err != nil {
+			return nil, err
+		}
+		if err := repository.SetCartPromotion(ctx, tx, cartID, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	change := &models.OrderStatusChange{ToStatus: order.Status}
+	if order.UserID != 0 {
+		change.ChangedBy = &order.UserID
+	}
+	if err := repository.CreateOrderStatusChange(ctx, tx, order.ID, change); err != nil {
+		return nil, err
+	}
+
+	lowStockAlerts, err := reserveOrderStock(ctx, tx, order, cartItems)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repository.ClearCart(ctx, tx, cartID); err != nil {
+		return nil, err
+	}
+	return lowStockAlerts, nil
+}
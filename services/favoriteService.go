@@ -15,6 +15,15 @@ func RemoveFromFavorites(ctx context.Context, userID, productID int64) error {
 	return repository.RemoveFromFavorites(ctx, userID, productID)
 }
 
-func GetFavorites(ctx context.Context, userID int64) ([]models.Product, error) {
-	return repository.GetFavorites(ctx, userID)
+// GetFavorites lists the user's favorite products, the most recently favorited first unless sorted otherwise.
+func GetFavorites(ctx context.Context, userID int64, req *models.PageRequest) (*models.Page[models.Product], error) {
+	page, err := resolvePage(req, productSortFields, "newest")
+	if err != nil {
+		return nil, err
+	}
+	favorites, err := repository.GetFavorites(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+	return finishPage(favorites, page), nil
 }
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+var (
+	ErrFulfilmentUnavailable   = errors.New("this store does not offer the chosen fulfilment type")
+	ErrDeliveryAddressRequired = errors.New("delivery orders need a delivery address")
+	ErrUnexpectedAddress       = errors.New("only delivery orders take a delivery address")
+	ErrTableNumberRequired     = errors.New("dine-in orders need a table number")
+	ErrAddressNotLocated       = errors.New("the delivery address has no coordinates; add its latitude and longitude")
+	ErrOutsideDeliveryArea     = errors.New("the delivery address is outside the delivery area")
+	ErrBelowDeliveryMinimum    = errors.New("the order is below the minimum for delivery to this address")
+	ErrInvalidDeliveryZone     = errors.New("invalid delivery zone")
+)
+
+// defaultFulfilmentTypes are offered by tenants that did not configure their own.
+var defaultFulfilmentTypes = []models.FulfilmentType{models.FulfilmentDelivery, models.FulfilmentPickup}
+
+const earthRadiusKm = 6371.0
+
+func offersFulfilment(config *models.TenantConfig, fulfilment models.FulfilmentType) bool {
+	offered := config.FulfilmentTypes
+	if len(offered) == 0 {
+		offered = defaultFulfilmentTypes
+	}
+	for _, t := range offered {
+		if t == fulfilment {
+			return true
+		}
+	}
+	return false
+}
+
+// checkFulfilment validates the fulfilment type of an order against the tenant's configuration and the delivery
+// address, which is nil when none was chosen. For deliveries into a zone it fills in the zone and its ETA and returns
+// the zone so its fee can be charged.
+func checkFulfilment(config *models.TenantConfig, order *models.Order, address *models.Address, subtotal models.Money) (*models.DeliveryZone, error) {
+	if !offersFulfilment(config, order.FulfilmentType) {
+		return nil, fmt.Errorf("%w: %s", ErrFulfilmentUnavailable, order.FulfilmentType)
+	}
+	if order.FulfilmentType != models.FulfilmentDelivery {
+		if address != nil {
+			return nil, ErrUnexpectedAddress
+		}
+		if order.FulfilmentType == models.FulfilmentDineIn && order.TableNumber == "" {
+			return nil, ErrTableNumberRequired
+		}
+		if order.FulfilmentType == models.FulfilmentPickup {
+			order.TableNumber = ""
+		}
+		return nil, nil
+	}
+
+	order.TableNumber = ""
+	if address == nil {
+		return nil, ErrDeliveryAddressRequired
+	}
+	if len(config.DeliveryZones) == 0 {
+		return nil, nil
+	}
+	if address.Latitude == nil || address.Longitude == nil {
+		return nil, ErrAddressNotLocated
+	}
+	zone := deliveryZoneFor(config, models.GeoPoint{Lat: *address.Latitude, Lng: *address.Longitude})
+	if zone == nil {
+		return nil, ErrOutsideDeliveryArea
+	}
+	if subtotal < zone.MinimumOrder {
+		return nil, fmt.Errorf("%w: delivery to %s starts at %s", ErrBelowDeliveryMinimum, zone.Name, zone.MinimumOrder.Format(config.Currency))
+	}
+	order.DeliveryZone = zone.Name
+	if zone.EtaMinutes > 0 {
+		eta := zone.EtaMinutes
+		order.EtaMinutes = &eta
+	}
+	return zone, nil
+}
+
+// deliveryZoneFor returns the first delivery zone containing point, or nil when the tenant does not deliver there.
+func deliveryZoneFor(config *models.TenantConfig, point models.GeoPoint) *models.DeliveryZone {
+	for i := range config.DeliveryZones {
+		zone := &config.DeliveryZones[i]
+		if len(zone.Polygon) > 0 {
+			if polygonContains(zone.Polygon, point) {
+				return zone
+			}
+		} else if config.Store != nil && distanceKm(*config.Store, point) <= zone.RadiusKm {
+			return zone
+		}
+	}
+	return nil
+}
+
+// distanceKm is the great-circle distance between two points.
+func distanceKm(a, b models.GeoPoint) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat, dLng := lat2-lat1, (b.Lng-a.Lng)*math.Pi/180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+// polygonContains casts a ray from point and counts the polygon edges it crosses. Delivery zones are small enough to
+// treat latitude and longitude as plane coordinates.
+func polygonContains(polygon []models.GeoPoint, point models.GeoPoint) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > point.Lat) != (b.Lat > point.Lat) &&
+			point.Lng < (b.Lng-a.Lng)*(point.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// validateDeliveryZones checks what the binding tags cannot: every zone is either a radius or a polygon, and radius
+// zones have a store to be measured from.
+func validateDeliveryZones(config *models.TenantConfig) error {
+	for _, zone := range config.DeliveryZones {
+		switch {
+		case zone.RadiusKm > 0 && len(zone.Polygon) > 0:
+			return fmt.Errorf("%w: %s has both a radius and a polygon", ErrInvalidDeliveryZone, zone.Name)
+		case zone.RadiusKm == 0 && len(zone.Polygon) == 0:
+			return fmt.Errorf("%w: %s needs a radius or a polygon", ErrInvalidDeliveryZone, zone.Name)
+		case zone.RadiusKm > 0 && config.Store == nil:
+			return fmt.Errorf("%w: %s is a radius zone but the store location is not set", ErrInvalidDeliveryZone, zone.Name)
+		}
+	}
+	return nil
+}
+
+// addDeliveryFee adds the fee of the delivery zone to a price breakdown.
+func addDeliveryFee(breakdown *priceBreakdown, zone *models.DeliveryZone) {
+	if zone == nil || zone.Fee <= 0 {
+		return
+	}
+	breakdown.FeeTotal += zone.Fee
+	breakdown.Total += zone.Fee
+	breakdown.Charges = append(breakdown.Charges, models.Charge{
+		Kind:   models.ChargeDeliveryFee,
+		Name:   zone.Name,
+		Amount: zone.Fee,
+	})
+}
@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+// guestCartTTL is how long a guest cart is kept after it was last changed.
+const guestCartTTL = 7 * 24 * time.Hour
+
+func newGuestCartToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("could not generate cart token: %w", err)
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+// lockGuestCart returns the locked guest cart behind a token, or sql.ErrNoRows when the token is empty, unknown or expired.
+func lockGuestCart(ctx context.Context, tx *sql.Tx, tenantID, token string) (int64, error) {
+	if token == "" {
+		return 0, sql.ErrNoRows
+	}
+	return repository.GetGuestCartID(ctx, tx, tenantID, hashToken(token))
+}
+
+// AddToGuestCart adds an item to the guest cart behind token. When the token is empty, unknown or expired a new cart is
+// started. The token of the cart that received the item is returned.
+func AddToGuestCart(ctx context.Context, tenantID, token string, payload *models.AddToCartPayload) (string, error) {
+	if err := validateOptionSelection(ctx, tenantID, payload.ProductID, payload.OptionIDs); err != nil {
+		return "", err
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	expiresAt := time.Now().UTC().Add(guestCartTTL)
+	cartID, err := lockGuestCart(ctx, tx, tenantID, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		if token, err = newGuestCartToken(); err != nil {
+			return "", err
+		}
+		cartID, err = repository.CreateGuestCart(ctx, tx, tenantID, hashToken(token), expiresAt)
+	} else if err == nil {
+		err = repository.ExtendGuestCart(ctx, tx, cartID, expiresAt)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := addCartItem(ctx, tx, cartID, tenantID, payload); err != nil {
+		return "", err
+	}
+	return token, tx.Commit()
+}
+
+// GetGuestCart returns the guest cart behind token. An unknown or expired token yields an empty cart.
+func GetGuestCart(ctx context.Context, tenantID, token string) (*models.Cart, error) {
+	config, err := GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []models.CartItem
+	cartID, err := lockGuestCart(ctx, nil, tenantID, token)
+	if err == nil {
+		items, err = repository.GetCartContentsByCartID(ctx, cartID)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	cart := newCart(config, items)
+	chargeCart(config, cart)
+	return cart, nil
+}
+
+func UpdateGuestCartItem(ctx context.Context, tenantID, token string, itemID int64, payload *models.UpdateCartItemPayload) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	cartID, err := lockGuestCart(ctx, tx, tenantID, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCartItemNotFound
+		}
+		return err
+	}
+	if err := updateCartItem(ctx, tx, cartID, tenantID, itemID, payload); err != nil {
+		return err
+	}
+	if err := repository.ExtendGuestCart(ctx, tx, cartID, time.Now().UTC().Add(guestCartTTL)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func RemoveGuestCartItem(ctx context.Context, tenantID, token string, itemID int64) error {
+	cartID, err := lockGuestCart(ctx, nil, tenantID, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCartItemNotFound
+		}
+		return err
+	}
+	rowsAffected, err := repository.RemoveCartItemByCartID(ctx, cartID, itemID)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrCartItemNotFound
+	}
+	return nil
+}
+
+// GuestCheckout places an order for the guest cart behind token. Guests pay on delivery; the contact details are kept
+// on the order and an order confirmation is e-mailed to them. The guest cart is gone afterwards.
+func GuestCheckout(ctx context.Context, tenantID, token string, payload *models.GuestCheckoutPayload) (*models.Order, error) {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	cartID, err := lockGuestCart(ctx, tx, tenantID, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCartEmpty
+		}
+		return nil, err
+	}
+
+	order := &models.Order{
+		TenantID:       tenantID,
+		GuestEmail:     payload.Email,
+		GuestPhone:     payload.Phone,
+		FulfilmentType: payload.FulfilmentType,
+		TableNumber:    payload.TableNumber,
+		ScheduledFor:   payload.ScheduledFor,
+	}
+	if order.FulfilmentType == "" {
+		order.FulfilmentType = models.FulfilmentPickup
+	}
+	lowStockAlerts, err := placeOrder(ctx, tx, cartID, order, nil, payload.AcceptPriceChanges)
+	if err != nil {
+		return nil, err
+	}
+	if err := repository.DeleteCart(ctx, tx, cartID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	sendLowStockAlerts(ctx, tenantID, lowStockAlerts)
+	notifyGuest(ctx, order, fmt.Sprintf("We received your order #%d", order.ID),
+		fmt.Sprintf("Thank you for your order #%d. The total of %s is paid at the store. We will keep you posted about its status at this address.", order.ID, order.TotalPrice.Format(order.Currency)))
+	return order, nil
+}
+
+// MergeGuestCart moves the items of a guest cart into the user's cart after they log in or register. Every line is added
+// as if the user added it now: it is checked against the stock, priced at the current prices and merged into a line
+// with the same options. Lines that can no longer be ordered are left out. The guest cart is deleted afterwards.
+func MergeGuestCart(ctx context.Context, userID int64, tenantID, token string) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	guestCartID, err := lockGuestCart(ctx, tx, tenantID, token)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if _, err := repository.FindOrCreateCartByUserID(ctx, tx, userID); err != nil {
+		return err
+	}
+	cartID, err := repository.GetCartIDForUpdate(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	guestLines, err := repository.GetAllCartLines(ctx, tx, guestCartID)
+	if err != nil {
+		return err
+	}
+	for _, guestLine := range guestLines {
+		item := &models.AddToCartPayload{ProductID: guestLine.ProductID, Quantity: guestLine.Quantity, OptionIDs: guestLine.OptionIDs}
+		if err := addCartItem(ctx, tx, cartID, tenantID, item); err != nil {
+			if errors.Is(err, ErrItemUnavailable) || errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrOptionNotFound) {
+				log.Printf("left product %d out of the cart of user %d merged from a guest cart: %v", guestLine.ProductID, userID, err)
+				continue
+			}
+			return err
+		}
+	}
+
+	if err := repository.DeleteCart(ctx, tx, guestCartID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// mergeGuestCartAfterLogin merges the guest cart into the user's cart without failing the login it belongs to.
+func mergeGuestCartAfterLogin(ctx context.Context, userID int64, tenantID, token string) {
+	if token == "" {
+		return
+	}
+	if err := MergeGuestCart(ctx, userID, tenantID, token); err != nil {
+		log.Printf("failed to merge guest cart into the cart of user %d: %v", userID, err)
+	}
+}
+
+// notifyGuest e-mails the customer of a guest order, who has no account to receive in-app notifications.
+// Failures are only logged.
+func notifyGuest(ctx context.Context, order *models.Order, subject, body string) {
+	if order.GuestEmail == "" {
+		return
+	}
+	if err := sendMail(ctx, MailMessage{To: order.GuestEmail, Subject: subject, Body: body}); err != nil {
+		log.Printf("failed to e-mail guest of order %d: %v", order.ID, err)
+	}
+}
+
+func DeleteExpiredGuestCarts(ctx context.Context, now time.Time) error {
+	deleted, err := repository.DeleteExpiredGuestCarts(ctx, now)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		log.Printf("deleted %d expired guest carts", deleted)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var (
+	ErrItemUnavailable = errors.New("item is not available")
+	ErrOptionNotFound  = errors.New("option not found")
+)
+
+const defaultLowStockThreshold = 5
+
+func init() {
+	OnOrderStatus(models.OrderStatusCancelled, releaseOrderStock)
+}
+
+type lowStockAlert struct {
+	name      string
+	remaining int
+}
+
+func unavailable(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrItemUnavailable, fmt.Sprintf(format, args...))
+}
+
+// checkStock reports whether quantity units can be taken from a stock level.
+func checkStock(level *models.StockLevel, quantity int) error {
+	if !level.IsAvailable {
+		return unavailable("%s is currently not available", level.Name)
+	}
+	if level.StockQuantity != nil && *level.StockQuantity < quantity {
+		if *level.StockQuantity == 0 {
+			return unavailable("%s is sold out", level.Name)
+		}
+		return unavailable("only %d of %s left", *level.StockQuantity, level.Name)
+	}
+	return nil
+}
+
+// checkItemAvailability makes sure a product and the chosen options can be ordered in the requested quantity.
+func checkItemAvailability(ctx context.Context, tenantID string, productID int64, optionIDs []int64, quantity int) error {
+	level, err := repository.GetProductStock(ctx, nil, tenantID, productID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		return err
+	}
+	if err := checkStock(level, quantity); err != nil {
+		return err
+	}
+	for _, optionID := range optionIDs {
+		level, err := repository.GetOptionStock(ctx, nil, productID, optionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrOptionNotFound
+			}
+			return err
+		}
+		if err := checkStock(level, quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reserveOrderStock takes the stock of every product and option in the order and records it against the order so
+// it can be released again. Stock rows are only locked here, all products and then all options, each in ID order, so
+// concurrent checkouts take their locks in the same order and cannot deadlock; reading the cart locks none of them.
+func reserveOrderStock(ctx context.Context, tx *sql.Tx, order *models.Order, items []models.CartItem) ([]lowStockAlert, error) {
+	productQuantities := make(map[int64]int)
+	optionQuantities := make(map[int64]int)
+	optionProducts := make(map[int64]int64)
+	for _, item := range items {
+		productQuantities[item.ProductID] += item.Quantity
+		for _, opt := range item.Options {
+			optionQuantities[opt.ID] += item.Quantity
+			optionProducts[opt.ID] = item.ProductID
+		}
+	}
+
+	var alerts []lowStockAlert
+	for _, productID := range sortedKeys(productQuantities) {
+		quantity := productQuantities[productID]
+		level, err := repository.GetProductStock(ctx, tx, order.TenantID, productID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkStock(level, quantity); err != nil {
+			return nil, err
+		}
+		if level.StockQuantity == nil {
+			continue
+		}
+		if err := repository.AdjustProductStock(ctx, tx, productID, -quantity); err != nil {
+			return nil, err
+		}
+		id := productID
+		if err := repository.CreateStockReservation(ctx, tx, order.ID, &models.StockReservation{ProductID: &id, Quantity: quantity}); err != nil {
+			return nil, err
+		}
+		alerts = appendLowStockAlert(alerts, level, quantity)
+	}
+
+	for _, optionID := range sortedKeys(optionQuantities) {
+		quantity := optionQuantities[optionID]
+		level, err := repository.GetOptionStock(ctx, tx, optionProducts[optionID], optionID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkStock(level, quantity); err != nil {
+			return nil, err
+		}
+		if level.StockQuantity == nil {
+			continue
+		}
+		if err := repository.AdjustOptionStock(ctx, tx, optionID, -quantity); err != nil {
+			return nil, err
+		}
+		id := optionID
+		if err := repository.CreateStockReservation(ctx, tx, order.ID, &models.StockReservation{OptionID: &id, Quantity: quantity}); err != nil {
+			return nil, err
+		}
+		alerts = appendLowStockAlert(alerts, level, quantity)
+	}
+	return alerts, nil
+}
+
+// appendLowStockAlert adds an alert when taking quantity units pushes the stock to or below its threshold.
+func appendLowStockAlert(alerts []lowStockAlert, level *models.StockLevel, quantity int) []lowStockAlert {
+	remaining := *level.StockQuantity - quantity
+	if remaining <= level.LowStockThreshold && *level.StockQuantity > level.LowStockThreshold {
+		alerts = append(alerts, lowStockAlert{name: level.Name, remaining: remaining})
+	}
+	return alerts
+}
+
+func sortedKeys(m map[int64]int) []int64 {
+	keys := make([]int64, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// releaseOrderStock puts the stock reserved by an order back when the order is cancelled.
+func releaseOrderStock(ctx context.Context, tx *sql.Tx, order *models.Order, change *models.OrderStatusChange) error {
+	reservations, err := repository.GetOpenStockReservations(ctx, tx, order.ID)
+	if err != nil {
+		return err
+	}
+	for _, r := range reservations {
+		if r.ProductID != nil {
+			if err := repository.AdjustProductStock(ctx, tx, *r.ProductID, r.Quantity); err != nil {
+				return err
+			}
+		}
+		if r.OptionID != nil {
+			if err := repository.AdjustOptionStock(ctx, tx, *r.OptionID, r.Quantity); err != nil {
+				return err
+			}
+		}
+	}
+	return repository.ReleaseStockReservations(ctx, tx, order.ID)
+}
+
+// sendLowStockAlerts notifies every admin of the tenant about stock that ran low. Failures are only logged.
+func sendLowStockAlerts(ctx context.Context, tenantID string, alerts []lowStockAlert) {
+	if len(alerts) == 0 {
+		return
+	}
+	adminIDs, err := repository.GetTenantAdminIDs(ctx, tenantID)
+	if err != nil {
+		log.Printf("failed to load admins of tenant %s for low stock alerts: %v", tenantID, err)
+		return
+	}
+	for _, alert := range alerts {
+		for _, adminID := range adminIDs {
+			notification := &models.Notification{
+				UserID:   adminID,
+				Title:    fmt.Sprintf("Only %d of %s left in stock", alert.remaining, alert.name),
+				Type:     "low_stock",
+				Metadata: models.RawJSONObject{"name": alert.name, "remaining": alert.remaining},
+			}
+			if err := repository.CreateNotification(ctx, notification); err != nil {
+				log.Printf("failed to send low stock alert to admin %d: %v", adminID, err)
+			}
+		}
+	}
+}
+
+func UpdateProductStock(ctx context.Context, tenantID string, productID int64, payload *models.StockPayload) error {
+	threshold := defaultLowStockThreshold
+	if payload.LowStockThreshold != nil {
+		threshold = *payload.LowStockThreshold
+	}
+	if _, err := repository.GetProductStock(ctx, nil, tenantID, productID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrProductNotFound
+		}
+		return err
+	}
+	return repository.UpdateProductStock(ctx, tenantID, productID, payload, threshold)
+}
+
+func UpdateOptionStock(ctx context.Context, tenantID string, optionID int64, payload *models.StockPayload) error {
+	exists, err := repository.OptionBelongsToTenant(ctx, tenantID, optionID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrOptionNotFound
+	}
+	return repository.UpdateOptionStock(ctx, tenantID, optionID, payload)
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/smtp"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional e-mails. The implementation is picked from MAIL_DRIVER by InitMailer and can be
+// swapped with SetMailer.
+type Mailer interface {
+	Send(ctx context.Context, msg MailMessage) error
+}
+
+var (
+	mailer     Mailer
+	mailerErr  error
+	mailerOnce sync.Once
+)
+
+func SetMailer(m Mailer) {
+	mailerOnce.Do(func() {})
+	mailer, mailerErr = m, nil
+}
+
+// InitMailer sets up the mailer from the environment. The server refuses to start when it fails, rather than logging
+// or dropping mails that carry reset tokens. A missing PASSWORD_RESET_URL only disables password resets for tenants
+// without a reset page of their own, so it is just warned about.
+func InitMailer() error {
+	mailerOnce.Do(func() {
+		mailer, mailerErr = newMailerFromEnv()
+		if _, err := defaultPasswordResetURL(); err != nil {
+			log.Printf("warning: %v; only tenants with a passwordResetUrl can send password reset links", err)
+		}
+	})
+	return mailerErr
+}
+
+func sendMail(ctx context.Context, msg MailMessage) error {
+	if err := InitMailer(); err != nil {
+		return err
+	}
+	return mailer.Send(ctx, msg)
+}
+
+// newMailerFromEnv builds the mailer named by MAIL_DRIVER. There is no default: the log driver prints reset tokens,
+// so it is only used when asked for explicitly.
+func newMailerFromEnv() (Mailer, error) {
+	switch driver := os.Getenv("MAIL_DRIVER"); driver {
+	case "smtp":
+		return &SMTPMailer{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     os.Getenv("SMTP_PORT"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("MAIL_FROM"),
+		}, nil
+	case "file":
+		dir := os.Getenv("MAIL_DIR")
+		if dir == "" {
+			dir = "mail_outbox"
+		}
+		return &FileMailer{Dir: dir}, nil
+	case "log":
+		return &LogMailer{}, nil
+	case "":
+		return nil, errors.New("MAIL_DRIVER is not set; use smtp, file or, for local development, log")
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER %q; use smtp, file or log", driver)
+	}
+}
+
+// passwordResetURL returns the page of a tenant's storefront that password reset links point to: the tenant's own
+// reset page, or PASSWORD_RESET_URL when it has none.
+func passwordResetURL(ctx context.Context, tenantID string) (string, error) {
+	config, err := GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	if config.PasswordResetURL != "" {
+		return config.PasswordResetURL, nil
+	}
+	resetURL, err := defaultPasswordResetURL()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrPasswordResetUnavailable, err)
+	}
+	return resetURL, nil
+}
+
+func defaultPasswordResetURL() (string, error) {
+	resetURL := os.Getenv("PASSWORD_RESET_URL")
+	parsed, err := url.Parse(resetURL)
+	if resetURL == "" || err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("PASSWORD_RESET_URL must be set to the absolute URL of the reset password page, got %q", resetURL)
+	}
+	return resetURL, nil
+}
+
+// LogMailer prints messages to the application log instead of sending them. It is meant for local development only,
+// as reset links end up in the log.
+type LogMailer struct{}
+
+func (m *LogMailer) Send(ctx context.Context, msg MailMessage) error {
+	log.Printf("mail to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// FileMailer writes every message to its own file in Dir so it can be inspected while developing.
+type FileMailer struct {
+	Dir string
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg MailMessage) error {
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), strings.NewReplacer("@", "_at_", "/", "_").Replace(msg.To))
+	content := fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s\r\n", msg.To, msg.Subject, msg.Body)
+	return os.WriteFile(filepath.Join(m.Dir, name), []byte(content), 0o644)
+}
+
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, msg MailMessage) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+	content := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n", m.From, msg.To, msg.Subject, msg.Body)
+	return smtp.SendMail(m.Host+":"+m.Port, auth, m.From, []string{msg.To}, []byte(content))
+}
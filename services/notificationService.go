@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/AryaTabani/Dorivo/models"
 	"github.com/AryaTabani/Dorivo/repository"
@@ -21,18 +22,32 @@ func CreateOrderStatusNotification(ctx context.Context, userID, orderID int64, t
 	return repository.CreateNotification(ctx, notification)
 }
 
-func GetMyNotifications(ctx context.Context, userID int64) ([]models.Notification, error) {
-	notificationsPtr, err := repository.GetNotificationsByUserID(ctx, userID)
+func CreateRefundNotification(ctx context.Context, userID, orderID int64, amount models.Money, currency string) error {
+	metadata := models.RawJSONObject{
+		"order_id": orderID,
+		"amount":   amount,
+		"currency": currency,
+	}
+
+	notification := &models.Notification{
+		UserID:   userID,
+		Title:    fmt.Sprintf("A refund of %s has been issued for your order", amount.Format(currency)),
+		Type:     "refund",
+		Metadata: metadata,
+	}
+	return repository.CreateNotification(ctx, notification)
+}
+
+func GetMyNotifications(ctx context.Context, userID int64, req *models.PageRequest) (*models.Page[models.Notification], error) {
+	page, err := resolvePage(req, notificationSortFields, "newest")
 	if err != nil {
 		return nil, err
 	}
-	notifications := make([]models.Notification, len(notificationsPtr))
-	for i, n := range notificationsPtr {
-		if n != nil {
-			notifications[i] = *n
-		}
+	notifications, err := repository.GetNotificationsByUserID(ctx, userID, page)
+	if err != nil {
+		return nil, err
 	}
-	return notifications, nil
+	return finishPage(notifications, page), nil
 }
 
 func MarkAsRead(ctx context.Context, userID int64, notificationIDs []int64) error {
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var (
+	ErrOptionGroupNotFound = errors.New("option group not found")
+	ErrInvalidOptionGroup  = errors.New("invalid option group")
+)
+
+func invalidOptionGroup(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidOptionGroup, fmt.Sprintf(format, args...))
+}
+
+// normalizeOptionGroupPayload checks the selection limits of a group and makes them consistent: a single choice group
+// allows at most one option, and a group is required exactly when at least one selection is needed.
+func normalizeOptionGroupPayload(payload *models.OptionGroupPayload) error {
+	if payload.SelectionType == models.SelectionSingle {
+		if payload.MaxSelections == nil {
+			one := 1
+			payload.MaxSelections = &one
+		}
+		if *payload.MaxSelections != 1 {
+			return invalidOptionGroup("a single choice group allows exactly one selection")
+		}
+	}
+	if payload.IsRequired && payload.MinSelections == 0 {
+		payload.MinSelections = 1
+	}
+	payload.IsRequired = payload.MinSelections > 0
+	if payload.MaxSelections != nil && payload.MinSelections > *payload.MaxSelections {
+		return invalidOptionGroup("min_selections cannot be greater than max_selections")
+	}
+	return nil
+}
+
+// checkOptionCount makes sure a group that has options offers enough of them to reach its minimum. A group without
+// options is allowed so admins can create it first and fill it afterwards.
+func checkOptionCount(minSelections int, options []models.Option) error {
+	if len(options) > 0 && minSelections > len(options) {
+		return invalidOptionGroup("the group needs at least %d options", minSelections)
+	}
+	return nil
+}
+
+// checkDefaultOptions makes sure no more options are selected by default than the group allows.
+func checkDefaultOptions(maxSelections *int, options []models.Option) error {
+	defaults := 0
+	for _, opt := range options {
+		if opt.IsDefault {
+			defaults++
+		}
+	}
+	if maxSelections != nil && defaults > *maxSelections {
+		return invalidOptionGroup("at most %d options can be selected by default", *maxSelections)
+	}
+	return nil
+}
+
+func getTenantOptionGroup(ctx context.Context, tx *sql.Tx, tenantID string, productID, groupID int64) (*models.OptionGroup, error) {
+	group, err := repository.GetOptionGroup(ctx, tx, tenantID, productID, groupID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOptionGroupNotFound
+		}
+		return nil, err
+	}
+	return group, nil
+}
+
+func findGroupOption(group *models.OptionGroup, optionID int64) (int, bool) {
+	for i, opt := range group.Options {
+		if opt.ID == optionID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func GetOptionGroups(ctx context.Context, tenantID string, productID int64) ([]models.OptionGroup, error) {
+	exists, err := repository.ProductExists(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrProductNotFound
+	}
+	return repository.GetOptionGroupsByProductID(ctx, productID)
+}
+
+func CreateOptionGroup(ctx context.Context, tenantID string, productID int64, payload *models.OptionGroupPayload) (*models.OptionGroup, error) {
+	if err := normalizeOptionGroupPayload(payload); err != nil {
+		return nil, err
+	}
+	options := make([]models.Option, len(payload.Options))
+	for i, opt := range payload.Options {
+		options[i] = models.Option{Name: opt.Name, IsDefault: opt.IsDefault}
+	}
+	if err := checkOptionCount(payload.MinSelections, options); err != nil {
+		return nil, err
+	}
+	if err := checkDefaultOptions(payload.MaxSelections, options); err != nil {
+		return nil, err
+	}
+
+	exists, err := repository.ProductExists(ctx, tenantID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrProductNotFound
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	groupID, err := repository.CreateOptionGroup(ctx, tx, productID, payload)
+	if err != nil {
+		return nil, err
+	}
+	for i := range payload.Options {
+		if _, err := repository.CreateOption(ctx, tx, groupID, &payload.Options[i]); err != nil {
+			return nil, err
+		}
+	}
+	group, err := repository.GetOptionGroup(ctx, tx, tenantID, productID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// UpdateOptionGroup replaces the settings of a group. Options in the payload are ignored; they have their own endpoints.
+func UpdateOptionGroup(ctx context.Context, tenantID string, productID, groupID int64, payload *models.OptionGroupPayload) error {
+	if err := normalizeOptionGroupPayload(payload); err != nil {
+		return err
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	group, err := getTenantOptionGroup(ctx, tx, tenantID, productID, groupID)
+	if err != nil {
+		return err
+	}
+	if err := checkOptionCount(payload.MinSelections, group.Options); err != nil {
+		return err
+	}
+	if err := checkDefaultOptions(payload.MaxSelections, group.Options); err != nil {
+		return err
+	}
+	if err := repository.UpdateOptionGroup(ctx, tx, groupID, payload); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func DeleteOptionGroup(ctx context.Context, tenantID string, productID, groupID int64) error {
+	if _, err := getTenantOptionGroup(ctx, nil, tenantID, productID, groupID); err != nil {
+		return err
+	}
+	return repository.DeleteOptionGroup(ctx, groupID)
+}
+
+// saveGroupOption creates (optionID 0) or updates an option of a locked group after checking that its defaults still
+// fit the group. Marking an option as default in a single choice group moves the default to it.
+func saveGroupOption(ctx context.Context, tx *sql.Tx, group *models.OptionGroup, optionID int64, payload *models.OptionPayload) (int64, error) {
+	options := append([]models.Option(nil), group.Options...)
+	if payload.IsDefault && group.SelectionType == models.SelectionSingle {
+		for i := range options {
+			options[i].IsDefault = false
+		}
+	}
+	updated := models.Option{ID: optionID, Name: payload.Name, IsDefault: payload.IsDefault}
+	if i, ok := findGroupOption(group, optionID); ok {
+		options[i] = updated
+	} else {
+		options = append(options, updated)
+	}
+	if err := checkDefaultOptions(group.MaxSelections, options); err != nil {
+		return 0, err
+	}
+
+	if optionID == 0 {
+		id, err := repository.CreateOption(ctx, tx, group.ID, payload)
+		if err != nil {
+			return 0, err
+		}
+		optionID = id
+	} else if err := repository.UpdateOption(ctx, tx, optionID, payload); err != nil {
+		return 0, err
+	}
+
+	if payload.IsDefault && group.SelectionType == models.SelectionSingle {
+		if err := repository.ClearDefaultOptions(ctx, tx, group.ID, optionID); err != nil {
+			return 0, err
+		}
+	}
+	return optionID, nil
+}
+
+func CreateOption(ctx context.Context, tenantID string, productID, groupID int64, payload *models.OptionPayload) (*models.Option, error) {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	group, err := getTenantOptionGroup(ctx, tx, tenantID, productID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	optionID, err := saveGroupOption(ctx, tx, group, 0, payload)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &models.Option{
+		ID:            optionID,
+		OptionGroupID: groupID,
+		Name:          payload.Name,
+		PriceModifier: payload.PriceModifier,
+		IsDefault:     payload.IsDefault,
+		SortOrder:     payload.SortOrder,
+		IsAvailable:   payload.IsAvailable == nil || *payload.IsAvailable,
+		StockQuantity: payload.StockQuantity,
+	}, nil
+}
+
+func UpdateOption(ctx context.Context, tenantID string, productID, groupID, optionID int64, payload *models.OptionPayload) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	group, err := getTenantOptionGroup(ctx, tx, tenantID, productID, groupID)
+	if err != nil {
+		return err
+	}
+	if _, ok := findGroupOption(group, optionID); !ok {
+		return ErrOptionNotFound
+	}
+	if _, err := saveGroupOption(ctx, tx, group, optionID, payload); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func DeleteOption(ctx context.Context, tenantID string, productID, groupID, optionID int64) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	group, err := getTenantOptionGroup(ctx, tx, tenantID, productID, groupID)
+	if err != nil {
+		return err
+	}
+	if _, ok := findGroupOption(group, optionID); !ok {
+		return ErrOptionNotFound
+	}
+	if group.MinSelections > len(group.Options)-1 {
+		return invalidOptionGroup("the group needs at least %d options; lower min_selections or delete the group instead", group.MinSelections)
+	}
+	if err := repository.DeleteOption(ctx, tx, optionID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var ErrInvalidOptionSelection = errors.New("the selected options are not valid for this product")
+
+// OptionSelectionError lists every problem found in a selection so clients can point at the offending groups at once.
+type OptionSelectionError struct {
+	Problems []models.OptionSelectionProblem
+}
+
+func (e *OptionSelectionError) Error() string {
+	if len(e.Problems) == 1 {
+		return fmt.Sprintf("%s: %s", ErrInvalidOptionSelection, e.Problems[0].Message)
+	}
+	return fmt.Sprintf("%s: %d problems", ErrInvalidOptionSelection, len(e.Problems))
+}
+
+func (e *OptionSelectionError) Unwrap() error {
+	return ErrInvalidOptionSelection
+}
+
+// checkOptionSelection checks the chosen options against the option groups of their product: every option must belong
+// to the product and be chosen once, and every group must get between its min and max selections.
+func checkOptionSelection(groups []models.OptionGroup, optionIDs []int64) []models.OptionSelectionProblem {
+	optionGroup := make(map[int64]*models.OptionGroup)
+	for i := range groups {
+		for _, opt := range groups[i].Options {
+			optionGroup[opt.ID] = &groups[i]
+		}
+	}
+
+	problems := make([]models.OptionSelectionProblem, 0)
+	chosen := make(map[int64]bool, len(optionIDs))
+	counts := make(map[int64]int, len(groups))
+	for _, optionID := range optionIDs {
+		group, ok := optionGroup[optionID]
+		if !ok {
+			problems = append(problems, models.OptionSelectionProblem{OptionID: optionID, Message: fmt.Sprintf("option %d does not belong to this product", optionID)})
+			continue
+		}
+		if chosen[optionID] {
+			problems = append(problems, models.OptionSelectionProblem{GroupID: group.ID, GroupName: group.Name, OptionID: optionID, Message: fmt.Sprintf("option %d was selected more than once", optionID)})
+			continue
+		}
+		chosen[optionID] = true
+		counts[group.ID]++
+	}
+
+	for _, group := range groups {
+		count := counts[group.ID]
+		minSelections := group.MinSelections
+		if group.IsRequired && minSelections == 0 {
+			minSelections = 1
+		}
+		problem := models.OptionSelectionProblem{GroupID: group.ID, GroupName: group.Name}
+		switch {
+		case group.SelectionType == models.SelectionSingle && count > 1:
+			problem.Message = fmt.Sprintf("choose only one option for %s", group.Name)
+		case count < minSelections && minSelections == 1:
+			problem.Message = fmt.Sprintf("%s is required", group.Name)
+		case count < minSelections:
+			problem.Message = fmt.Sprintf("choose at least %d options for %s", minSelections, group.Name)
+		case group.MaxSelections != nil && count > *group.MaxSelections:
+			problem.Message = fmt.Sprintf("choose at most %d options for %s", *group.MaxSelections, group.Name)
+		default:
+			continue
+		}
+		problems = append(problems, problem)
+	}
+	return problems
+}
+
+// validateOptionSelection loads the option groups of a tenant's product and checks the chosen options against them.
+func validateOptionSelection(ctx context.Context, tenantID string, productID int64, optionIDs []int64) error {
+	groups, err := GetOptionGroups(ctx, tenantID, productID)
+	if err != nil {
+		return err
+	}
+	if problems := checkOptionSelection(groups, optionIDs); len(problems) > 0 {
+		return &OptionSelectionError{Problems: problems}
+	}
+	return nil
+}
+
+// validateCartSelections re-checks the options of every cart line against the current option groups, which may have
+// changed since the items were added.
+func validateCartSelections(ctx context.Context, items []models.CartItem) error {
+	groupsByProduct := make(map[int64][]models.OptionGroup)
+	problems := make([]models.OptionSelectionProblem, 0)
+	for _, item := range items {
+		groups, ok := groupsByProduct[item.ProductID]
+		if !ok {
+			var err error
+			groups, err = repository.GetOptionGroupsByProductID(ctx, item.ProductID)
+			if err != nil {
+				return err
+			}
+			groupsByProduct[item.ProductID] = groups
+		}
+
+		optionIDs := make([]int64, len(item.Options))
+		for i, opt := range item.Options {
+			optionIDs[i] = opt.ID
+		}
+		for _, problem := range checkOptionSelection(groups, optionIDs) {
+			problem.CartItemID = item.ID
+			problem.Message = fmt.Sprintf("%s: %s", item.Name, problem.Message)
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		return &OptionSelectionError{Problems: problems}
+	}
+	return nil
+}
@@ -17,41 +17,41 @@ var (
 	ErrReviewExists           = errors.New("a review for this order already exists")
 )
 
-func GetMyOrders(ctx context.Context, userID int64, status string) ([]models.OrderSummaryView, error) {
-	if status == "" {
-		status = "Active"
+func GetMyOrders(ctx context.Context, userID int64, statusFilter []string, req *models.PageRequest) (*models.Page[models.OrderSummaryView], error) {
+	statuses, err := ParseOrderStatusFilter(statusFilter)
+	if err != nil {
+		return nil, err
+	}
+	page, err := resolvePage(req, orderSortFields, "newest")
+	if err != nil {
+		return nil, err
+	}
+	orders, err := repository.GetOrdersByUserID(ctx, userID, statuses, page)
+	if err != nil {
+		return nil, err
 	}
-	return repository.GetOrdersByUserID(ctx, userID, status)
+	return finishPage(orders, page), nil
 }
 
 func CancelOrder(ctx context.Context, userID, orderID int64, reason string) error {
-	order, err := repository.GetOrderByIdAndUserID(ctx, orderID, userID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+	check := func(order *models.Order) error {
+		if order.UserID != userID {
 			return ErrOrderNotFound
 		}
-		return err
+		if !containsOrderStatus(customerCancellableStatuses, order.Status) {
+			return ErrOrderCannotBeCancelled
+		}
+		return nil
 	}
-
-	if order.Status != "Active" {
-		return ErrOrderCannotBeCancelled
+	write := func(tx *sql.Tx) error {
+		return repository.CreateCancellation(ctx, tx, userID, orderID, reason)
 	}
-
-	tx, err := repository.BeginTx(ctx)
+	order, err := moveOrder(ctx, orderID, models.OrderStatusCancelled, &userID, reason, check, write)
 	if err != nil {
 		return err
 	}
-	defer tx.Rollback()
-
-	if err := repository.CreateCancellation(ctx, tx, userID, orderID, reason); err != nil {
-		return err
-	}
-
-	if err := repository.UpdateOrderStatus(ctx, tx, orderID, "Cancelled"); err != nil {
-		return err
-	}
-
-	return tx.Commit()
+	notifyOrderStatusChange(ctx, order)
+	return nil
 }
 
 func LeaveReview(ctx context.Context, userID, orderID int64, payload *models.LeaveReviewPayload) error {
@@ -63,7 +63,7 @@ func LeaveReview(ctx context.Context, userID, orderID int64, payload *models.Lea
 		return err
 	}
 
-	if order.Status != "Completed" {
+	if order.Status != models.OrderStatusCompleted {
 		return ErrOrderNotCompleted
 	}
 
@@ -77,3 +77,89 @@ func LeaveReview(ctx context.Context, userID, orderID int64, payload *models.Lea
 
 	return repository.CreateReview(ctx, userID, orderID, payload.Rating, payload.Comment)
 }
+
+func GetOrderDetails(ctx context.Context, userID, orderID int64) (*models.OrderDetails, error) {
+	order, err := repository.GetOrderByIdAndUserID(ctx, orderID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return loadOrderDetails(ctx, order)
+}
+
+func AdminGetOrderDetails(ctx context.Context, tenantID string, orderID int64) (*models.OrderDetails, error) {
+	order, err := repository.GetOrderByIDAndTenantID(ctx, orderID, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return loadOrderDetails(ctx, order)
+}
+
+func loadOrderDetails(ctx context.Context, order *models.Order) (*models.OrderDetails, error) {
+	items, err := repository.GetOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	order.Items = items
+	order.Charges, err = repository.GetOrderCharges(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &models.OrderDetails{Order: *order}
+	details.Subtotal = fillOrderItemTotals(details.Items)
+
+	if order.PaymentMethodID != nil {
+		method, err := repository.GetPaymentMethodByIDAndUserID(ctx, *order.PaymentMethodID, order.UserID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		details.PaymentMethod = method
+	}
+
+	details.CancellationReason, err = repository.GetCancellationReason(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := repository.GetReviewByOrderID(ctx, order.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	details.Review = review
+
+	details.StatusHistory, err = repository.GetOrderStatusHistory(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	details.Payments, err = repository.GetPaymentsByOrderID(ctx, nil, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	details.Refunds, err = repository.GetRefundsByOrderID(ctx, nil, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// fillOrderItemTotals derives the base price and line total of each stored order line and returns the order subtotal.
+func fillOrderItemTotals(items []models.OrderItem) models.Money {
+	var subtotal models.Money
+	for i := range items {
+		items[i].BasePrice = items[i].Price
+		for _, opt := range items[i].Options {
+			items[i].BasePrice -= opt.PriceModifier
+		}
+		items[i].LineTotal = items[i].Price.Times(items[i].Quantity)
+		subtotal += items[i].LineTotal
+	}
+	return subtotal
+}
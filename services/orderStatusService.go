@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var (
+	ErrInvalidOrderStatus      = errors.New("invalid order status")
+	ErrIllegalStatusTransition = errors.New("illegal order status transition")
+)
+
+// orderStatusTransitions is the order lifecycle: every status maps to the statuses it may move to next.
+// Statuses without outgoing transitions are final.
+var orderStatusTransitions = map[models.OrderStatus][]models.OrderStatus{
+	models.OrderStatusPending:        {models.OrderStatusAccepted, models.OrderStatusCancelled},
+	models.OrderStatusAccepted:       {models.OrderStatusPreparing, models.OrderStatusCancelled},
+	models.OrderStatusPreparing:      {models.OrderStatusReadyForPickup, models.OrderStatusOutForDelivery, models.OrderStatusCancelled},
+	models.OrderStatusReadyForPickup: {models.OrderStatusCompleted, models.OrderStatusCancelled},
+	models.OrderStatusOutForDelivery: {models.OrderStatusCompleted},
+	models.OrderStatusCompleted:      {models.OrderStatusRefunded},
+	models.OrderStatusCancelled:      {models.OrderStatusRefunded},
+	models.OrderStatusRefunded:       {},
+}
+
+// openOrderStatuses are the statuses of orders that are still being worked on. They are listed when no status filter is given.
+var openOrderStatuses = []models.OrderStatus{
+	models.OrderStatusPending,
+	models.OrderStatusAccepted,
+	models.OrderStatusPreparing,
+	models.OrderStatusReadyForPickup,
+	models.OrderStatusOutForDelivery,
+}
+
+// customerCancellableStatuses are the statuses in which a customer may still cancel their own order.
+var customerCancellableStatuses = []models.OrderStatus{
+	models.OrderStatusPending,
+	models.OrderStatusAccepted,
+}
+
+var orderStatusNotificationTitles = map[models.OrderStatus]string{
+	models.OrderStatusAccepted:       "Your order has been accepted",
+	models.OrderStatusPreparing:      "Your order is being prepared",
+	models.OrderStatusReadyForPickup: "Your order is ready for pickup",
+	models.OrderStatusOutForDelivery: "Your order is on its way",
+	models.OrderStatusCompleted:      "Your order has been completed",
+	models.OrderStatusCancelled:      "Your order has been cancelled",
+	models.OrderStatusRefunded:       "Your order has been refunded",
+}
+
+// OrderStatusHook runs inside the transaction that moves an order into the status it was registered for.
+// Returning an error aborts the whole status change.
+type OrderStatusHook func(ctx context.Context, tx *sql.Tx, order *models.Order, change *models.OrderStatusChange) error
+
+// OrderPaymentHook settles the payment of an order about to enter the status it was registered for. It runs before
+// the status change transaction and outside of it, so the payment gateway is never waited on with rows locked, and
+// must record its outcome itself. Returning an error aborts the status change.
+type OrderPaymentHook func(ctx context.Context, order *models.Order) error
+
+var (
+	orderStatusHooks  = map[models.OrderStatus][]OrderStatusHook{}
+	orderPaymentHooks = map[models.OrderStatus][]OrderPaymentHook{}
+)
+
+// OnOrderStatus registers a hook that runs whenever an order enters the given status.
+func OnOrderStatus(status models.OrderStatus, hook OrderStatusHook) {
+	orderStatusHooks[status] = append(orderStatusHooks[status], hook)
+}
+
+// BeforeOrderStatus registers a payment hook that runs before an order enters the given status.
+func BeforeOrderStatus(status models.OrderStatus, hook OrderPaymentHook) {
+	orderPaymentHooks[status] = append(orderPaymentHooks[status], hook)
+}
+
+func isValidOrderStatus(status models.OrderStatus) bool {
+	_, ok := orderStatusTransitions[status]
+	return ok
+}
+
+func canTransitionOrder(from, to models.OrderStatus) bool {
+	for _, next := range orderStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func containsOrderStatus(statuses []models.OrderStatus, status models.OrderStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOrderStatusFilter turns the raw status query values into a list of statuses. Each value may hold several
+// comma separated statuses; "Active" is accepted as shorthand for all open statuses and no value at all means the same.
+func ParseOrderStatusFilter(values []string) ([]models.OrderStatus, error) {
+	statuses := make([]models.OrderStatus, 0)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			if strings.EqualFold(part, "Active") {
+				statuses = append(statuses, openOrderStatuses...)
+				continue
+			}
+			status := models.OrderStatus(part)
+			if !isValidOrderStatus(status) {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidOrderStatus, part)
+			}
+			if !containsOrderStatus(statuses, status) {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	if len(statuses) == 0 {
+		return openOrderStatuses, nil
+	}
+	return statuses, nil
+}
+
+// checkOrderTransition reports whether an order may move into status to.
+func checkOrderTransition(order *models.Order, to models.OrderStatus) error {
+	if !isValidOrderStatus(to) {
+		return fmt.Errorf("%w: %s", ErrInvalidOrderStatus, to)
+	}
+	if !canTransitionOrder(order.Status, to) {
+		return fmt.Errorf("%w: an order cannot move from %s to %s", ErrIllegalStatusTransition, order.Status, to)
+	}
+	if to == models.OrderStatusOutForDelivery && order.FulfilmentType != models.FulfilmentDelivery {
+		return fmt.Errorf("%w: only delivery orders go out for delivery", ErrIllegalStatusTransition)
+	}
+	return nil
+}
+
+// moveOrder moves an order into status to. check vets the order for the caller before anything is changed and write
+// adds the caller's own changes to the status change transaction; both may be nil. The order's named lock is held
+// throughout, so the payment hooks of the new status can call the gateway without the order row being locked, and a
+// payment that was settled before a failed status change is found settled when the change is retried.
+func moveOrder(ctx context.Context, orderID int64, to models.OrderStatus, changedBy *int64, note string, check func(*models.Order) error, write func(*sql.Tx) error) (*models.Order, error) {
+	release, err := repository.LockOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	order, err := repository.GetOrderByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if check != nil {
+		if err := check(order); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkOrderTransition(order, to); err != nil {
+		return nil, err
+	}
+	for _, hook := range orderPaymentHooks[to] {
+		if err := hook(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	order, err = repository.GetOrderForUpdate(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if write != nil {
+		if err := write(tx); err != nil {
+			return nil, err
+		}
+	}
+	if err := changeOrderStatus(ctx, tx, order, to, changedBy, note); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// changeOrderStatus moves a locked order along the transition graph, records the change in its history and runs the
+// hooks registered for the new status. The order is updated in place. Payment hooks are not run here; callers moving
+// an order into a status that has them go through moveOrder.
+func changeOrderStatus(ctx context.Context, tx *sql.Tx, order *models.Order, to models.OrderStatus, changedBy *int64, note string) error {
+	if err := checkOrderTransition(order, to); err != nil {
+		return err
+	}
+
+	if err := repository.UpdateOrderStatus(ctx, tx, order.ID, to); err != nil {
+		return err
+	}
+
+	change := &models.OrderStatusChange{
+		FromStatus: order.Status,
+		ToStatus:   to,
+		ChangedBy:  changedBy,
+		Note:       note,
+	}
+	if err := repository.CreateOrderStatusChange(ctx, tx, order.ID, change); err != nil {
+		return err
+	}
+
+	order.Status = to
+	for _, hook := range orderStatusHooks[to] {
+		if err := hook(ctx, tx, order, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyOrderStatusChange tells the customer about a committed status change. A failed notification must not undo
+// the change, so errors are only logged.
+func notifyOrderStatusChange(ctx context.Context, order *models.Order) {
+	title, ok := orderStatusNotificationTitles[order.Status]
+	if !ok {
+		return
+	}
+	if order.UserID == 0 {
+		notifyGuest(ctx, order, fmt.Sprintf("Order #%d: %s", order.ID, title), fmt.Sprintf("%s. Order #%d is now %s.", title, order.ID, order.Status))
+		return
+	}
+	if err := CreateOrderStatusNotification(ctx, order.UserID, order.ID, title); err != nil {
+		log.Printf("failed to notify user %d about order %d: %v", order.UserID, order.ID, err)
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort field")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// sortFields lists the fields a list can be sorted by, each with whether it naturally sorts in descending order.
+type sortFields map[string]bool
+
+var (
+	productSortFields      = sortFields{"price": false, "rating": true, "newest": true, "name": false, "popularity": true}
+	orderSortFields        = sortFields{"newest": true, "price": false}
+	customerSortFields     = sortFields{"newest": true, "name": false}
+	notificationSortFields = sortFields{"newest": true}
+	// Keyword searches can also be sorted by relevance, which ties are then broken by the other fields.
+	searchSortFields = sortFields{"relevance": true, "price": false, "rating": true, "newest": true, "name": false, "popularity": true}
+)
+
+// pageCursor is what an opaque cursor holds: the sort it was made for and either the sort keys of the last item
+// handed out or, for lists paged in memory, how many items were handed out.
+type pageCursor struct {
+	Sort   string        `json:"s"`
+	After  []interface{} `json:"k,omitempty"`
+	Offset int           `json:"o,omitempty"`
+}
+
+// resolvePage validates a page request against the sort fields of a list. Only the first page counts the total.
+func resolvePage(req *models.PageRequest, fields sortFields, defaultSort string) (*models.PageQuery, error) {
+	page := &models.PageQuery{Limit: req.Limit}
+	if page.Limit <= 0 {
+		page.Limit = defaultPageSize
+	}
+	page.Limit = min(page.Limit, maxPageSize)
+
+	sortSpec := req.Sort
+	if strings.TrimSpace(sortSpec) == "" {
+		sortSpec = defaultSort
+	}
+	var canonical []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(sortSpec, ",") {
+		part = strings.TrimSpace(part)
+		name, reversed := strings.CutPrefix(part, "-")
+		desc, ok := fields[name]
+		if !ok || seen[name] {
+			return nil, fmt.Errorf("%w: %q; allowed fields are %s", ErrInvalidSort, part, fields)
+		}
+		seen[name] = true
+		page.Sort = append(page.Sort, models.SortField{Field: name, Desc: desc != reversed})
+		canonical = append(canonical, part)
+	}
+	page.SortKey = strings.Join(canonical, ",")
+
+	if req.Cursor == "" {
+		page.WithTotal = true
+		return page, nil
+	}
+	cursor, err := decodeCursor(req.Cursor)
+	if err != nil || cursor.Sort != page.SortKey || (cursor.After != nil && len(cursor.After) != len(page.Sort)+1) || cursor.Offset < 0 {
+		return nil, ErrInvalidCursor
+	}
+	page.After = cursor.After
+	page.Offset = cursor.Offset
+	return page, nil
+}
+
+func (f sortFields) String() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var cursor pageCursor
+	if err := decoder.Decode(&cursor); err != nil {
+		return nil, err
+	}
+	// Numbers go back to MySQL as their decimal text, which compares exactly with both integer and DECIMAL columns.
+	for i, v := range cursor.After {
+		switch v := v.(type) {
+		case json.Number:
+			cursor.After[i] = v.String()
+		case string:
+		default:
+			return nil, ErrInvalidCursor
+		}
+	}
+	return &cursor, nil
+}
+
+// finishPage turns the sort keys of the last item of a page into the cursor for the next one.
+func finishPage[T any](page *models.Page[T], query *models.PageQuery) *models.Page[T] {
+	if page.Pagination.HasMore {
+		page.Pagination.NextCursor = encodeCursor(pageCursor{Sort: query.SortKey, After: page.NextKey})
+	}
+	page.NextKey = nil
+	return page
+}
+
+// slicePage pages a list that was sorted in memory.
+func slicePage[T any](items []T, query *models.PageQuery) *models.Page[T] {
+	page := &models.Page[T]{Items: make([]T, 0), Pagination: models.Pagination{Limit: query.Limit}}
+	if query.WithTotal {
+		total := len(items)
+		page.Pagination.Total = &total
+	}
+	if query.Offset < len(items) {
+		end := min(query.Offset+query.Limit, len(items))
+		page.Items = items[query.Offset:end]
+		if end < len(items) {
+			page.Pagination.HasMore = true
+			page.Pagination.NextCursor = encodeCursor(pageCursor{Sort: query.SortKey, Offset: end})
+		}
+	}
+	return page
+}
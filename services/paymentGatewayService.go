@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+var (
+	ErrInvalidCardToken = errors.New("the payment processor did not recognise this card token")
+	ErrPaymentDeclined  = errors.New("the payment was declined")
+)
+
+type CardDetails struct {
+	Brand    string
+	LastFour string
+	ExpMonth int
+	ExpYear  int
+}
+
+// PaymentGateway talks to the payment processor. Card numbers never reach this service: the client tokenizes the card
+// with the processor and only the token is passed around. The implementation is picked from PAYMENT_GATEWAY by
+// InitPaymentGateway and can be swapped with SetPaymentGateway.
+type PaymentGateway interface {
+	// LookupCard returns the metadata of the card behind a processor token.
+	LookupCard(ctx context.Context, token string) (*CardDetails, error)
+	// Authorize reserves amount, in minor units of currency, on the card and returns the authorization reference.
+	Authorize(ctx context.Context, token string, amount models.Money, currency string) (string, error)
+	// Capture collects a previously authorized amount.
+	Capture(ctx context.Context, authorization string, amount models.Money) (string, error)
+	// Void releases an authorization that has not been captured.
+	Void(ctx context.Context, authorization string) (string, error)
+	// Refund returns amount of a captured authorization to the card.
+	Refund(ctx context.Context, authorization string, amount models.Money) (string, error)
+}
+
+var (
+	gateway     PaymentGateway
+	gatewayErr  error
+	gatewayOnce sync.Once
+)
+
+func SetPaymentGateway(g PaymentGateway) {
+	gatewayOnce.Do(func() {})
+	gateway, gatewayErr = g, nil
+}
+
+// InitPaymentGateway sets up the payment gateway from the environment. The server refuses to start when it fails, so a
+// misspelt gateway never ends up taking orders with the fake one.
+func InitPaymentGateway() error {
+	gatewayOnce.Do(func() {
+		gateway, gatewayErr = newPaymentGatewayFromEnv()
+	})
+	return gatewayErr
+}
+
+func paymentGateway() (PaymentGateway, error) {
+	if err := InitPaymentGateway(); err != nil {
+		return nil, err
+	}
+	return gateway, nil
+}
+
+// newPaymentGatewayFromEnv builds the gateway named by PAYMENT_GATEWAY. There is no default: the fake gateway approves
+// every payment without charging anything, so it is only used when asked for explicitly.
+func newPaymentGatewayFromEnv() (PaymentGateway, error) {
+	switch driver := os.Getenv("PAYMENT_GATEWAY"); driver {
+	case "fake":
+		return &FakePaymentGateway{}, nil
+	case "":
+		return nil, errors.New("PAYMENT_GATEWAY is not set; use fake for local development")
+	default:
+		return nil, fmt.Errorf("unknown PAYMENT_GATEWAY %q; use fake", driver)
+	}
+}
+
+type fakeCard struct {
+	details    CardDetails
+	declineMsg string
+}
+
+// fakeCards are the test tokens understood by FakePaymentGateway. A token may carry any suffix after an underscore
+// (e.g. tok_visa_alice) so several users can save the same test card.
+var fakeCards = map[string]fakeCard{
+	"tok_visa":              {details: CardDetails{Brand: "Visa", LastFour: "4242"}},
+	"tok_mastercard":        {details: CardDetails{Brand: "Mastercard", LastFour: "4444"}},
+	"tok_amex":              {details: CardDetails{Brand: "American Express", LastFour: "8431"}},
+	"tok_expired":           {details: CardDetails{Brand: "Visa", LastFour: "0069", ExpMonth: 12, ExpYear: 2020}},
+	"tok_chargeDeclined":    {details: CardDetails{Brand: "Visa", LastFour: "0002"}, declineMsg: "card declined"},
+	"tok_insufficientFunds": {details: CardDetails{Brand: "Visa", LastFour: "9995"}, declineMsg: "insufficient funds"},
+}
+
+// FakePaymentGateway is an in-process gateway for development. It never contacts a processor and keeps no state:
+// cards are resolved from fakeCards, and an authorization reference carries the authorized amount next to a random
+// part, so it stays valid across restarts and on serverless instances and can only be captured or refunded up to that
+// amount.
+type FakePaymentGateway struct{}
+
+func (g *FakePaymentGateway) card(token string) (*fakeCard, error) {
+	for key, card := range fakeCards {
+		if token == key || strings.HasPrefix(token, key+"_") {
+			card := card
+			if card.details.ExpYear == 0 {
+				card.details.ExpMonth = 12
+				card.details.ExpYear = time.Now().Year() + 3
+			}
+			return &card, nil
+		}
+	}
+	return nil, ErrInvalidCardToken
+}
+
+func (g *FakePaymentGateway) reference(kind string, amount models.Money) (string, error) {
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrProcessorFailed, err)
+	}
+	return fmt.Sprintf("fake_%s_%d_%s", kind, amount, hex.EncodeToString(random)), nil
+}
+
+// authorized returns the amount carried by an authorization reference issued by Authorize.
+func (g *FakePaymentGateway) authorized(authorization string) (models.Money, bool) {
+	parts := strings.Split(authorization, "_")
+	if len(parts) != 4 || parts[0] != "fake" || parts[1] != "auth" || len(parts[3]) != 16 {
+		return 0, false
+	}
+	if _, err := hex.DecodeString(parts[3]); err != nil {
+		return 0, false
+	}
+	amount, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+	return models.Money(amount), true
+}
+
+func (g *FakePaymentGateway) LookupCard(ctx context.Context, token string) (*CardDetails, error) {
+	card, err := g.card(token)
+	if err != nil {
+		return nil, err
+	}
+	return &card.details, nil
+}
+
+func (g *FakePaymentGateway) Authorize(ctx context.Context, token string, amount models.Money, currency string) (string, error) {
+	card, err := g.card(token)
+	if err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("%w: invalid amount", ErrProcessorFailed)
+	}
+	if card.declineMsg != "" {
+		return "", fmt.Errorf("%w: %s", ErrPaymentDeclined, card.declineMsg)
+	}
+	return g.reference("auth", amount)
+}
+
+func (g *FakePaymentGateway) Capture(ctx context.Context, authorization string, amount models.Money) (string, error) {
+	authorized, ok := g.authorized(authorization)
+	if !ok {
+		return "", fmt.Errorf("%w: unknown authorization %s", ErrProcessorFailed, authorization)
+	}
+	if amount <= 0 || amount > authorized {
+		return "", fmt.Errorf("%w: amount %d is outside the authorized %d", ErrProcessorFailed, amount, authorized)
+	}
+	return g.reference("capture", amount)
+}
+
+func (g *FakePaymentGateway) Void(ctx context.Context, authorization string) (string, error) {
+	authorized, ok := g.authorized(authorization)
+	if !ok {
+		return "", fmt.Errorf("%w: unknown authorization %s", ErrProcessorFailed, authorization)
+	}
+	return g.reference("void", authorized)
+}
+
+func (g *FakePaymentGateway) Refund(ctx context.Context, authorization string, amount models.Money) (string, error) {
+	authorized, ok := g.authorized(authorization)
+	if !ok {
+		return "", fmt.Errorf("%w: unknown authorization %s", ErrProcessorFailed, authorization)
+	}
+	if amount <= 0 || amount > authorized {
+		return "", fmt.Errorf("%w: amount %d is outside the authorized %d", ErrProcessorFailed, amount, authorized)
+	}
+	return g.reference("refund", amount)
+}
@@ -2,28 +2,33 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/AryaTabani/Dorivo/models"
 	"github.com/AryaTabani/Dorivo/repository"
 )
 
-var ErrPaymentMethodNotFound = errors.New("payment method not found or you do not have permission to delete it")
-var ErrProcessorFailed = errors.New("failed to validate token with payment processor")
+var ErrPaymentMethodNotFound = errors.New("payment method not found")
+var ErrPaymentMethodExpired = errors.New("this card has expired")
+var ErrProcessorFailed = errors.New("the payment processor could not complete the request")
 
-func AddPaymentMethod(ctx context.Context, userID int64, payload *models.AddPaymentMethodPayload) error {
+func init() {
+	BeforeOrderStatus(models.OrderStatusCompleted, captureOrderPayment)
+	BeforeOrderStatus(models.OrderStatusCancelled, voidOrderPayment)
+}
 
-	cardDetails := struct {
-		Brand    string
-		LastFour string
-		ExpMonth int
-		ExpYear  int
-	}{
-		Brand:    "Visa",
-		LastFour: "4242",
-		ExpMonth: int(time.Now().Month()),
-		ExpYear:  time.Now().Year() + 2,
+func AddPaymentMethod(ctx context.Context, userID int64, payload *models.AddPaymentMethodPayload) error {
+	processor, err := paymentGateway()
+	if err != nil {
+		return err
+	}
+	cardDetails, err := processor.LookupCard(ctx, payload.ProcessorToken)
+	if err != nil {
+		return err
 	}
 
 	newMethod := &models.PaymentMethod{
@@ -34,21 +39,331 @@ func AddPaymentMethod(ctx context.Context, userID int64, payload *models.AddPaym
 		ExpiryMonth:    cardDetails.ExpMonth,
 		ExpiryYear:     cardDetails.ExpYear,
 	}
+	if paymentMethodExpired(newMethod, time.Now()) {
+		return ErrPaymentMethodExpired
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	return repository.CreatePaymentMethod(ctx, newMethod)
+	// Locked so that of two cards added at once exactly one becomes the default.
+	if err := repository.LockPaymentMethods(ctx, tx, userID); err != nil {
+		return err
+	}
+	hasDefault, err := repository.HasDefaultPaymentMethod(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	newMethod.IsDefault = !hasDefault
+
+	if err := repository.CreatePaymentMethod(ctx, tx, newMethod); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func GetMyPaymentMethods(ctx context.Context, userID int64) ([]models.PaymentMethod, error) {
-	return repository.GetPaymentMethodsByUserID(ctx, userID)
+	methods, err := repository.GetPaymentMethodsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range methods {
+		methods[i].IsExpired = paymentMethodExpired(&methods[i], now)
+	}
+	return methods, nil
+}
+
+func SetDefaultPaymentMethod(ctx context.Context, userID, methodID int64) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := repository.LockPaymentMethods(ctx, tx, userID); err != nil {
+		return err
+	}
+	method, err := repository.GetPaymentMethodForUpdate(ctx, tx, methodID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPaymentMethodNotFound
+		}
+		return err
+	}
+	if paymentMethodExpired(method, time.Now()) {
+		return ErrPaymentMethodExpired
+	}
+
+	if err := repository.SetDefaultPaymentMethod(ctx, tx, userID, methodID); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func DeletePaymentMethod(ctx context.Context, userID, methodID int64) error {
-	rowsAffected, err := repository.DeletePaymentMethod(ctx, userID, methodID)
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := repository.LockPaymentMethods(ctx, tx, userID); err != nil {
+		return err
+	}
+	method, err := repository.GetPaymentMethodForUpdate(ctx, tx, methodID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPaymentMethodNotFound
+		}
+		return err
+	}
+
+	if _, err := repository.DeletePaymentMethod(ctx, tx, userID, methodID); err != nil {
+		return err
+	}
+
+	if method.IsDefault {
+		now := time.Now()
+		if err := repository.PromoteDefaultPaymentMethod(ctx, tx, userID, now.Year(), int(now.Month())); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// paymentMethodExpired reports whether a card is past its expiry. Cards stay valid until the end of their expiry month.
+func paymentMethodExpired(method *models.PaymentMethod, now time.Time) bool {
+	return method.ExpiryYear*12+method.ExpiryMonth < now.Year()*12+int(now.Month())
+}
+
+// NotifyExpiringPaymentMethods reminds users whose cards expire next month. Every card is only reminded about once.
+func NotifyExpiringPaymentMethods(ctx context.Context, now time.Time) error {
+	nextMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, 1, 0)
+	methods, err := repository.GetPaymentMethodsExpiringIn(ctx, nextMonth.Year(), int(nextMonth.Month()))
+	if err != nil {
+		return err
+	}
+
+	for _, method := range methods {
+		notification := &models.Notification{
+			UserID: method.UserID,
+			Title:  fmt.Sprintf("Your %s ending in %s expires next month", method.CardBrand, method.LastFour),
+			Type:   "payment_method_expiring",
+			Metadata: models.RawJSONObject{
+				"payment_method_id": method.ID,
+				"expiry_month":      method.ExpiryMonth,
+				"expiry_year":       method.ExpiryYear,
+			},
+		}
+		if err := repository.CreateNotification(ctx, notification); err != nil {
+			return err
+		}
+		if err := repository.MarkExpiryReminderSent(ctx, method.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authorizeOrderPayment reserves amount on the chosen card before the order it is for is placed. Only a failed attempt
+// is written to the ledger here; a successful authorization is stored together with the order.
+func authorizeOrderPayment(ctx context.Context, userID int64, method *models.PaymentMethod, amount models.Money, currency string) (*models.PaymentTransaction, error) {
+	entry := &models.PaymentTransaction{
+		UserID:          userID,
+		PaymentMethodID: &method.ID,
+		Operation:       models.PaymentAuthorize,
+		Status:          models.PaymentSucceeded,
+		Amount:          amount,
+	}
+	processor, err := paymentGateway()
+	if err != nil {
+		return nil, err
+	}
+	reference, err := processor.Authorize(ctx, method.ProcessorToken, amount, currency)
+	if err != nil {
+		return nil, recordPayment(ctx, entry, reference, err)
+	}
+	entry.GatewayReference = reference
+	return entry, nil
+}
+
+// releaseAuthorization voids an authorization whose checkout did not go through. The order does not exist, so there
+// is nothing to record it against and a failure can only be logged.
+func releaseAuthorization(ctx context.Context, authorization *models.PaymentTransaction) {
+	processor, err := paymentGateway()
+	if err == nil {
+		_, err = processor.Void(ctx, authorization.GatewayReference)
+	}
 	if err != nil {
+		log.Printf("failed to void authorization %s of rolled back checkout: %v", authorization.GatewayReference, err)
+	}
+}
+
+func captureOrderPayment(ctx context.Context, order *models.Order) error {
+	authorization, err := openAuthorization(ctx, nil, order.ID)
+	if err != nil || authorization == nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return ErrPaymentMethodNotFound
+	entry := &models.PaymentTransaction{
+		OrderID:         &order.ID,
+		UserID:          order.UserID,
+		PaymentMethodID: authorization.PaymentMethodID,
+		Operation:       models.PaymentCapture,
+		Amount:          authorization.Amount,
 	}
+	processor, err := paymentGateway()
+	if err != nil {
+		return err
+	}
+	reference, err := processor.Capture(ctx, authorization.GatewayReference, authorization.Amount)
+	return recordPayment(ctx, entry, reference, err)
+}
+
+func voidOrderPayment(ctx context.Context, order *models.Order) error {
+	authorization, err := openAuthorization(ctx, nil, order.ID)
+	if err != nil || authorization == nil {
+		return err
+	}
+	entry := &models.PaymentTransaction{
+		OrderID:         &order.ID,
+		UserID:          order.UserID,
+		PaymentMethodID: authorization.PaymentMethodID,
+		Operation:       models.PaymentVoid,
+		Amount:          authorization.Amount,
+	}
+	processor, err := paymentGateway()
+	if err != nil {
+		return err
+	}
+	reference, err := processor.Void(ctx, authorization.GatewayReference)
+	return recordPayment(ctx, entry, reference, err)
+}
+
+// refundOrderPayment gives amount of a captured order payment back to the card and returns the ledger entry ID.
+// Orders that were not paid by card have nothing to refund through the gateway and return nil.
+func refundOrderPayment(ctx context.Context, order *models.Order, amount models.Money) (*int64, error) {
+	authorization, err := capturedAuthorization(ctx, nil, order.ID)
+	if err != nil || authorization == nil {
+		return nil, err
+	}
+	entry := &models.PaymentTransaction{
+		OrderID:         &order.ID,
+		UserID:          order.UserID,
+		PaymentMethodID: authorization.PaymentMethodID,
+		Operation:       models.PaymentRefund,
+		Amount:          amount,
+	}
+	processor, err := paymentGateway()
+	if err != nil {
+		return nil, err
+	}
+	reference, err := processor.Refund(ctx, authorization.GatewayReference, amount)
+	if err := recordPayment(ctx, entry, reference, err); err != nil {
+		return nil, err
+	}
+	return &entry.ID, nil
+}
+
+// checkOrderCharged makes sure an order paid by card has had its payment captured. A cancelled card order whose
+// authorization was voided was never charged, so refunding it would record money that was never paid back. Orders
+// without a card payment are paid at the store.
+func checkOrderCharged(ctx context.Context, order *models.Order) error {
+	payments, err := repository.GetPaymentsByOrderID(ctx, nil, order.ID)
+	if err != nil {
+		return err
+	}
+	var authorized, captured bool
+	for _, p := range payments {
+		if p.Status != models.PaymentSucceeded {
+			continue
+		}
+		switch p.Operation {
+		case models.PaymentAuthorize:
+			authorized = true
+		case models.PaymentCapture:
+			captured = true
+		}
+	}
+	if authorized && !captured {
+		return fmt.Errorf("%w: %w", ErrOrderNotRefundable, ErrOrderNotPaid)
+	}
+	return nil
+}
+
+// capturedAuthorization returns the authorization of an order whose amount has been captured.
+func capturedAuthorization(ctx context.Context, tx *sql.Tx, orderID int64) (*models.PaymentTransaction, error) {
+	payments, err := repository.GetPaymentsByOrderID(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	var authorization, captured *models.PaymentTransaction
+	for i := range payments {
+		p := &payments[i]
+		if p.Status != models.PaymentSucceeded {
+			continue
+		}
+		switch p.Operation {
+		case models.PaymentAuthorize:
+			authorization = p
+		case models.PaymentCapture:
+			captured = authorization
+		}
+	}
+	return captured, nil
+}
+
+// openAuthorization returns the successful authorization of an order that has been neither captured nor voided yet.
+func openAuthorization(ctx context.Context, tx *sql.Tx, orderID int64) (*models.PaymentTransaction, error) {
+	payments, err := repository.GetPaymentsByOrderID(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	var authorization *models.PaymentTransaction
+	for i := range payments {
+		p := &payments[i]
+		if p.Status != models.PaymentSucceeded {
+			continue
+		}
+		switch p.Operation {
+		case models.PaymentAuthorize:
+			authorization = p
+		case models.PaymentCapture, models.PaymentVoid:
+			authorization = nil
+		}
+	}
+	return authorization, nil
+}
+
+// recordPayment writes the outcome of a gateway call to the ledger and returns the gateway error unchanged. It is
+// called outside of any transaction, so the entry is kept whatever becomes of the operation that made the call. A
+// failed attempt that cannot be recorded is only logged, so the caller still sees why the payment failed.
+func recordPayment(ctx context.Context, entry *models.PaymentTransaction, reference string, gatewayErr error) error {
+	if gatewayErr != nil {
+		entry.Status = models.PaymentFailed
+		entry.FailureReason = truncate(gatewayErr.Error(), 255)
+		if _, err := repository.CreatePaymentTransaction(ctx, nil, entry); err != nil {
+			log.Printf("failed to record failed %s for user %d: %v", entry.Operation, entry.UserID, err)
+		}
+		return gatewayErr
+	}
+
+	entry.Status = models.PaymentSucceeded
+	entry.GatewayReference = reference
+	id, err := repository.CreatePaymentTransaction(ctx, nil, entry)
+	if err != nil {
+		return err
+	}
+	entry.ID = id
 	return nil
 }
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/AryaTabani/Dorivo/models"
 	"github.com/AryaTabani/Dorivo/repository"
@@ -13,9 +14,87 @@ var ErrProductNotFound = errors.New("product not found")
 
 const BEST_SELLER_LIMIT = 10
 
-func SearchProducts(ctx context.Context, tenantID string, filters map[string][]string) ([]models.Product, error) {
-	return repository.SearchProducts(ctx, tenantID, filters)
+// SearchProducts lists a page of a tenant's products matching the filters. A "q" filter searches names, descriptions
+// and tags by keyword: the full-text index finds candidates, which are then kept only when every word of the query
+// matches, allowing for small typos, and ranked by where and how well they matched unless a sort is asked for. As
+// that happens in memory, keyword searches are paged in memory too. With facets=true the filter choices are counted
+// as well.
+func SearchProducts(ctx context.Context, tenantID string, filters map[string][]string, req *models.PageRequest) (*models.Page[models.Product], *models.ProductFacets, error) {
+	if req.Sort == "" && len(filters["sort_by"]) > 0 && filters["sort_by"][0] == "rating_desc" {
+		req.Sort = "rating"
+	}
+	withFacets := len(filters["facets"]) > 0 && filters["facets"][0] == "true"
+	var terms []string
+	if q := filters["q"]; len(q) > 0 {
+		terms = searchTerms(q[0])
+	}
+	if len(terms) == 0 {
+		delete(filters, "q")
+		page, err := resolvePage(req, productSortFields, "name")
+		if err != nil {
+			return nil, nil, err
+		}
+		products, err := repository.SearchProducts(ctx, tenantID, filters, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		var facets *models.ProductFacets
+		if withFacets {
+			if facets, err = repository.GetProductFacets(ctx, tenantID, filters, nil); err != nil {
+				return nil, nil, err
+			}
+		}
+		return finishPage(products, page), facets, nil
+	}
+
+	page, err := resolvePage(req, searchSortFields, "relevance")
+	if err != nil {
+		return nil, nil, err
+	}
+	byRelevance := page.Sort[0].Field == "relevance"
+	var sqlSort []models.SortField
+	for _, field := range page.Sort {
+		if field.Field != "relevance" {
+			sqlSort = append(sqlSort, field)
+		}
+	}
+	filters["q"] = []string{strings.Join(terms, " ")}
+	candidates, err := repository.SearchProducts(ctx, tenantID, filters, &models.PageQuery{Sort: sqlSort})
+	if err != nil {
+		return nil, nil, err
+	}
+	products := slicePage(rankSearchResults(candidates.Items, terms, !byRelevance), page)
+	if !withFacets {
+		return products, nil, nil
+	}
+	facets, err := keywordFacets(ctx, tenantID, filters, terms)
+	if err != nil {
+		return nil, nil, err
+	}
+	return products, facets, nil
 }
+
+// keywordFacets counts the filter choices of a keyword search. The keyword matches are worked out without the other
+// filters first, so that each facet can leave its own filter out.
+func keywordFacets(ctx context.Context, tenantID string, filters map[string][]string, terms []string) (*models.ProductFacets, error) {
+	candidates, err := repository.SearchProducts(ctx, tenantID, map[string][]string{"q": filters["q"]}, &models.PageQuery{})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0)
+	for _, product := range rankSearchResults(candidates.Items, terms, true) {
+		ids = append(ids, product.ID)
+	}
+
+	others := make(map[string][]string, len(filters))
+	for key, values := range filters {
+		if key != "q" {
+			others[key] = values
+		}
+	}
+	return repository.GetProductFacets(ctx, tenantID, others, ids)
+}
+
 func GetTags(ctx context.Context, tenantID string) ([]models.Tag, error) {
 	return repository.GetTags(ctx, tenantID)
 }
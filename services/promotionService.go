@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var (
+	ErrPromotionNotFound   = errors.New("promotion not found")
+	ErrPromotionCodeExists = errors.New("a promotion with this code already exists")
+	ErrPromotionInUse      = errors.New("this promotion has already been redeemed; deactivate it instead of deleting it")
+	ErrInvalidPromotion    = errors.New("invalid promotion")
+	ErrPromoNotApplicable  = errors.New("promo code cannot be applied")
+)
+
+func promoError(reason string) error {
+	return fmt.Errorf("%w: %s", ErrPromoNotApplicable, reason)
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func validatePromotionPayload(ctx context.Context, tenantID string, payload *models.PromotionPayload) error {
+	payload.Code = normalizePromoCode(payload.Code)
+	if payload.Code == "" {
+		return fmt.Errorf("%w: code must not be blank", ErrInvalidPromotion)
+	}
+
+	switch payload.Type {
+	case models.PromotionPercentage:
+		if payload.Value <= 0 || payload.Value > 100 {
+			return fmt.Errorf("%w: a percentage discount must be between 0 and 100", ErrInvalidPromotion)
+		}
+	case models.PromotionFixedAmount:
+		if payload.Amount == 0 && payload.Value > 0 {
+			currency, _, err := tenantMoney(ctx, tenantID)
+			if err != nil {
+				return err
+			}
+			payload.Amount = models.MinorUnits(payload.Value, currency)
+		}
+		payload.Value = 0
+		if payload.Amount <= 0 {
+			return fmt.Errorf("%w: a fixed discount must be greater than zero", ErrInvalidPromotion)
+		}
+	case models.PromotionFreeItem:
+		if payload.FreeProductID == nil {
+			return fmt.Errorf("%w: free_product_id is required for a free item promotion", ErrInvalidPromotion)
+		}
+		if _, err := GetProductDetails(ctx, tenantID, *payload.FreeProductID); err != nil {
+			if errors.Is(err, ErrProductNotFound) {
+				return fmt.Errorf("%w: free_product_id does not match a product of this store", ErrInvalidPromotion)
+			}
+			return err
+		}
+	}
+
+	if payload.StartsAt != nil && payload.EndsAt != nil && !payload.EndsAt.After(*payload.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidPromotion)
+	}
+	return nil
+}
+
+func CreatePromotion(ctx context.Context, tenantID string, payload *models.PromotionPayload) (int64, error) {
+	if err := validatePromotionPayload(ctx, tenantID, payload); err != nil {
+		return 0, err
+	}
+	_, err := repository.GetPromotionByCode(ctx, tenantID, payload.Code)
+	if err == nil {
+		return 0, ErrPromotionCodeExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+	if payload.IsActive == nil {
+		active := true
+		payload.IsActive = &active
+	}
+	return repository.CreatePromotion(ctx, tenantID, payload)
+}
+
+func UpdatePromotion(ctx context.Context, tenantID string, promotionID int64, payload *models.PromotionPayload) error {
+	promotion, err := repository.GetPromotionByID(ctx, nil, promotionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPromotionNotFound
+		}
+		return err
+	}
+	if promotion.TenantID != tenantID {
+		return ErrPromotionNotFound
+	}
+
+	if err := validatePromotionPayload(ctx, tenantID, payload); err != nil {
+		return err
+	}
+	existing, err := repository.GetPromotionByCode(ctx, tenantID, payload.Code)
+	if err == nil && existing.ID != promotionID {
+		return ErrPromotionCodeExists
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if payload.IsActive == nil {
+		payload.IsActive = &promotion.IsActive
+	}
+
+	_, err = repository.UpdatePromotion(ctx, tenantID, promotionID, payload)
+	return err
+}
+
+func DeletePromotion(ctx context.Context, tenantID string, promotionID int64) error {
+	promotion, err := repository.GetPromotionByID(ctx, nil, promotionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPromotionNotFound
+		}
+		return err
+	}
+	if promotion.TenantID != tenantID {
+		return ErrPromotionNotFound
+	}
+	if promotion.UsageCount > 0 {
+		return ErrPromotionInUse
+	}
+
+	rowsAffected, err := repository.DeletePromotion(ctx, tenantID, promotionID)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+func GetPromotions(ctx context.Context, tenantID string) ([]models.Promotion, error) {
+	return repository.GetPromotionsByTenant(ctx, tenantID)
+}
+
+func GetPromotionRedemptions(ctx context.Context, tenantID string, promotionID int64) ([]models.PromotionRedemption, error) {
+	return repository.GetPromotionRedemptions(ctx, tenantID, promotionID)
+}
+
+func ApplyPromoCode(ctx context.Context, userID int64, tenantID string, code string) (*models.Cart, error) {
+	promotion, err := repository.GetPromotionByCode(ctx, tenantID, normalizePromoCode(code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, promoError("this code does not exist")
+		}
+		return nil, err
+	}
+
+	items, err := repository.GetCartContentsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrCartEmpty
+	}
+	subtotal := priceCartItems(items)
+	if _, err := calculatePromotionDiscount(ctx, nil, promotion, userID, items, subtotal); err != nil {
+		return nil, err
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	cartID, err := repository.FindOrCreateCartByUserID(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := repository.SetCartPromotion(ctx, tx, cartID, &promotion.ID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return GetCart(ctx, userID, tenantID)
+}
+
+func RemovePromoCode(ctx context.Context, userID int64) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	cartID, err := repository.FindOrCreateCartByUserID(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+	if err := repository.SetCartPromotion(ctx, tx, cartID, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// calculatePromotionDiscount checks every rule of the promotion against the priced cart lines and returns the
+// discount it grants. Rule violations are reported as ErrPromoNotApplicable so callers can show them to the customer.
+func calculatePromotionDiscount(ctx context.Context, tx *sql.Tx, promotion *models.Promotion, userID int64, items []models.CartItem, subtotal models.Money) (models.Money, error) {
+	currency, rounding, err := tenantMoney(ctx, promotion.TenantID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	if !promotion.IsActive {
+		return 0, promoError("this code is no longer active")
+	}
+	if promotion.StartsAt != nil && now.Before(*promotion.StartsAt) {
+		return 0, promoError("this code is not valid yet")
+	}
+	if promotion.EndsAt != nil && now.After(*promotion.EndsAt) {
+		return 0, promoError("this code has expired")
+	}
+	if promotion.MaxUses != nil && promotion.UsageCount >= *promotion.MaxUses {
+		return 0, promoError("this code has reached its usage limit")
+	}
+	if promotion.MaxUsesPerUser != nil {
+		used, err := repository.CountUserRedemptions(ctx, tx, promotion.ID, userID)
+		if err != nil {
+			return 0, err
+		}
+		if used >= *promotion.MaxUsesPerUser {
+			return 0, promoError("you have already used this code the maximum number of times")
+		}
+	}
+	if promotion.MinBasket != nil && subtotal < *promotion.MinBasket {
+		return 0, promoError(fmt.Sprintf("a minimum basket of %s is required", promotion.MinBasket.Format(currency)))
+	}
+
+	var eligibleTotal, freeItemPrice models.Money
+	for _, item := range items {
+		if !promotionAppliesTo(promotion, &item) {
+			continue
+		}
+		eligibleTotal += item.TotalPrice
+		if promotion.FreeProductID != nil && item.ProductID == *promotion.FreeProductID && freeItemPrice == 0 {
+			freeItemPrice = item.UnitPrice
+		}
+	}
+	if eligibleTotal == 0 {
+		return 0, promoError("no item in your cart qualifies for this code")
+	}
+
+	var discount models.Money
+	switch promotion.Type {
+	case models.PromotionPercentage:
+		discount = eligibleTotal.Percent(promotion.Value, rounding)
+	case models.PromotionFixedAmount:
+		discount = min(promotion.Amount, eligibleTotal)
+	case models.PromotionFreeItem:
+		if freeItemPrice == 0 {
+			return 0, promoError("add the free item to your cart to use this code")
+		}
+		discount = freeItemPrice
+	default:
+		return 0, promoError("this code has an unknown discount type")
+	}
+
+	return min(discount, subtotal), nil
+}
+
+func promotionAppliesTo(promotion *models.Promotion, item *models.CartItem) bool {
+	if len(promotion.Categories) == 0 && len(promotion.Tags) == 0 {
+		return true
+	}
+	for _, category := range promotion.Categories {
+		if strings.EqualFold(category, item.MainCategory) {
+			return true
+		}
+	}
+	for _, tag := range promotion.Tags {
+		for _, itemTag := range item.Tags {
+			if strings.EqualFold(tag, itemTag) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var (
+	ErrOrderNotRefundable = errors.New("only completed or cancelled orders can be refunded")
+	ErrNothingToRefund    = errors.New("this order has already been fully refunded")
+	ErrOrderNotPaid       = errors.New("the card payment of this order was never captured, so nothing was charged")
+	ErrInvalidRefund      = errors.New("invalid refund")
+)
+
+// RefundOrder refunds the requested lines of a completed or cancelled order, or all of what is left when no lines are
+// given. Orders paid by card can only be refunded once their payment has been captured; orders paid at the store are
+// refunded there. A line is refunded its share of the order total, so the discount, taxes and fees are spread over the lines
+// the same way they were charged. Once nothing is left to refund the order moves to Refunded. The money is paid back
+// before the refunds are stored, under the order's named lock, so the order row is not locked while the gateway is
+// waited on.
+func RefundOrder(ctx context.Context, tenantID string, adminID, orderID int64, payload *models.RefundPayload) ([]models.Refund, error) {
+	release, err := repository.LockOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	order, err := repository.GetOrderByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if order.TenantID != tenantID {
+		return nil, ErrOrderNotFound
+	}
+	if err := checkOrderTransition(order, models.OrderStatusRefunded); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOrderNotRefundable, err)
+	}
+	if err := checkOrderCharged(ctx, order); err != nil {
+		return nil, err
+	}
+	remaining := order.TotalPrice - order.RefundedTotal
+	if remaining <= 0 && len(payload.Items) > 0 {
+		return nil, ErrNothingToRefund
+	}
+
+	var refunds []models.Refund
+	if len(payload.Items) == 0 {
+		if remaining > 0 {
+			refunds = []models.Refund{{Amount: remaining}}
+		}
+	} else {
+		refunds, err = lineRefunds(ctx, nil, order, payload.Items, remaining)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var total models.Money
+	for i := range refunds {
+		refunds[i].Reason = payload.Reason
+		refunds[i].RefundedBy = &adminID
+		total += refunds[i].Amount
+	}
+
+	var paymentID *int64
+	if total > 0 {
+		paymentID, err = refundOrderPayment(ctx, order, total)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := storeRefunds(ctx, orderID, refunds, paymentID, &adminID, payload.Reason); err != nil {
+		if paymentID != nil {
+			log.Printf("refund %d of order %d was paid out but could not be stored: %v", *paymentID, orderID, err)
+		}
+		return nil, err
+	}
+
+	if order.UserID == 0 {
+		notifyGuest(ctx, order, fmt.Sprintf("Refund for order #%d", order.ID), fmt.Sprintf("We refunded %s of your order #%d.", total.Format(order.Currency), order.ID))
+	} else if err := CreateRefundNotification(ctx, order.UserID, order.ID, total, order.Currency); err != nil {
+		log.Printf("failed to notify user %d about refund of order %d: %v", order.UserID, order.ID, err)
+	}
+	return refunds, nil
+}
+
+// storeRefunds writes refunds that have been paid out, under the ledger entry paymentID, and moves the order to
+// Refunded once nothing is left to refund.
+func storeRefunds(ctx context.Context, orderID int64, refunds []models.Refund, paymentID *int64, refundedBy *int64, reason string) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	order, err := repository.GetOrderForUpdate(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+	var total models.Money
+	for i := range refunds {
+		refunds[i].OrderID = order.ID
+		refunds[i].PaymentID = paymentID
+		id, err := repository.CreateRefund(ctx, tx, &refunds[i])
+		if err != nil {
+			return err
+		}
+		refunds[i].ID = id
+		total += refunds[i].Amount
+	}
+	if err := repository.AddOrderRefundedTotal(ctx, tx, order.ID, total); err != nil {
+		return err
+	}
+	order.RefundedTotal += total
+
+	if order.TotalPrice-order.RefundedTotal <= 0 {
+		if err := changeOrderStatus(ctx, tx, order, models.OrderStatusRefunded, refundedBy, reason); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func GetOrderRefunds(ctx context.Context, tenantID string, orderID int64) ([]models.Refund, error) {
+	if _, err := repository.GetOrderByIDAndTenantID(ctx, orderID, tenantID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return repository.GetRefundsByOrderID(ctx, nil, orderID)
+}
+
+// lineRefunds prices the requested line refunds, making sure no line is refunded more often than it was ordered.
+func lineRefunds(ctx context.Context, tx *sql.Tx, order *models.Order, requested []models.RefundItemPayload, remaining models.Money) ([]models.Refund, error) {
+	_, rounding, err := tenantMoney(ctx, order.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := repository.GetOrderItems(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	refunded, err := repository.GetRefundedQuantities(ctx, tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subtotal := fillOrderItemTotals(items)
+	itemsByID := make(map[int64]*models.OrderItem, len(items))
+	for i := range items {
+		itemsByID[items[i].ID] = &items[i]
+	}
+
+	refunds := make([]models.Refund, 0, len(requested))
+	var total models.Money
+	for _, req := range requested {
+		item, ok := itemsByID[req.OrderItemID]
+		if !ok {
+			return nil, fmt.Errorf("%w: order item %d does not belong to this order", ErrInvalidRefund, req.OrderItemID)
+		}
+		if refunded[item.ID]+req.Quantity > item.Quantity {
+			return nil, fmt.Errorf("%w: only %d of %q can still be refunded", ErrInvalidRefund, item.Quantity-refunded[item.ID], item.Name)
+		}
+		refunded[item.ID] += req.Quantity
+
+		amount := min(item.Price.Times(req.Quantity).Share(order.TotalPrice, subtotal, rounding), remaining-total)
+		total += amount
+		itemID := item.ID
+		refunds = append(refunds, models.Refund{OrderItemID: &itemID, Quantity: req.Quantity, Amount: amount})
+	}
+	return refunds, nil
+}
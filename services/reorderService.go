@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+// Reorder puts the lines of one of the user's past orders back into their cart, on top of what is already there.
+// Lines whose product or options were deleted, whose options no longer fit the product, that are out of stock or,
+// unless accepted, whose price changed are skipped and reported.
+func Reorder(ctx context.Context, userID int64, tenantID string, orderID int64, payload *models.ReorderPayload) (*models.ReorderResult, error) {
+	order, err := repository.GetOrderByIdAndUserID(ctx, orderID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if order.TenantID != tenantID {
+		return nil, ErrOrderNotFound
+	}
+	items, err := repository.GetOrderItems(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := repository.FindOrCreateCartByUserID(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+	cartID, err := repository.GetCartIDForUpdate(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := make([]models.SkippedReorderLine, 0)
+	for i := range items {
+		item := &items[i]
+		line, skip, err := reorderLine(ctx, tenantID, item, payload.AcceptPriceChanges)
+		if err == nil && skip == nil {
+			err = addCartItem(ctx, tx, cartID, tenantID, line)
+			switch {
+			case errors.Is(err, ErrItemUnavailable):
+				skip, err = &models.SkippedReorderLine{Reason: models.ReorderUnavailable, Message: err.Error()}, nil
+			case errors.Is(err, ErrProductNotFound):
+				skip, err = &models.SkippedReorderLine{Reason: models.ReorderProductDeleted, Message: fmt.Sprintf("%s is no longer sold", item.Name)}, nil
+			case errors.Is(err, ErrOptionNotFound):
+				skip, err = &models.SkippedReorderLine{Reason: models.ReorderOptionDeleted, Message: fmt.Sprintf("an option of %s is no longer offered", item.Name)}, nil
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if skip != nil {
+			skip.OrderItemID = item.ID
+			skip.Name = item.Name
+			skip.Quantity = item.Quantity
+			skip.OrderedPrice = item.Price
+			skipped = append(skipped, *skip)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	cart, err := GetCart(ctx, userID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ReorderResult{Cart: cart, Skipped: skipped}, nil
+}
+
+// reorderLine turns a past order line into a cart line, or explains why it cannot be ordered again.
+func reorderLine(ctx context.Context, tenantID string, item *models.OrderItem, acceptPriceChanges bool) (*models.AddToCartPayload, *models.SkippedReorderLine, error) {
+	if item.ProductID == nil {
+		return nil, &models.SkippedReorderLine{Reason: models.ReorderProductDeleted, Message: fmt.Sprintf("%s is no longer sold", item.Name)}, nil
+	}
+	line := &models.AddToCartPayload{ProductID: *item.ProductID, Quantity: item.Quantity, OptionIDs: make([]int64, 0, len(item.Options))}
+	for _, opt := range item.Options {
+		if opt.OptionID == nil {
+			return nil, &models.SkippedReorderLine{Reason: models.ReorderOptionDeleted, Message: fmt.Sprintf("%s is no longer offered for %s", opt.Name, item.Name)}, nil
+		}
+		line.OptionIDs = append(line.OptionIDs, *opt.OptionID)
+	}
+
+	err := validateOptionSelection(ctx, tenantID, line.ProductID, line.OptionIDs)
+	var selectionErr *OptionSelectionError
+	switch {
+	case errors.Is(err, ErrProductNotFound):
+		return nil, &models.SkippedReorderLine{Reason: models.ReorderProductDeleted, Message: fmt.Sprintf("%s is no longer sold", item.Name)}, nil
+	case errors.As(err, &selectionErr):
+		return nil, &models.SkippedReorderLine{Reason: models.ReorderOptionsChanged, Message: selectionErr.Problems[0].Message}, nil
+	case err != nil:
+		return nil, nil, err
+	}
+
+	price, err := repository.GetCurrentUnitPrice(ctx, tenantID, line.ProductID, line.OptionIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if price != item.Price && !acceptPriceChanges {
+		return nil, &models.SkippedReorderLine{
+			Reason:       models.ReorderPriceChanged,
+			Message:      fmt.Sprintf("the price of %s changed since this order", item.Name),
+			CurrentPrice: &price,
+		}, nil
+	}
+	return line, nil, nil
+}
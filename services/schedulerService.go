@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// StartBackgroundJobs runs the periodic maintenance jobs until ctx is cancelled. It is meant for long-running
+// deployments; serverless entry points do not start it.
+func StartBackgroundJobs(ctx context.Context) {
+	go runPeriodically(ctx, "payment method expiry reminders", 24*time.Hour, func(ctx context.Context) error {
+		return NotifyExpiringPaymentMethods(ctx, time.Now())
+	})
+	go runPeriodically(ctx, "expired guest cart cleanup", time.Hour, func(ctx context.Context) error {
+		return DeleteExpiredGuestCarts(ctx, time.Now().UTC())
+	})
+}
+
+// runPeriodically runs job once right away and then every interval. Failures are logged and retried on the next tick.
+func runPeriodically(ctx context.Context, name string, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := job(ctx); err != nil {
+			log.Printf("background job %q failed: %v", name, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
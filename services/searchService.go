@@ -0,0 +1,247 @@
+package services
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/AryaTabani/Dorivo/models"
+)
+
+const (
+	maxSearchTerms        = 10
+	highlightContextRunes = 60
+	maxFragmentRunes      = 160
+)
+
+// searchFolder folds the Arabic yeh, alef maksura and kaf into the Persian yeh and kaf, so text typed on either
+// keyboard layout matches. The repository folds the stored search text the same way.
+var searchFolder = strings.NewReplacer("ي", "ی", "ى", "ی", "ك", "ک")
+
+func normalizeSearchText(s string) string {
+	return searchFolder.Replace(strings.ToLower(s))
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+// searchTerms splits a keyword query into distinct normalized words.
+func searchTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, term := range strings.FieldsFunc(normalizeSearchText(query), func(r rune) bool { return !isWordRune(r) }) {
+		if !seen[term] && len(terms) < maxSearchTerms {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// searchWord is a word of a product field, located by rune offsets into the original text.
+type searchWord struct {
+	start, end int
+	normalized string
+}
+
+func splitWords(runes []rune) []searchWord {
+	var words []searchWord
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && isWordRune(runes[i]) {
+			i++
+		}
+		words = append(words, searchWord{start: start, end: i, normalized: normalizeSearchText(string(runes[start:i]))})
+	}
+	return words
+}
+
+// typoAllowance is how many edits a query term may be away from a word and still match it.
+func typoAllowance(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance is the optimal string alignment distance: insertions, deletions, substitutions and swaps of two
+// neighbouring characters each count as one edit.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev2 := make([]int, len(t)+1)
+	prev := make([]int, len(t)+1)
+	cur := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		cur[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(t)]
+}
+
+// termMatch scores how well a query term matches a word: 1 for the same word, less for a word the term starts or
+// that is a small typo away, and 0 for no match.
+func termMatch(term, word string) float64 {
+	if term == word {
+		return 1
+	}
+	if strings.HasPrefix(word, term) && len([]rune(term)) >= 2 {
+		return 0.8
+	}
+	allowed := typoAllowance(term)
+	if allowed == 0 {
+		return 0
+	}
+	if diff := len([]rune(term)) - len([]rune(word)); diff > allowed || -diff > allowed {
+		return 0
+	}
+	if editDistance(term, word) <= allowed {
+		return 0.6
+	}
+	return 0
+}
+
+// searchField is a product field that keyword search looks at; matches in the name count most.
+type searchField struct {
+	name    string
+	text    string
+	weight  float64
+	runes   []rune
+	words   []searchWord
+	matched []bool
+}
+
+// rankSearchResults keeps the products that match every query term, fills in their highlights and, unless another
+// sort order was asked for, orders them by relevance with the best rated first among equals.
+func rankSearchResults(products []models.Product, terms []string, keepOrder bool) []models.Product {
+	type scored struct {
+		product models.Product
+		score   float64
+	}
+	var results []scored
+
+	for _, p := range products {
+		fields := []*searchField{
+			{name: "name", text: p.Name, weight: 3},
+			{name: "tags", text: strings.Join(p.Tags, ", "), weight: 2},
+			{name: "description", text: p.Description, weight: 1},
+		}
+		for _, field := range fields {
+			field.runes = []rune(field.text)
+			field.words = splitWords(field.runes)
+			field.matched = make([]bool, len(field.words))
+		}
+
+		var score float64
+		matchesAll := true
+		for _, term := range terms {
+			var best float64
+			for _, field := range fields {
+				for i, word := range field.words {
+					if quality := termMatch(term, word.normalized); quality > 0 {
+						field.matched[i] = true
+						best = max(best, quality*field.weight)
+					}
+				}
+			}
+			if best == 0 {
+				matchesAll = false
+				break
+			}
+			score += best
+		}
+		if !matchesAll {
+			continue
+		}
+
+		for _, field := range fields {
+			if fragment := highlight(field); fragment != "" {
+				p.Highlights = append(p.Highlights, models.SearchHighlight{Field: field.name, Fragment: fragment})
+			}
+		}
+		results = append(results, scored{product: p, score: score})
+	}
+
+	if !keepOrder {
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].score != results[j].score {
+				return results[i].score > results[j].score
+			}
+			return results[i].product.Rating > results[j].product.Rating
+		})
+	}
+	ranked := make([]models.Product, len(results))
+	for i, result := range results {
+		ranked[i] = result.product
+	}
+	return ranked
+}
+
+// highlight renders the matched words of a field, cutting long text down to a fragment around the first match.
+func highlight(field *searchField) string {
+	first := -1
+	for i, matched := range field.matched {
+		if matched {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	from, to := 0, len(field.runes)
+	if to > maxFragmentRunes {
+		from = max(field.words[first].start-highlightContextRunes, 0)
+		to = min(from+maxFragmentRunes, len(field.runes))
+		for from > 0 && isWordRune(field.runes[from-1]) && from < field.words[first].start {
+			from++
+		}
+		for to < len(field.runes) && to > field.words[first].end && isWordRune(field.runes[to]) {
+			to--
+		}
+	}
+
+	var b strings.Builder
+	pos := from
+	for i, word := range field.words {
+		if !field.matched[i] || word.start < from || word.end > to {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(field.runes[pos:word.start])))
+		b.WriteString("<em>" + html.EscapeString(string(field.runes[word.start:word.end])) + "</em>")
+		pos = word.end
+	}
+	b.WriteString(html.EscapeString(string(field.runes[pos:to])))
+
+	fragment := strings.TrimSpace(b.String())
+	if from > 0 {
+		fragment = "…" + fragment
+	}
+	if to < len(field.runes) {
+		fragment += "…"
+	}
+	return fragment
+}
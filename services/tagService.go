@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AryaTabani/Dorivo/models"
+	"github.com/AryaTabani/Dorivo/repository"
+)
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("a tag with this name already exists; merge the tags instead")
+	ErrInvalidTag  = errors.New("invalid tag")
+	ErrTagInUse    = errors.New("tag is used by promotions")
+)
+
+// maxTagNameLength is the length of tags.name.
+const maxTagNameLength = 150
+
+// normalizeTagName trims a tag name and checks it fits. Commas are refused because tag lists are joined and split on
+// them, in product listings as well as in the tags filter.
+func normalizeTagName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidTag)
+	}
+	if utf8.RuneCountInString(name) > maxTagNameLength {
+		return "", fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidTag, name, maxTagNameLength)
+	}
+	if strings.Contains(name, ",") {
+		return "", fmt.Errorf("%w: %q contains a comma", ErrInvalidTag, name)
+	}
+	return name, nil
+}
+
+func CreateTag(ctx context.Context, tenantID string, payload *models.TagPayload) (*models.Tag, error) {
+	name, err := normalizeTagName(payload.Name)
+	if err != nil {
+		return nil, err
+	}
+	payload.Name = name
+	_, err = repository.GetTagByName(ctx, nil, tenantID, name)
+	if err == nil {
+		return nil, ErrTagExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	category, err := resolveCategory(ctx, nil, tenantID, payload.CategoryID, payload.MainCategory)
+	if err != nil {
+		return nil, err
+	}
+	payload.CategoryID, payload.MainCategory = &category.ID, category.Name
+
+	tagID, err := repository.CreateTag(ctx, nil, tenantID, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Tag{ID: tagID, Name: name, CategoryID: payload.CategoryID, MainCategory: payload.MainCategory}, nil
+}
+
+// UpdateTag renames a tag or moves it to another category. A new name shows up on every product of the tag and
+// in the restrictions of promotions using it; renaming onto another tag's name is refused, as that is a merge.
+func UpdateTag(ctx context.Context, tenantID string, tagID int64, payload *models.TagPayload) error {
+	name, err := normalizeTagName(payload.Name)
+	if err != nil {
+		return err
+	}
+	payload.Name = name
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	tag, err := getTag(ctx, tx, tenantID, tagID)
+	if err != nil {
+		return err
+	}
+	existing, err := repository.GetTagByName(ctx, tx, tenantID, name)
+	if err == nil && existing.ID != tagID {
+		return ErrTagExists
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	category, err := resolveCategory(ctx, tx, tenantID, payload.CategoryID, payload.MainCategory)
+	if err != nil {
+		return err
+	}
+	payload.CategoryID, payload.MainCategory = &category.ID, category.Name
+
+	if err := repository.UpdateTag(ctx, tx, tenantID, tagID, payload); err != nil {
+		return err
+	}
+	if tag.Name != name {
+		if err := repository.RenamePromotionTag(ctx, tx, tenantID, tag.Name, name); err != nil {
+			return err
+		}
+		productIDs, err := repository.GetTagProductIDs(ctx, tx, tagID)
+		if err != nil {
+			return err
+		}
+		if err := repository.RefreshProductSearchText(ctx, tx, productIDs...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// MergeTag folds a tag into another one: its products and the promotions restricted to it move over to the other tag,
+// and the tag itself is deleted.
+func MergeTag(ctx context.Context, tenantID string, tagID int64, payload *models.MergeTagPayload) error {
+	if payload.IntoTagID == tagID {
+		return fmt.Errorf("%w: a tag cannot be merged into itself", ErrInvalidTag)
+	}
+
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	tag, err := getTag(ctx, tx, tenantID, tagID)
+	if err != nil {
+		return err
+	}
+	into, err := getTag(ctx, tx, tenantID, payload.IntoTagID)
+	if err != nil {
+		return err
+	}
+	productIDs, err := repository.GetTagProductIDs(ctx, tx, tagID)
+	if err != nil {
+		return err
+	}
+
+	if err := repository.MergeTag(ctx, tx, tenantID, tagID, into.ID); err != nil {
+		return err
+	}
+	if err := repository.RenamePromotionTag(ctx, tx, tenantID, tag.Name, into.Name); err != nil {
+		return err
+	}
+	if err := repository.RefreshProductSearchText(ctx, tx, productIDs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteTag removes a tag from the store and its products. Tags that promotions are restricted to cannot be deleted,
+// since the promotions would then apply to everything; they can be merged into another tag instead.
+func DeleteTag(ctx context.Context, tenantID string, tagID int64) error {
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	tag, err := getTag(ctx, tx, tenantID, tagID)
+	if err != nil {
+		return err
+	}
+	codes, err := repository.PromotionsUsingTag(ctx, tx, tenantID, tag.Name)
+	if err != nil {
+		return err
+	}
+	if len(codes) > 0 {
+		return fmt.Errorf("%w: %s", ErrTagInUse, strings.Join(codes, ", "))
+	}
+	productIDs, err := repository.GetTagProductIDs(ctx, tx, tagID)
+	if err != nil {
+		return err
+	}
+
+	if err := repository.DeleteTag(ctx, tx, tenantID, tagID); err != nil {
+		return err
+	}
+	if err := repository.RefreshProductSearchText(ctx, tx, productIDs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func getTag(ctx context.Context, tx *sql.Tx, tenantID string, tagID int64) (*models.Tag, error) {
+	tag, err := repository.GetTagByID(ctx, tx, tenantID, tagID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTagNotFound
+	}
+	return tag, err
+}
+
+// normalizeTagNames checks the tag names of a product payload and drops repeats, which the store would treat as the
+// same tag anyway.
+func normalizeTagNames(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name, err := normalizeTagName(name)
+		if err != nil {
+			return nil, err
+		}
+		duplicate := false
+		for _, kept := range normalized {
+			if strings.EqualFold(kept, name) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized, nil
+}
+
+// setProductTags gives a product the named tags, creating the ones the store does not have yet in the product's
+// category.
+func setProductTags(ctx context.Context, tx *sql.Tx, tenantID string, productID int64, names []string, category *models.Category) error {
+	tagIDs := make([]int64, 0, len(names))
+	for _, name := range names {
+		tag, err := repository.GetTagByName(ctx, tx, tenantID, name)
+		if err == nil {
+			tagIDs = append(tagIDs, tag.ID)
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		tagID, err := repository.CreateTag(ctx, tx, tenantID, &models.TagPayload{Name: name, CategoryID: &category.ID, MainCategory: category.Name})
+		if err != nil {
+			return err
+		}
+		tagIDs = append(tagIDs, tagID)
+	}
+	return repository.SetProductTags(ctx, tx, productID, tagIDs)
+}
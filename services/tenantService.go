@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	db "github.com/AryaTabani/Dorivo/DB"
@@ -14,6 +15,7 @@ import (
 )
 
 var ErrTenantNotFound = errors.New("tenant not found")
+var ErrCurrencyLocked = errors.New("the currency cannot be changed once the store has products, promotions or orders")
 
 func GetTenantConfig(ctx context.Context, id string) (*models.TenantConfig, error) {
 	cacheKey := fmt.Sprintf("tenant_config:%s", id)
@@ -22,6 +24,7 @@ func GetTenantConfig(ctx context.Context, id string) (*models.TenantConfig, erro
 	if err == nil {
 		var config models.TenantConfig
 		if json.Unmarshal([]byte(val), &config) == nil {
+			applyMoneyDefaults(&config)
 			return &config, nil
 		}
 	} else if err != redis.Nil {
@@ -41,5 +44,25 @@ func GetTenantConfig(ctx context.Context, id string) (*models.TenantConfig, erro
 		db.Rdb.Set(db.Ctx, cacheKey, configJSON, 10*time.Minute)
 	}
 
+	applyMoneyDefaults(&tenant.Config)
 	return &tenant.Config, nil
 }
+
+func applyMoneyDefaults(config *models.TenantConfig) {
+	if config.Currency == "" {
+		config.Currency = models.DefaultCurrency
+	}
+	config.Currency = strings.ToUpper(config.Currency)
+	if config.Rounding == "" {
+		config.Rounding = models.DefaultRounding
+	}
+}
+
+// tenantMoney returns the currency and rounding rule of a tenant.
+func tenantMoney(ctx context.Context, tenantID string) (string, models.RoundingMode, error) {
+	config, err := GetTenantConfig(ctx, tenantID)
+	if err != nil {
+		return "", "", err
+	}
+	return config.Currency, config.Rounding, nil
+}
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
@@ -16,12 +19,18 @@ import (
 
 var jwtSecret = []byte(os.Getenv("JWT_SECRET_KEY"))
 var (
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrUserExists         = errors.New("a user with this email address already exists")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidCredentials       = errors.New("invalid email or password")
+	ErrUserExists               = errors.New("a user with this email address already exists")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrInvalidResetToken        = errors.New("invalid or expired password reset token")
+	ErrPasswordResetUnavailable = errors.New("password reset is not set up for this store")
 )
 
-func RegisterUser(ctx context.Context, tenantID string, payload *models.RegisterPayload) (*models.User, error) {
+const passwordResetTokenTTL = time.Hour
+
+// RegisterUser creates a customer account. When cartToken names a guest cart, its items are moved into the new
+// user's cart.
+func RegisterUser(ctx context.Context, tenantID string, payload *models.RegisterPayload, cartToken string) (*models.User, error) {
 	_, err := repository.GetUserByEmailAndTenant(ctx, payload.Email, tenantID)
 	if err == nil {
 		return nil, ErrUserExists
@@ -37,6 +46,7 @@ func RegisterUser(ctx context.Context, tenantID string, payload *models.Register
 
 	newUser := &models.User{
 		TenantID:      tenantID,
+		Role:          "CUSTOMER",
 		Full_name:     payload.Full_name,
 		Email:         payload.Email,
 		Mobile_number: payload.Mobile_number,
@@ -47,11 +57,14 @@ func RegisterUser(ctx context.Context, tenantID string, payload *models.Register
 	if err := repository.CreateUser(ctx, newUser); err != nil {
 		return nil, fmt.Errorf("could not create user: %w", err)
 	}
+	mergeGuestCartAfterLogin(ctx, newUser.ID, tenantID, cartToken)
 
 	return newUser, nil
 }
 
-func LoginUser(ctx context.Context, tenantID string, payload *models.LoginPayload) (string, error) {
+// LoginUser checks the credentials and returns a JWT. When cartToken names a guest cart, its items are merged into the
+// user's cart.
+func LoginUser(ctx context.Context, tenantID string, payload *models.LoginPayload, cartToken string) (string, error) {
 	user, err := repository.GetUserByEmailAndTenant(ctx, payload.Email, tenantID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -64,6 +77,7 @@ func LoginUser(ctx context.Context, tenantID string, payload *models.LoginPayloa
 	if err != nil {
 		return "", ErrInvalidCredentials
 	}
+	mergeGuestCartAfterLogin(ctx, user.ID, tenantID, cartToken)
 
 	return generateUserToken(user.ID, tenantID, user.Role)
 }
@@ -117,8 +131,88 @@ func ChangePassword(ctx context.Context, userID int64, payload *models.ChangePas
 	if err != nil {
 		return fmt.Errorf("could not hash new password: %w", err)
 	}
-	return repository.UpdateUserPassword(ctx, userID, string(newHashedPassword))
+	return repository.UpdateUserPassword(ctx, nil, userID, string(newHashedPassword))
 }
 func DeleteAccount(ctx context.Context, userID int64) error {
 	return repository.DeleteUserByID(ctx, userID)
 }
+
+// RequestPasswordReset e-mails a single-use reset link to the user. Unknown addresses are ignored silently
+// so the endpoint cannot be used to discover which e-mails are registered.
+func RequestPasswordReset(ctx context.Context, tenantID string, email string) error {
+	// Checked first so that a store without a reset page answers the same for registered and unknown addresses.
+	resetURL, err := passwordResetURL(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	user, err := repository.GetUserByEmailAndTenant(ctx, email, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return fmt.Errorf("could not generate reset token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	expiresAt := time.Now().UTC().Add(passwordResetTokenTTL)
+	if err := repository.CreatePasswordResetToken(ctx, user.ID, hashToken(token), expiresAt); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %d minutes.\n\n%s?token=%s\n\nIf you did not ask for a password reset you can ignore this e-mail.",
+		user.Full_name, int(passwordResetTokenTTL.Minutes()), resetURL, token)
+
+	return sendMail(ctx, MailMessage{To: user.Email, Subject: "Reset your password", Body: body})
+}
+
+func ResetPassword(ctx context.Context, tenantID string, payload *models.ResetPasswordPayload) error {
+	tokenHash := hashToken(payload.Token)
+	userID, expiresAt, err := repository.GetPasswordResetToken(ctx, tokenHash, tenantID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	if time.Now().After(expiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	newHashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("could not hash new password: %w", err)
+	}
+
+	// The token is only used up together with the password change, so a failed update leaves it valid for a retry.
+	tx, err := repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	consumed, err := repository.DeletePasswordResetToken(ctx, tx, tokenHash)
+	if err != nil {
+		return err
+	}
+	if consumed == 0 {
+		return ErrInvalidResetToken
+	}
+	if err := repository.UpdateUserPassword(ctx, tx, userID, string(newHashedPassword)); err != nil {
+		return err
+	}
+	if err := repository.DeletePasswordResetTokensByUserID(ctx, tx, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}